@@ -1,18 +1,22 @@
 package wl
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net"
+	"os"
 	"runtime"
+	"sync/atomic"
 
 	"deedles.dev/wl/internal/debug"
+	"deedles.dev/wl/internal/limit"
 	"deedles.dev/wl/internal/objstore"
 	"deedles.dev/wl/wire"
 	"deedles.dev/xsync"
 )
 
-//go:generate go run deedles.dev/wl/cmd/wlgen -client -out protocol.go -xml ../protocol/wayland.xml
+//go:generate go run deedles.dev/wl/cmd/wlgen -role client -out protocol.go -builtin wayland
 
 // Client tracks the connection state, including objects and the event
 // queue. It is the primary interface to a Wayland server.
@@ -20,14 +24,29 @@ type Client struct {
 	conn  *wire.Conn
 	stop  xsync.Stopper
 	queue xsync.Queue[func() error]
+	limit limit.Limiter
 	store *objstore.Store
+	perr  atomic.Pointer[ProtocolError]
+
+	// polled is set for clients created by NewPolledClient, which
+	// have no goroutine of their own and are driven by ReadEvents and
+	// DispatchPending instead of the event queue.
+	polled  bool
+	pending []*wire.MessageBuffer
+	errs    []error
 }
 
 // Dial opens a connection to the Wayland display based on the
 // current environment. It follows the procedure outlined at
 // https://wayland-book.com/protocol-design/wire-protocol.html#transports
 func Dial() (*Client, error) {
-	c, err := wire.Dial()
+	return DialContext(context.Background())
+}
+
+// DialContext is like Dial, but gives up on connecting when ctx is
+// done.
+func DialContext(ctx context.Context) (*Client, error) {
+	c, err := wire.DialContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -38,12 +57,45 @@ func Dial() (*Client, error) {
 // NewClient creates a new client that wraps conn. The returned client
 // assumes responsibility for closing conn.
 func NewClient(conn *wire.Conn) *Client {
+	client := newClient(conn, false)
+	go client.listen()
+
+	return client
+}
+
+// NewPolledClient creates a new client that wraps conn, the same as
+// NewClient, but without starting a goroutine to read from conn. It
+// is meant for integrating with an external event loop, similar to
+// libwayland's wl_display_read_events and
+// wl_display_dispatch_pending. Whenever the file descriptor returned
+// by Fd becomes readable, call ReadEvents to read the messages that
+// have arrived and then DispatchPending to handle them. Requests are
+// added to the connection's output buffer instead of the event queue,
+// so Flush must be called before waiting for the file descriptor to
+// become readable, as with wl_display_flush. Events is never sent
+// anything.
+//
+// As ReadEvents never blocks, there is no need for an equivalent of
+// wl_display_prepare_read and wl_display_cancel_read. A polled client
+// is not safe for concurrent use.
+func NewPolledClient(conn *wire.Conn) *Client {
+	return newClient(conn, true)
+}
+
+func newClient(conn *wire.Conn, polled bool) *Client {
+	if debug.Client && (conn.Logger() == nil) {
+		conn.SetLogger(os.Stderr)
+	}
+
 	client := Client{
-		conn:  conn,
-		store: objstore.New(1),
+		conn:   conn,
+		store:  objstore.New(wire.ClientIDMin, wire.ClientIDMax),
+		polled: polled,
 	}
-	client.Add(NewDisplay(&client))
-	go client.listen()
+
+	display := NewDisplay(&client)
+	display.SetVersion(DisplayVersion)
+	client.Add(display)
 
 	return &client
 }
@@ -52,6 +104,10 @@ func (client *Client) listen() {
 	defer client.Close()
 
 	for {
+		if !client.limit.Wait(client.stop.Done()) {
+			return
+		}
+
 		msg, err := wire.ReadMessage(client.conn)
 		if err != nil {
 			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
@@ -66,12 +122,104 @@ func (client *Client) listen() {
 			}
 		}
 
+		client.limit.Add()
 		select {
 		case <-client.stop.Done():
 			return
-		case client.queue.Push() <- func() error { return client.dispatch(msg) }:
-			// TODO: Limit number of queued incoming messages?
+		case client.queue.Push() <- client.dispatchFunc(msg):
+		}
+	}
+}
+
+// Fd returns the file descriptor of the client's connection. See
+// wire.Conn.Fd for details.
+func (client *Client) Fd() int {
+	return client.conn.Fd()
+}
+
+// Flush sends any requests that are waiting in the connection's
+// output buffer. This is only necessary for a client created with
+// NewPolledClient.
+func (client *Client) Flush() error {
+	return client.conn.Flush()
+}
+
+// ReadEvents reads the messages that are available on the connection
+// without blocking and adds them to the list of pending events to be
+// handled by DispatchPending. It is only useful with a client created
+// by NewPolledClient, and should be called when the file
+// descriptor returned by Fd becomes readable. If the server has closed
+// the connection, the returned error wraps wire.ErrDisconnected.
+func (client *Client) ReadEvents() error {
+	msgs, err := wire.ReadAvailable(client.conn)
+	client.pending = append(client.pending, msgs...)
+	return err
+}
+
+// DispatchPending handles all of the events that have been read by
+// ReadEvents but not yet dispatched, in the order that they arrived.
+// It does not read from the connection. The returned error joins the
+// errors from dispatching each event, as well as any errors from
+// sending requests since the last call.
+func (client *Client) DispatchPending() error {
+	errs := client.errs
+	client.errs = nil
+
+	for len(client.pending) > 0 {
+		msg := client.pending[0]
+		client.pending[0] = nil
+		client.pending = client.pending[1:]
+
+		errs = append(errs, client.dispatch(msg))
+	}
+
+	return errors.Join(errs...)
+}
+
+// roundTripPolled implements RoundTripContext for a polled client by
+// reading from the connection until the sync callback has been
+// dispatched.
+func (client *Client) roundTripPolled(ctx context.Context) error {
+	var done bool
+	client.Display().Sync().Then(func(uint32) { done = true })
+
+	var errs []error
+	for {
+		errs = append(errs, client.DispatchPending())
+		if done || (client.Err() != nil) {
+			return errors.Join(errs...)
+		}
+
+		if err := client.Flush(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		msg, err := wire.ReadMessageContext(ctx, client.conn)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		client.pending = append(client.pending, msg)
+	}
+}
+
+// runPolled implements Run for a polled client.
+func (client *Client) runPolled(ctx context.Context) error {
+	for {
+		if err := client.DispatchPending(); err != nil {
+			return err
+		}
+		if err := client.Flush(); err != nil {
+			return err
 		}
+
+		msg, err := wire.ReadMessageContext(ctx, client.conn)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		client.pending = append(client.pending, msg)
 	}
 }
 
@@ -102,29 +250,114 @@ func (client *Client) Get(id uint32) wire.Object {
 }
 
 // Delete deletes the object identified by ID, if it exists. If the
-// object has a delete handler specified, it is called.
+// object has a delete handler specified, it is called. The ID may be
+// reused for a new object afterwards, so this should only be called
+// once the server has confirmed the deletion with a
+// wl_display.delete_id event.
 func (client *Client) Delete(id uint32) {
 	client.store.Delete(id)
 }
 
 // DeleteAll removes all objects from the client, running their delete
-// handlers where applicable. This is not done automatically, so if
-// you want the handlers to be run when, for example, the client
-// disconnects you must call this yourself.
+// handlers where applicable, and resets object ID allocation. This is
+// not done automatically, so if you want the handlers to be run when,
+// for example, the client disconnects you must call this yourself.
+// It is the same as Reset, but ignores errors.
 func (client *Client) DeleteAll() {
-	client.store.Clear()
+	client.Reset()
+}
+
+// Reset removes all objects from the client and resets object ID
+// allocation, the same as DeleteAll. Before each object is deleted,
+// the cleanup functions registered for it with AddCleanup are run,
+// which closes any file descriptors that it holds, such as ones
+// received in events that it had no handler for. Any errors from them
+// are returned.
+//
+// The Display is deleted along with everything else, but is then
+// added back with the same ID, as it exists for as long as the
+// connection does.
+func (client *Client) Reset() error {
+	display, _ := client.Get(1).(*Display)
+	err := client.store.Reset()
+	if display != nil {
+		client.store.Add(display)
+	}
+	return err
+}
+
+// Err returns the ProtocolError that the server has reported, if
+// any. A client that has received one is no longer usable.
+func (client *Client) Err() error {
+	if perr := client.perr.Load(); perr != nil {
+		return *perr
+	}
+	return nil
 }
 
 func (client *Client) dispatch(msg *wire.MessageBuffer) error {
+	if (msg.Sender() == 1) && (msg.Op() == 0) {
+		perr, err := client.readProtocolError(msg)
+		if err == nil {
+			client.perr.CompareAndSwap(nil, perr)
+			return errors.Join(*perr, client.store.Dispatch(msg))
+		}
+	}
+
 	return client.store.Dispatch(msg)
 }
 
-// Enqueue adds msg to the event queue.
+// dispatchFunc returns a function that dispatches msg and then
+// releases its slot in the queue limit.
+func (client *Client) dispatchFunc(msg *wire.MessageBuffer) func() error {
+	return func() error {
+		defer client.limit.Done()
+		return client.dispatch(msg)
+	}
+}
+
+// SetQueueLimit sets the maximum number of incoming messages that can
+// be waiting in the event queue to be dispatched. While the limit is
+// reached, no more messages are read from the connection until some
+// of the pending ones have been handled. This keeps slow handling of
+// events from causing an unbounded amount of memory to be used, with
+// the remote end eventually being blocked from sending by the
+// socket's buffer filling up instead. A limit of zero or less, the
+// default, disables the check.
+func (client *Client) SetQueueLimit(n int) {
+	client.limit.SetLimit(n)
+}
+
+// Enqueue adds msg to the event queue. For a client created with
+// NewPolledClient, msg is added to the connection's output buffer
+// instead, with any error being returned by the next call to
+// DispatchPending.
+//
+// Once the server has reported a ProtocolError, msg is discarded
+// instead of being sent, and the ProtocolError is returned by the
+// function in the event queue or by DispatchPending in its place.
 func (client *Client) Enqueue(msg *wire.MessageBuilder) {
+	if client.polled {
+		if err := client.Err(); err != nil {
+			client.errs = append(client.errs, err)
+			return
+		}
+
+		client.conn.Tracef(" -> %v", msg)
+		if err := msg.BuildBuffered(client.conn); err != nil {
+			client.errs = append(client.errs, err)
+		}
+		return
+	}
+
 	select {
 	case <-client.stop.Done():
 	case client.queue.Push() <- func() error {
-		debug.Printf(" -> %v", msg)
+		if err := client.Err(); err != nil {
+			return err
+		}
+
+		client.conn.Tracef(" -> %v", msg)
 		return msg.Build(client.conn)
 	}:
 	}
@@ -143,11 +376,33 @@ func (client *Client) Events() <-chan func() error {
 
 // RoundTrip flushes the event queue continuously until the server
 // indicates that it has finished processing all messages sent by the
-// call to this method.
+// call to this method. For a client created with NewPolledClient,
+// it instead blocks reading from the connection and dispatching the
+// events that arrive until that happens.
 //
 // If the client's connection has been closed, Flush returns
 // net.ErrClosed.
 func (client *Client) RoundTrip() error {
+	return client.RoundTripContext(context.Background())
+}
+
+// RoundTripContext is like RoundTrip, but stops waiting when ctx is
+// done, in which case the context's error is returned along with any
+// errors from events that were handled before then. The server's
+// reply may still arrive afterwards, but will be ignored.
+//
+// If the server reports a ProtocolError, which means that it will
+// never reply, RoundTripContext returns it without waiting any
+// longer. Once that has happened, it always fails immediately with
+// the same error.
+func (client *Client) RoundTripContext(ctx context.Context) error {
+	if err := client.Err(); err != nil {
+		return err
+	}
+	if client.polled {
+		return client.roundTripPolled(ctx)
+	}
+
 	select {
 	case <-client.stop.Done():
 		return net.ErrClosed
@@ -156,7 +411,7 @@ func (client *Client) RoundTrip() error {
 
 	done := make(chan struct{})
 	get := client.queue.Pop()
-	defer func() { runtime.KeepAlive(client.queue) }()
+	defer func() { runtime.KeepAlive(&client.queue) }()
 	client.Display().Sync().Then(func(uint32) {
 		close(done)
 		get = nil
@@ -166,12 +421,50 @@ func (client *Client) RoundTrip() error {
 
 	for {
 		select {
+		case <-ctx.Done():
+			return errors.Join(append(errs, ctx.Err())...)
 		case <-client.stop.Done():
 			return net.ErrClosed
 		case <-done:
 			return errors.Join(errs...)
 		case ev := <-get:
 			errs = append(errs, ev())
+			if client.Err() != nil {
+				return errors.Join(errs...)
+			}
+		}
+	}
+}
+
+// Run handles events until ctx is done or the client is closed. It is
+// an alternative to reading from Events manually for programs that
+// don't need to wait for anything else at the same time. If an event
+// returns an error, Run stops and returns it, leaving the client
+// usable, so it can be called again to continue. When ctx is done,
+// the context's error is returned. When the client is closed, Run
+// returns nil.
+//
+// For a client created with NewPolledClient, Run reads from the
+// connection itself, blocking until messages arrive, which makes it
+// possible to switch between the two styles of handling events.
+func (client *Client) Run(ctx context.Context) error {
+	if client.polled {
+		return client.runPolled(ctx)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-client.stop.Done():
+			return nil
+		case ev, ok := <-client.queue.Pop():
+			if !ok {
+				return nil
+			}
+			if err := ev(); err != nil {
+				return err
+			}
 		}
 	}
 }
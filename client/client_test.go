@@ -0,0 +1,140 @@
+package wl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"deedles.dev/wl/internal/bin"
+	"deedles.dev/wl/wire"
+)
+
+// newTestClient returns a polled client connected to the returned
+// Conn, which acts as the server.
+func newTestClient(t *testing.T) (*Client, *wire.Conn) {
+	t.Helper()
+
+	c, s, err := wire.SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewPolledClient(wire.NewConn(c))
+	server := wire.NewConn(s)
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+// receivedCounter counts the messages received in a recording made
+// with wire.Conn.Record.
+type receivedCounter struct {
+	m sync.Mutex
+	n int
+}
+
+func (c *receivedCounter) Write(frame []byte) (int, error) {
+	if wire.Direction(bin.Value[uint32]([4]byte(frame))) == wire.Received {
+		c.m.Lock()
+		c.n++
+		c.m.Unlock()
+	}
+	return len(frame), nil
+}
+
+func (c *receivedCounter) count() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.n
+}
+
+func TestQueueLimit(t *testing.T) {
+	const (
+		limit = 3
+		total = 10
+	)
+
+	c, s, err := wire.SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, server := wire.NewConn(c), wire.NewConn(s)
+	defer server.Close()
+
+	var received receivedCounter
+	conn.Record(&received)
+	client := NewClient(conn)
+	defer client.Close()
+	client.SetQueueLimit(limit)
+
+	// The events are for an object that the client doesn't have, so
+	// handling them fails, but they still take up room in the queue
+	// until they are handled.
+	sender := NewCallback(nil)
+	sender.SetID(100)
+	for i := range total {
+		done := wire.NewMessage(sender, 0)
+		done.WriteUint(uint32(i))
+		if err := done.Build(server); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for received.count() < limit {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %v messages were read", received.count())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Handle the events slowly, checking each time that the client
+	// hasn't read further ahead than the limit allows.
+	for handled := range total {
+		time.Sleep(10 * time.Millisecond)
+		if n := received.count(); n-handled > limit {
+			t.Fatalf("%v messages read with %v handled", n, handled)
+		}
+
+		select {
+		case ev := <-client.Events():
+			ev()
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %v", handled)
+		}
+	}
+	if n := received.count(); n != total {
+		t.Fatalf("%v messages read, want %v", n, total)
+	}
+}
+
+func TestMissingFDs(t *testing.T) {
+	client, _ := newTestClient(t)
+	keyboard := NewKeyboard(client)
+	client.Add(keyboard)
+
+	// A keymap event needs a file descriptor, but one read from a
+	// plain reader has none available.
+	data := make([]byte, wire.HeaderSize+8)
+	wire.EncodeHeader(data, keyboard.ID(), 0, uint16(len(data)))
+	binary.NativeEndian.PutUint32(data[8:], uint32(KeyboardKeymapFormatXkbV1))
+	msg, err := wire.ReadMessageFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = keyboard.Dispatch(msg)
+	var missing wire.MissingFDsError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected a MissingFDsError, got %v", err)
+	}
+	want := wire.MissingFDsError{Interface: "wl_keyboard", Method: "keymap", Want: 1, Have: 0}
+	if missing != want {
+		t.Fatalf("got %+v, want %+v", missing, want)
+	}
+}
@@ -0,0 +1,61 @@
+package wl
+
+import (
+	"fmt"
+
+	"deedles.dev/wl/wire"
+)
+
+// ProtocolError is a fatal error reported by the server in a
+// wl_display.error event. After sending one, the server disconnects
+// the client, so once a ProtocolError has been received the client
+// refuses to send any further requests, failing them with the same
+// error instead.
+type ProtocolError struct {
+	// ObjectID is the ID of the object that the error occurred on.
+	ObjectID uint32
+
+	// Interface is the name of the interface of the object that the
+	// error occurred on, or an empty string if the client does not
+	// know of an object with that ID.
+	Interface string
+
+	// Code is the error code. Its meaning depends on Interface, as
+	// each interface defines its own error enum.
+	Code uint32
+
+	// Message is a description of the error meant for debugging.
+	Message string
+}
+
+func (err ProtocolError) Error() string {
+	iface := err.Interface
+	if iface == "" {
+		iface = "unknown object"
+	}
+	return fmt.Sprintf("protocol error on %v@%v: code %v: %v", iface, err.ObjectID, err.Code, err.Message)
+}
+
+// readProtocolError decodes the arguments of a wl_display.error event
+// from a clone of msg so that msg itself can still be dispatched
+// normally.
+func (client *Client) readProtocolError(msg *wire.MessageBuffer) (*ProtocolError, error) {
+	msg, err := msg.Clone(0)
+	if err != nil {
+		return nil, err
+	}
+
+	perr := ProtocolError{
+		ObjectID: msg.ReadUint(),
+		Code:     msg.ReadUint(),
+		Message:  msg.ReadString(),
+	}
+	if err := msg.Err(); err != nil {
+		return nil, err
+	}
+
+	if obj, ok := client.Get(perr.ObjectID).(interface{ Interface() string }); ok {
+		perr.Interface = obj.Interface()
+	}
+	return &perr, nil
+}
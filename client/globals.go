@@ -0,0 +1,125 @@
+package wl
+
+import (
+	"fmt"
+	"slices"
+
+	"deedles.dev/wl/wire"
+)
+
+// Global is a global object advertised by the server.
+type Global struct {
+	Name      uint32
+	Interface string
+	Version   uint32
+}
+
+// Globals keeps track of the globals advertised by the server. It
+// implements RegistryListener, so it can be used directly as the
+// Listener of a Registry to keep it up to date automatically.
+//
+// The zero value is ready to use.
+type Globals struct {
+	// OnAdd, if not nil, is called whenever a global is added,
+	// including when one replaces an existing global with the same
+	// name.
+	OnAdd func(Global)
+
+	// OnRemove, if not nil, is called whenever a global is removed.
+	OnRemove func(Global)
+
+	globals []Global
+}
+
+// Add adds a global. If a global with the same name already exists,
+// it is replaced.
+func (g *Globals) Add(name uint32, inter string, version uint32) {
+	global := Global{Name: name, Interface: inter, Version: version}
+
+	i := slices.IndexFunc(g.globals, func(global Global) bool { return global.Name == name })
+	if i >= 0 {
+		g.globals[i] = global
+	} else {
+		g.globals = append(g.globals, global)
+	}
+
+	if g.OnAdd != nil {
+		g.OnAdd(global)
+	}
+}
+
+// Remove removes the global with the given name, if it exists.
+func (g *Globals) Remove(name uint32) {
+	i := slices.IndexFunc(g.globals, func(global Global) bool { return global.Name == name })
+	if i < 0 {
+		return
+	}
+
+	global := g.globals[i]
+	g.globals = slices.Delete(g.globals, i, i+1)
+	if g.OnRemove != nil {
+		g.OnRemove(global)
+	}
+}
+
+// All returns all of the globals in the order in which they were
+// added.
+func (g *Globals) All() []Global {
+	return slices.Clone(g.globals)
+}
+
+// Find returns the name and version of a global that implements the
+// given interface. If there is more than one, the one that was added
+// first is returned.
+func (g *Globals) Find(inter string) (name, version uint32, ok bool) {
+	i := slices.IndexFunc(g.globals, func(global Global) bool { return global.Interface == inter })
+	if i < 0 {
+		return 0, 0, false
+	}
+	return g.globals[i].Name, g.globals[i].Version, true
+}
+
+// Global implements RegistryListener by calling Add.
+func (g *Globals) Global(name uint32, inter string, version uint32) {
+	g.Add(name, inter, version)
+}
+
+// GlobalRemove implements RegistryListener by calling Remove.
+func (g *Globals) GlobalRemove(name uint32) {
+	g.Remove(name)
+}
+
+// Bindable is implemented by the types generated for interfaces that
+// can be bound as globals.
+type Bindable interface {
+	wire.Object
+	Interface() string
+	MaxVersion() uint32
+	SetVersion(uint32)
+}
+
+// Bind binds the first global in g that implements the interface of
+// the objects returned by newObj, such as NewOutput, and returns the
+// new object. The version bound is the highest supported by both the
+// global and the object's type. If g has no global for the interface,
+// or if no version is supported by both, an error is returned and
+// nothing is bound.
+func Bind[T Bindable](client *Client, registry wire.Binder, g *Globals, newObj func(wire.State) T) (T, error) {
+	obj := newObj(client)
+	name, version, ok := g.Find(obj.Interface())
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("no global implements %v", obj.Interface())
+	}
+
+	v := wire.NegotiateVersion(obj.MaxVersion(), version)
+	if v == 0 {
+		var zero T
+		return zero, wire.VersionError{Interface: obj.Interface(), Local: obj.MaxVersion(), Remote: version}
+	}
+
+	obj.SetVersion(v)
+	client.Add(obj)
+	registry.Bind(name, wire.NewID{Interface: obj.Interface(), Version: v, ID: obj.ID()})
+	return obj, nil
+}
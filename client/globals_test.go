@@ -0,0 +1,97 @@
+package wl
+
+import (
+	"slices"
+	"testing"
+
+	"deedles.dev/wl/wire"
+)
+
+func TestGlobals(t *testing.T) {
+	client, server := newTestClient(t)
+	registry := client.Display().GetRegistry()
+
+	var g Globals
+	var added, removed []uint32
+	g.OnAdd = func(global Global) { added = append(added, global.Name) }
+	g.OnRemove = func(global Global) { removed = append(removed, global.Name) }
+	registry.Listener = &g
+
+	global := func(name uint32, inter string, version uint32) {
+		t.Helper()
+
+		mb := wire.NewMessage(registry, 0)
+		mb.WriteUint(name)
+		mb.WriteString(inter)
+		mb.WriteUint(version)
+		if err := mb.Build(server); err != nil {
+			t.Fatal(err)
+		}
+		dispatch(t, client)
+	}
+	globalRemove := func(name uint32) {
+		t.Helper()
+
+		mb := wire.NewMessage(registry, 1)
+		mb.WriteUint(name)
+		if err := mb.Build(server); err != nil {
+			t.Fatal(err)
+		}
+		dispatch(t, client)
+	}
+	find := func(inter string, name, version uint32, ok bool) {
+		t.Helper()
+
+		gn, gv, gok := g.Find(inter)
+		if (gn != name) || (gv != version) || (gok != ok) {
+			t.Fatalf("Find(%q) = %v, %v, %v, want %v, %v, %v", inter, gn, gv, gok, name, version, ok)
+		}
+	}
+
+	global(1, CompositorInterface, 4)
+	global(2, OutputInterface, 2)
+	global(3, OutputInterface, 3)
+	find(CompositorInterface, 1, 4, true)
+	find(SeatInterface, 0, 0, false)
+
+	// The first output that was advertised is found, until it is
+	// removed.
+	find(OutputInterface, 2, 2, true)
+	globalRemove(2)
+	find(OutputInterface, 3, 3, true)
+
+	// Removing a global that doesn't exist does nothing.
+	globalRemove(2)
+
+	// Advertising an existing name replaces the global in place.
+	global(1, CompositorInterface, 5)
+	find(CompositorInterface, 1, 5, true)
+
+	want := []Global{
+		{Name: 1, Interface: CompositorInterface, Version: 5},
+		{Name: 3, Interface: OutputInterface, Version: 3},
+	}
+	if all := g.All(); !slices.Equal(all, want) {
+		t.Fatalf("got globals %v, want %v", all, want)
+	}
+	if want := []uint32{1, 2, 3, 1}; !slices.Equal(added, want) {
+		t.Fatalf("OnAdd called for %v, want %v", added, want)
+	}
+	if want := []uint32{2}; !slices.Equal(removed, want) {
+		t.Fatalf("OnRemove called for %v, want %v", removed, want)
+	}
+}
+
+// dispatch reads a single event from client's connection and
+// dispatches it.
+func dispatch(t *testing.T, client *Client) {
+	t.Helper()
+
+	ev, err := wire.ReadMessage(client.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.dispatch(ev); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,4 +1,29 @@
-// Code generated by wlgen. DO NOT EDIT.
+// Code generated by wlgen from the wayland protocol. DO NOT EDIT.
+
+// Copyright © 2008-2011 Kristian Høgsberg
+// Copyright © 2010-2011 Intel Corporation
+// Copyright © 2012-2013 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the
+// next paragraph) shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT.  IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
 
 package wl
 
@@ -8,6 +33,37 @@ import (
 	"os"
 )
 
+// ProtocolName is the name of the protocol that this file was
+// generated from.
+const ProtocolName = "wayland"
+
+// Interfaces lists the interfaces defined by the wayland
+// protocol along with the highest version of each that is supported.
+var Interfaces = []wire.InterfaceInfo{
+	{Name: DisplayInterface, Version: DisplayVersion},
+	{Name: RegistryInterface, Version: RegistryVersion},
+	{Name: CallbackInterface, Version: CallbackVersion},
+	{Name: CompositorInterface, Version: CompositorVersion},
+	{Name: ShmPoolInterface, Version: ShmPoolVersion},
+	{Name: ShmInterface, Version: ShmVersion},
+	{Name: BufferInterface, Version: BufferVersion},
+	{Name: DataOfferInterface, Version: DataOfferVersion},
+	{Name: DataSourceInterface, Version: DataSourceVersion},
+	{Name: DataDeviceInterface, Version: DataDeviceVersion},
+	{Name: DataDeviceManagerInterface, Version: DataDeviceManagerVersion},
+	{Name: ShellInterface, Version: ShellVersion},
+	{Name: ShellSurfaceInterface, Version: ShellSurfaceVersion},
+	{Name: SurfaceInterface, Version: SurfaceVersion},
+	{Name: SeatInterface, Version: SeatVersion},
+	{Name: PointerInterface, Version: PointerVersion},
+	{Name: KeyboardInterface, Version: KeyboardVersion},
+	{Name: TouchInterface, Version: TouchVersion},
+	{Name: OutputInterface, Version: OutputVersion},
+	{Name: RegionInterface, Version: RegionVersion},
+	{Name: SubcompositorInterface, Version: SubcompositorVersion},
+	{Name: SubsurfaceInterface, Version: SubsurfaceVersion},
+}
+
 const (
 	DisplayInterface = "wl_display"
 	DisplayVersion   = 1
@@ -33,30 +89,49 @@ type DisplayListener interface {
 	DeleteId(id uint32)
 }
 
+// DisplayErrorEvent holds the arguments of a wl_display.error
+// event.
+type DisplayErrorEvent struct {
+	ObjectId uint32
+	Code     uint32
+	Message  string
+}
+
+// DisplayDeleteIdEvent holds the arguments of a wl_display.delete_id
+// event.
+type DisplayDeleteIdEvent struct {
+	Id uint32
+}
+
 // The core global object.  This is a special singleton object.  It
 // is used for internal Wayland protocol features.
 type Display struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DisplayListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnError, if not nil, is called with the arguments of
+	// each incoming error event before Listener is.
+	OnError func(DisplayErrorEvent)
 
-	state wire.State
-	id    uint32
+	// OnDeleteId, if not nil, is called with the arguments of
+	// each incoming delete_id event before Listener is.
+	OnDeleteId func(DisplayDeleteIdEvent)
 }
 
+var (
+	_ wire.Object      = (*Display)(nil)
+	_ wire.DebugObject = (*Display)(nil)
+)
+
 // NewDisplay returns a newly instantiated Display. It is
 // primarily intended for use by generated code.
 func NewDisplay(state wire.State) *Display {
-	return &Display{state: state}
-}
-
-func (obj *Display) State() wire.State {
-	return obj.state
+	return &Display{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Display) Dispatch(msg *wire.MessageBuffer) error {
@@ -73,6 +148,13 @@ func (obj *Display) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnError != nil {
+			obj.OnError(DisplayErrorEvent{
+				ObjectId: objectId,
+				Code:     code,
+				Message:  message,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -91,6 +173,11 @@ func (obj *Display) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDeleteId != nil {
+			obj.OnDeleteId(DisplayDeleteIdEvent{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -107,22 +194,8 @@ func (obj *Display) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Display) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Display) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Display) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Display) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_display", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_display", obj.ID())
 }
 
 func (obj *Display) MethodName(op uint16) string {
@@ -141,7 +214,19 @@ func (obj *Display) Interface() string {
 	return DisplayInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DisplayVersion, the same as MaxVersion.
 func (obj *Display) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DisplayVersion
+}
+
+// MaxVersion returns DisplayVersion, the highest version of
+// wl_display that is supported.
+func (obj *Display) MaxVersion() uint32 {
 	return DisplayVersion
 }
 
@@ -159,13 +244,14 @@ func (obj *Display) Version() uint32 {
 func (obj *Display) Sync() (callback *Callback) {
 	builder := wire.NewMessage(obj, 0)
 
-	callback = NewCallback(obj.state)
-	obj.state.Add(callback)
+	callback = NewCallback(obj.State())
+	callback.SetVersion(obj.Version())
+	obj.State().Add(callback)
 	builder.WriteObject(callback)
 
 	builder.Method = "sync"
 	builder.Args = []any{callback}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return callback
 }
 
@@ -181,13 +267,14 @@ func (obj *Display) Sync() (callback *Callback) {
 func (obj *Display) GetRegistry() (registry *Registry) {
 	builder := wire.NewMessage(obj, 1)
 
-	registry = NewRegistry(obj.state)
-	obj.state.Add(registry)
+	registry = NewRegistry(obj.State())
+	registry.SetVersion(obj.Version())
+	obj.State().Add(registry)
 	builder.WriteObject(registry)
 
 	builder.Method = "get_registry"
 	builder.Args = []any{registry}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return registry
 }
 
@@ -209,22 +296,25 @@ const (
 	DisplayErrorImplementation DisplayError = 3
 )
 
-func (enum DisplayError) String() string {
-	switch enum {
-	case 0:
-		return "DisplayErrorInvalidObject"
-
-	case 1:
-		return "DisplayErrorInvalidMethod"
-
-	case 2:
-		return "DisplayErrorNoMemory"
+// DisplayErrorNames maps the values of DisplayError to their names.
+var DisplayErrorNames = map[DisplayError]string{
+	DisplayErrorInvalidObject:  "DisplayErrorInvalidObject",
+	DisplayErrorInvalidMethod:  "DisplayErrorInvalidMethod",
+	DisplayErrorNoMemory:       "DisplayErrorNoMemory",
+	DisplayErrorImplementation: "DisplayErrorImplementation",
+}
 
-	case 3:
-		return "DisplayErrorImplementation"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum DisplayError) String() string {
+	return wire.EnumString(enum, DisplayErrorNames)
+}
 
-	return "<invalid DisplayError>"
+// Since returns the version of wl_display that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DisplayError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -255,6 +345,20 @@ type RegistryListener interface {
 	GlobalRemove(name uint32)
 }
 
+// RegistryGlobalEvent holds the arguments of a wl_registry.global
+// event.
+type RegistryGlobalEvent struct {
+	Name      uint32
+	Interface string
+	Version   uint32
+}
+
+// RegistryGlobalRemoveEvent holds the arguments of a wl_registry.global_remove
+// event.
+type RegistryGlobalRemoveEvent struct {
+	Name uint32
+}
+
 // The singleton global registry object.  The server has a number of
 // global objects that are available to all clients.  These objects
 // typically represent an actual object in the server (for example,
@@ -276,27 +380,32 @@ type RegistryListener interface {
 // emit events to the client and lets the client invoke requests on
 // the object.
 type Registry struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener RegistryListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnGlobal, if not nil, is called with the arguments of
+	// each incoming global event before Listener is.
+	OnGlobal func(RegistryGlobalEvent)
 
-	state wire.State
-	id    uint32
+	// OnGlobalRemove, if not nil, is called with the arguments of
+	// each incoming global_remove event before Listener is.
+	OnGlobalRemove func(RegistryGlobalRemoveEvent)
 }
 
+var (
+	_ wire.Object      = (*Registry)(nil)
+	_ wire.DebugObject = (*Registry)(nil)
+)
+
 // NewRegistry returns a newly instantiated Registry. It is
 // primarily intended for use by generated code.
 func NewRegistry(state wire.State) *Registry {
-	return &Registry{state: state}
-}
-
-func (obj *Registry) State() wire.State {
-	return obj.state
+	return &Registry{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Registry) Dispatch(msg *wire.MessageBuffer) error {
@@ -313,6 +422,13 @@ func (obj *Registry) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnGlobal != nil {
+			obj.OnGlobal(RegistryGlobalEvent{
+				Name:      name,
+				Interface: _interface,
+				Version:   version,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -331,6 +447,11 @@ func (obj *Registry) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnGlobalRemove != nil {
+			obj.OnGlobalRemove(RegistryGlobalRemoveEvent{
+				Name: name,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -347,22 +468,8 @@ func (obj *Registry) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Registry) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Registry) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Registry) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Registry) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_registry", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_registry", obj.ID())
 }
 
 func (obj *Registry) MethodName(op uint16) string {
@@ -381,7 +488,19 @@ func (obj *Registry) Interface() string {
 	return RegistryInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// RegistryVersion, the same as MaxVersion.
 func (obj *Registry) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return RegistryVersion
+}
+
+// MaxVersion returns RegistryVersion, the highest version of
+// wl_registry that is supported.
+func (obj *Registry) MaxVersion() uint32 {
 	return RegistryVersion
 }
 
@@ -393,9 +512,11 @@ func (obj *Registry) Bind(name uint32, id wire.NewID) {
 	builder.WriteUint(name)
 	builder.WriteNewID(id)
 
+	builder.Fail(wire.CheckMessageSize(RegistryInterface, "bind", builder))
+
 	builder.Method = "bind"
 	builder.Args = []any{name, id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -411,30 +532,37 @@ type CallbackListener interface {
 	Done(callbackData uint32)
 }
 
+// CallbackDoneEvent holds the arguments of a wl_callback.done
+// event.
+type CallbackDoneEvent struct {
+	CallbackData uint32
+}
+
 // Clients can handle the 'done' event to get notified when
 // the related request is done.
 type Callback struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener CallbackListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnDone, if not nil, is called with the arguments of
+	// each incoming done event before Listener is.
+	OnDone func(CallbackDoneEvent)
 }
 
+var (
+	_ wire.Object      = (*Callback)(nil)
+	_ wire.DebugObject = (*Callback)(nil)
+)
+
 // NewCallback returns a newly instantiated Callback. It is
 // primarily intended for use by generated code.
 func NewCallback(state wire.State) *Callback {
-	return &Callback{state: state}
-}
-
-func (obj *Callback) State() wire.State {
-	return obj.state
+	return &Callback{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Callback) Dispatch(msg *wire.MessageBuffer) error {
@@ -447,6 +575,11 @@ func (obj *Callback) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDone != nil {
+			obj.OnDone(CallbackDoneEvent{
+				CallbackData: callbackData,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -463,22 +596,8 @@ func (obj *Callback) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Callback) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Callback) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Callback) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Callback) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_callback", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_callback", obj.ID())
 }
 
 func (obj *Callback) MethodName(op uint16) string {
@@ -494,7 +613,19 @@ func (obj *Callback) Interface() string {
 	return CallbackInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// CallbackVersion, the same as MaxVersion.
 func (obj *Callback) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return CallbackVersion
+}
+
+// MaxVersion returns CallbackVersion, the highest version of
+// wl_callback that is supported.
+func (obj *Callback) MaxVersion() uint32 {
 	return CallbackVersion
 }
 
@@ -507,30 +638,37 @@ const (
 // compositor is in charge of combining the contents of multiple
 // surfaces into one displayable output.
 type Compositor struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*Compositor)(nil)
+	_ wire.DebugObject = (*Compositor)(nil)
+)
+
 // NewCompositor returns a newly instantiated Compositor. It is
 // primarily intended for use by generated code.
 func NewCompositor(state wire.State) *Compositor {
-	return &Compositor{state: state}
-}
+	return &Compositor{Proxy: wire.NewProxy(state)}
+}
+
+// BindCompositor binds the global identified by name to a new
+// Compositor. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and CompositorVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindCompositor(state wire.State, registry wire.Binder, name, version uint32) (*Compositor, error) {
+	v := wire.NegotiateVersion(CompositorVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: CompositorInterface, Local: CompositorVersion, Remote: version}
+	}
 
-func BindCompositor(state wire.State, registry wire.Binder, name, version uint32) *Compositor {
 	obj := NewCompositor(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: CompositorInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *Compositor) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: CompositorInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *Compositor) Dispatch(msg *wire.MessageBuffer) error {
@@ -542,22 +680,8 @@ func (obj *Compositor) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Compositor) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Compositor) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Compositor) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Compositor) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_compositor", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_compositor", obj.ID())
 }
 
 func (obj *Compositor) MethodName(op uint16) string {
@@ -571,7 +695,19 @@ func (obj *Compositor) Interface() string {
 	return CompositorInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// CompositorVersion, the same as MaxVersion.
 func (obj *Compositor) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return CompositorVersion
+}
+
+// MaxVersion returns CompositorVersion, the highest version of
+// wl_compositor that is supported.
+func (obj *Compositor) MaxVersion() uint32 {
 	return CompositorVersion
 }
 
@@ -579,13 +715,14 @@ func (obj *Compositor) Version() uint32 {
 func (obj *Compositor) CreateSurface() (id *Surface) {
 	builder := wire.NewMessage(obj, 0)
 
-	id = NewSurface(obj.state)
-	obj.state.Add(id)
+	id = NewSurface(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "create_surface"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -593,13 +730,14 @@ func (obj *Compositor) CreateSurface() (id *Surface) {
 func (obj *Compositor) CreateRegion() (id *Region) {
 	builder := wire.NewMessage(obj, 1)
 
-	id = NewRegion(obj.state)
-	obj.state.Add(id)
+	id = NewRegion(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "create_region"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -616,23 +754,18 @@ const (
 // setup/teardown overhead and is useful when interactively resizing
 // a surface or for many small buffers.
 type ShmPool struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*ShmPool)(nil)
+	_ wire.DebugObject = (*ShmPool)(nil)
+)
+
 // NewShmPool returns a newly instantiated ShmPool. It is
 // primarily intended for use by generated code.
 func NewShmPool(state wire.State) *ShmPool {
-	return &ShmPool{state: state}
-}
-
-func (obj *ShmPool) State() wire.State {
-	return obj.state
+	return &ShmPool{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *ShmPool) Dispatch(msg *wire.MessageBuffer) error {
@@ -644,22 +777,8 @@ func (obj *ShmPool) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *ShmPool) ID() uint32 {
-	return obj.id
-}
-
-func (obj *ShmPool) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *ShmPool) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *ShmPool) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shm_pool", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shm_pool", obj.ID())
 }
 
 func (obj *ShmPool) MethodName(op uint16) string {
@@ -673,7 +792,19 @@ func (obj *ShmPool) Interface() string {
 	return ShmPoolInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShmPoolVersion, the same as MaxVersion.
 func (obj *ShmPool) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShmPoolVersion
+}
+
+// MaxVersion returns ShmPoolVersion, the highest version of
+// wl_shm_pool that is supported.
+func (obj *ShmPool) MaxVersion() uint32 {
 	return ShmPoolVersion
 }
 
@@ -691,8 +822,9 @@ func (obj *ShmPool) Version() uint32 {
 func (obj *ShmPool) CreateBuffer(offset int32, width int32, height int32, stride int32, format ShmFormat) (id *Buffer) {
 	builder := wire.NewMessage(obj, 0)
 
-	id = NewBuffer(obj.state)
-	obj.state.Add(id)
+	id = NewBuffer(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 	builder.WriteInt(offset)
 	builder.WriteInt(width)
@@ -702,7 +834,7 @@ func (obj *ShmPool) CreateBuffer(offset int32, width int32, height int32, stride
 
 	builder.Method = "create_buffer"
 	builder.Args = []any{id, offset, width, height, stride, format}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -716,7 +848,7 @@ func (obj *ShmPool) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -731,7 +863,7 @@ func (obj *ShmPool) Resize(size int32) {
 
 	builder.Method = "resize"
 	builder.Args = []any{size}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -749,6 +881,12 @@ type ShmListener interface {
 	Format(format ShmFormat)
 }
 
+// ShmFormatEvent holds the arguments of a wl_shm.format
+// event.
+type ShmFormatEvent struct {
+	Format ShmFormat
+}
+
 // A singleton global object that provides support for shared
 // memory.
 //
@@ -759,34 +897,47 @@ type ShmListener interface {
 // format events to inform clients about the valid pixel formats
 // that can be used for buffers.
 type Shm struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ShmListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnFormat, if not nil, is called with the arguments of
+	// each incoming format event before Listener is.
+	OnFormat func(ShmFormatEvent)
 }
 
+var (
+	_ wire.Object      = (*Shm)(nil)
+	_ wire.DebugObject = (*Shm)(nil)
+)
+
 // NewShm returns a newly instantiated Shm. It is
 // primarily intended for use by generated code.
 func NewShm(state wire.State) *Shm {
-	return &Shm{state: state}
-}
+	return &Shm{Proxy: wire.NewProxy(state)}
+}
+
+// BindShm binds the global identified by name to a new
+// Shm. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and ShmVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindShm(state wire.State, registry wire.Binder, name, version uint32) (*Shm, error) {
+	v := wire.NegotiateVersion(ShmVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: ShmInterface, Local: ShmVersion, Remote: version}
+	}
 
-func BindShm(state wire.State, registry wire.Binder, name, version uint32) *Shm {
 	obj := NewShm(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: ShmInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *Shm) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: ShmInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *Shm) Dispatch(msg *wire.MessageBuffer) error {
@@ -799,6 +950,11 @@ func (obj *Shm) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnFormat != nil {
+			obj.OnFormat(ShmFormatEvent{
+				Format: format,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -815,22 +971,8 @@ func (obj *Shm) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Shm) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Shm) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Shm) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Shm) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shm", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shm", obj.ID())
 }
 
 func (obj *Shm) MethodName(op uint16) string {
@@ -846,7 +988,19 @@ func (obj *Shm) Interface() string {
 	return ShmInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShmVersion, the same as MaxVersion.
 func (obj *Shm) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShmVersion
+}
+
+// MaxVersion returns ShmVersion, the highest version of
+// wl_shm that is supported.
+func (obj *Shm) MaxVersion() uint32 {
 	return ShmVersion
 }
 
@@ -858,15 +1012,16 @@ func (obj *Shm) Version() uint32 {
 func (obj *Shm) CreatePool(fd *os.File, size int32) (id *ShmPool) {
 	builder := wire.NewMessage(obj, 0)
 
-	id = NewShmPool(obj.state)
-	obj.state.Add(id)
+	id = NewShmPool(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 	builder.WriteFile(fd)
 	builder.WriteInt(size)
 
 	builder.Method = "create_pool"
 	builder.Args = []any{id, fd, size}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -884,19 +1039,24 @@ const (
 	ShmErrorInvalidFd ShmError = 2
 )
 
-func (enum ShmError) String() string {
-	switch enum {
-	case 0:
-		return "ShmErrorInvalidFormat"
-
-	case 1:
-		return "ShmErrorInvalidStride"
+// ShmErrorNames maps the values of ShmError to their names.
+var ShmErrorNames = map[ShmError]string{
+	ShmErrorInvalidFormat: "ShmErrorInvalidFormat",
+	ShmErrorInvalidStride: "ShmErrorInvalidStride",
+	ShmErrorInvalidFd:     "ShmErrorInvalidFd",
+}
 
-	case 2:
-		return "ShmErrorInvalidFd"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ShmError) String() string {
+	return wire.EnumString(enum, ShmErrorNames)
+}
 
-	return "<invalid ShmError>"
+// Since returns the version of wl_shm that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShmError) Since() uint32 {
+	return 1
 }
 
 // This describes the memory layout of an individual pixel.
@@ -1212,322 +1372,125 @@ const (
 	ShmFormatQ401 ShmFormat = 825242705
 )
 
+// ShmFormatNames maps the values of ShmFormat to their names.
+var ShmFormatNames = map[ShmFormat]string{
+	ShmFormatArgb8888:             "ShmFormatArgb8888",
+	ShmFormatXrgb8888:             "ShmFormatXrgb8888",
+	ShmFormatC8:                   "ShmFormatC8",
+	ShmFormatRgb332:               "ShmFormatRgb332",
+	ShmFormatBgr233:               "ShmFormatBgr233",
+	ShmFormatXrgb4444:             "ShmFormatXrgb4444",
+	ShmFormatXbgr4444:             "ShmFormatXbgr4444",
+	ShmFormatRgbx4444:             "ShmFormatRgbx4444",
+	ShmFormatBgrx4444:             "ShmFormatBgrx4444",
+	ShmFormatArgb4444:             "ShmFormatArgb4444",
+	ShmFormatAbgr4444:             "ShmFormatAbgr4444",
+	ShmFormatRgba4444:             "ShmFormatRgba4444",
+	ShmFormatBgra4444:             "ShmFormatBgra4444",
+	ShmFormatXrgb1555:             "ShmFormatXrgb1555",
+	ShmFormatXbgr1555:             "ShmFormatXbgr1555",
+	ShmFormatRgbx5551:             "ShmFormatRgbx5551",
+	ShmFormatBgrx5551:             "ShmFormatBgrx5551",
+	ShmFormatArgb1555:             "ShmFormatArgb1555",
+	ShmFormatAbgr1555:             "ShmFormatAbgr1555",
+	ShmFormatRgba5551:             "ShmFormatRgba5551",
+	ShmFormatBgra5551:             "ShmFormatBgra5551",
+	ShmFormatRgb565:               "ShmFormatRgb565",
+	ShmFormatBgr565:               "ShmFormatBgr565",
+	ShmFormatRgb888:               "ShmFormatRgb888",
+	ShmFormatBgr888:               "ShmFormatBgr888",
+	ShmFormatXbgr8888:             "ShmFormatXbgr8888",
+	ShmFormatRgbx8888:             "ShmFormatRgbx8888",
+	ShmFormatBgrx8888:             "ShmFormatBgrx8888",
+	ShmFormatAbgr8888:             "ShmFormatAbgr8888",
+	ShmFormatRgba8888:             "ShmFormatRgba8888",
+	ShmFormatBgra8888:             "ShmFormatBgra8888",
+	ShmFormatXrgb2101010:          "ShmFormatXrgb2101010",
+	ShmFormatXbgr2101010:          "ShmFormatXbgr2101010",
+	ShmFormatRgbx1010102:          "ShmFormatRgbx1010102",
+	ShmFormatBgrx1010102:          "ShmFormatBgrx1010102",
+	ShmFormatArgb2101010:          "ShmFormatArgb2101010",
+	ShmFormatAbgr2101010:          "ShmFormatAbgr2101010",
+	ShmFormatRgba1010102:          "ShmFormatRgba1010102",
+	ShmFormatBgra1010102:          "ShmFormatBgra1010102",
+	ShmFormatYuyv:                 "ShmFormatYuyv",
+	ShmFormatYvyu:                 "ShmFormatYvyu",
+	ShmFormatUyvy:                 "ShmFormatUyvy",
+	ShmFormatVyuy:                 "ShmFormatVyuy",
+	ShmFormatAyuv:                 "ShmFormatAyuv",
+	ShmFormatNv12:                 "ShmFormatNv12",
+	ShmFormatNv21:                 "ShmFormatNv21",
+	ShmFormatNv16:                 "ShmFormatNv16",
+	ShmFormatNv61:                 "ShmFormatNv61",
+	ShmFormatYuv410:               "ShmFormatYuv410",
+	ShmFormatYvu410:               "ShmFormatYvu410",
+	ShmFormatYuv411:               "ShmFormatYuv411",
+	ShmFormatYvu411:               "ShmFormatYvu411",
+	ShmFormatYuv420:               "ShmFormatYuv420",
+	ShmFormatYvu420:               "ShmFormatYvu420",
+	ShmFormatYuv422:               "ShmFormatYuv422",
+	ShmFormatYvu422:               "ShmFormatYvu422",
+	ShmFormatYuv444:               "ShmFormatYuv444",
+	ShmFormatYvu444:               "ShmFormatYvu444",
+	ShmFormatR8:                   "ShmFormatR8",
+	ShmFormatR16:                  "ShmFormatR16",
+	ShmFormatRg88:                 "ShmFormatRg88",
+	ShmFormatGr88:                 "ShmFormatGr88",
+	ShmFormatRg1616:               "ShmFormatRg1616",
+	ShmFormatGr1616:               "ShmFormatGr1616",
+	ShmFormatXrgb16161616f:        "ShmFormatXrgb16161616f",
+	ShmFormatXbgr16161616f:        "ShmFormatXbgr16161616f",
+	ShmFormatArgb16161616f:        "ShmFormatArgb16161616f",
+	ShmFormatAbgr16161616f:        "ShmFormatAbgr16161616f",
+	ShmFormatXyuv8888:             "ShmFormatXyuv8888",
+	ShmFormatVuy888:               "ShmFormatVuy888",
+	ShmFormatVuy101010:            "ShmFormatVuy101010",
+	ShmFormatY210:                 "ShmFormatY210",
+	ShmFormatY212:                 "ShmFormatY212",
+	ShmFormatY216:                 "ShmFormatY216",
+	ShmFormatY410:                 "ShmFormatY410",
+	ShmFormatY412:                 "ShmFormatY412",
+	ShmFormatY416:                 "ShmFormatY416",
+	ShmFormatXvyu2101010:          "ShmFormatXvyu2101010",
+	ShmFormatXvyu1216161616:       "ShmFormatXvyu1216161616",
+	ShmFormatXvyu16161616:         "ShmFormatXvyu16161616",
+	ShmFormatY0l0:                 "ShmFormatY0l0",
+	ShmFormatX0l0:                 "ShmFormatX0l0",
+	ShmFormatY0l2:                 "ShmFormatY0l2",
+	ShmFormatX0l2:                 "ShmFormatX0l2",
+	ShmFormatYuv4208bit:           "ShmFormatYuv4208bit",
+	ShmFormatYuv42010bit:          "ShmFormatYuv42010bit",
+	ShmFormatXrgb8888A8:           "ShmFormatXrgb8888A8",
+	ShmFormatXbgr8888A8:           "ShmFormatXbgr8888A8",
+	ShmFormatRgbx8888A8:           "ShmFormatRgbx8888A8",
+	ShmFormatBgrx8888A8:           "ShmFormatBgrx8888A8",
+	ShmFormatRgb888A8:             "ShmFormatRgb888A8",
+	ShmFormatBgr888A8:             "ShmFormatBgr888A8",
+	ShmFormatRgb565A8:             "ShmFormatRgb565A8",
+	ShmFormatBgr565A8:             "ShmFormatBgr565A8",
+	ShmFormatNv24:                 "ShmFormatNv24",
+	ShmFormatNv42:                 "ShmFormatNv42",
+	ShmFormatP210:                 "ShmFormatP210",
+	ShmFormatP010:                 "ShmFormatP010",
+	ShmFormatP012:                 "ShmFormatP012",
+	ShmFormatP016:                 "ShmFormatP016",
+	ShmFormatAxbxgxrx106106106106: "ShmFormatAxbxgxrx106106106106",
+	ShmFormatNv15:                 "ShmFormatNv15",
+	ShmFormatQ410:                 "ShmFormatQ410",
+	ShmFormatQ401:                 "ShmFormatQ401",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum ShmFormat) String() string {
-	switch enum {
-	case 0:
-		return "ShmFormatArgb8888"
-
-	case 1:
-		return "ShmFormatXrgb8888"
-
-	case 538982467:
-		return "ShmFormatC8"
-
-	case 943867730:
-		return "ShmFormatRgb332"
-
-	case 944916290:
-		return "ShmFormatBgr233"
-
-	case 842093144:
-		return "ShmFormatXrgb4444"
-
-	case 842089048:
-		return "ShmFormatXbgr4444"
-
-	case 842094674:
-		return "ShmFormatRgbx4444"
-
-	case 842094658:
-		return "ShmFormatBgrx4444"
-
-	case 842093121:
-		return "ShmFormatArgb4444"
-
-	case 842089025:
-		return "ShmFormatAbgr4444"
-
-	case 842088786:
-		return "ShmFormatRgba4444"
-
-	case 842088770:
-		return "ShmFormatBgra4444"
-
-	case 892424792:
-		return "ShmFormatXrgb1555"
-
-	case 892420696:
-		return "ShmFormatXbgr1555"
-
-	case 892426322:
-		return "ShmFormatRgbx5551"
-
-	case 892426306:
-		return "ShmFormatBgrx5551"
-
-	case 892424769:
-		return "ShmFormatArgb1555"
-
-	case 892420673:
-		return "ShmFormatAbgr1555"
-
-	case 892420434:
-		return "ShmFormatRgba5551"
-
-	case 892420418:
-		return "ShmFormatBgra5551"
-
-	case 909199186:
-		return "ShmFormatRgb565"
-
-	case 909199170:
-		return "ShmFormatBgr565"
-
-	case 875710290:
-		return "ShmFormatRgb888"
-
-	case 875710274:
-		return "ShmFormatBgr888"
-
-	case 875709016:
-		return "ShmFormatXbgr8888"
-
-	case 875714642:
-		return "ShmFormatRgbx8888"
-
-	case 875714626:
-		return "ShmFormatBgrx8888"
-
-	case 875708993:
-		return "ShmFormatAbgr8888"
-
-	case 875708754:
-		return "ShmFormatRgba8888"
-
-	case 875708738:
-		return "ShmFormatBgra8888"
-
-	case 808669784:
-		return "ShmFormatXrgb2101010"
-
-	case 808665688:
-		return "ShmFormatXbgr2101010"
-
-	case 808671314:
-		return "ShmFormatRgbx1010102"
-
-	case 808671298:
-		return "ShmFormatBgrx1010102"
-
-	case 808669761:
-		return "ShmFormatArgb2101010"
-
-	case 808665665:
-		return "ShmFormatAbgr2101010"
-
-	case 808665426:
-		return "ShmFormatRgba1010102"
-
-	case 808665410:
-		return "ShmFormatBgra1010102"
-
-	case 1448695129:
-		return "ShmFormatYuyv"
-
-	case 1431918169:
-		return "ShmFormatYvyu"
-
-	case 1498831189:
-		return "ShmFormatUyvy"
-
-	case 1498765654:
-		return "ShmFormatVyuy"
-
-	case 1448433985:
-		return "ShmFormatAyuv"
-
-	case 842094158:
-		return "ShmFormatNv12"
-
-	case 825382478:
-		return "ShmFormatNv21"
-
-	case 909203022:
-		return "ShmFormatNv16"
-
-	case 825644622:
-		return "ShmFormatNv61"
-
-	case 961959257:
-		return "ShmFormatYuv410"
-
-	case 961893977:
-		return "ShmFormatYvu410"
-
-	case 825316697:
-		return "ShmFormatYuv411"
-
-	case 825316953:
-		return "ShmFormatYvu411"
-
-	case 842093913:
-		return "ShmFormatYuv420"
-
-	case 842094169:
-		return "ShmFormatYvu420"
-
-	case 909202777:
-		return "ShmFormatYuv422"
-
-	case 909203033:
-		return "ShmFormatYvu422"
-
-	case 875713881:
-		return "ShmFormatYuv444"
-
-	case 875714137:
-		return "ShmFormatYvu444"
-
-	case 538982482:
-		return "ShmFormatR8"
-
-	case 540422482:
-		return "ShmFormatR16"
-
-	case 943212370:
-		return "ShmFormatRg88"
-
-	case 943215175:
-		return "ShmFormatGr88"
-
-	case 842221394:
-		return "ShmFormatRg1616"
-
-	case 842224199:
-		return "ShmFormatGr1616"
-
-	case 1211388504:
-		return "ShmFormatXrgb16161616f"
-
-	case 1211384408:
-		return "ShmFormatXbgr16161616f"
-
-	case 1211388481:
-		return "ShmFormatArgb16161616f"
-
-	case 1211384385:
-		return "ShmFormatAbgr16161616f"
-
-	case 1448434008:
-		return "ShmFormatXyuv8888"
-
-	case 875713878:
-		return "ShmFormatVuy888"
-
-	case 808670550:
-		return "ShmFormatVuy101010"
-
-	case 808530521:
-		return "ShmFormatY210"
-
-	case 842084953:
-		return "ShmFormatY212"
-
-	case 909193817:
-		return "ShmFormatY216"
-
-	case 808531033:
-		return "ShmFormatY410"
-
-	case 842085465:
-		return "ShmFormatY412"
-
-	case 909194329:
-		return "ShmFormatY416"
-
-	case 808670808:
-		return "ShmFormatXvyu2101010"
-
-	case 909334104:
-		return "ShmFormatXvyu1216161616"
-
-	case 942954072:
-		return "ShmFormatXvyu16161616"
-
-	case 810299481:
-		return "ShmFormatY0l0"
-
-	case 810299480:
-		return "ShmFormatX0l0"
-
-	case 843853913:
-		return "ShmFormatY0l2"
-
-	case 843853912:
-		return "ShmFormatX0l2"
-
-	case 942691673:
-		return "ShmFormatYuv4208bit"
-
-	case 808539481:
-		return "ShmFormatYuv42010bit"
-
-	case 943805016:
-		return "ShmFormatXrgb8888A8"
-
-	case 943800920:
-		return "ShmFormatXbgr8888A8"
-
-	case 943806546:
-		return "ShmFormatRgbx8888A8"
-
-	case 943806530:
-		return "ShmFormatBgrx8888A8"
-
-	case 943798354:
-		return "ShmFormatRgb888A8"
-
-	case 943798338:
-		return "ShmFormatBgr888A8"
-
-	case 943797586:
-		return "ShmFormatRgb565A8"
-
-	case 943797570:
-		return "ShmFormatBgr565A8"
-
-	case 875714126:
-		return "ShmFormatNv24"
-
-	case 842290766:
-		return "ShmFormatNv42"
-
-	case 808530512:
-		return "ShmFormatP210"
-
-	case 808530000:
-		return "ShmFormatP010"
-
-	case 842084432:
-		return "ShmFormatP012"
-
-	case 909193296:
-		return "ShmFormatP016"
-
-	case 808534593:
-		return "ShmFormatAxbxgxrx106106106106"
-
-	case 892425806:
-		return "ShmFormatNv15"
-
-	case 808531025:
-		return "ShmFormatQ410"
-
-	case 825242705:
-		return "ShmFormatQ401"
-	}
+	return wire.EnumString(enum, ShmFormatNames)
+}
 
-	return "<invalid ShmFormat>"
+// Since returns the version of wl_shm that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShmFormat) Since() uint32 {
+	return 1
 }
 
 const (
@@ -1553,33 +1516,39 @@ type BufferListener interface {
 	Release()
 }
 
+// BufferReleaseEvent holds the arguments of a wl_buffer.release
+// event.
+type BufferReleaseEvent struct {
+}
+
 // A buffer provides the content for a wl_surface. Buffers are
 // created through factory interfaces such as wl_drm, wl_shm or
 // similar. It has a width and a height and can be attached to a
 // wl_surface, but the mechanism by which a client provides and
 // updates the contents is defined by the buffer factory interface.
 type Buffer struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener BufferListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnRelease, if not nil, is called with the arguments of
+	// each incoming release event before Listener is.
+	OnRelease func(BufferReleaseEvent)
 }
 
+var (
+	_ wire.Object      = (*Buffer)(nil)
+	_ wire.DebugObject = (*Buffer)(nil)
+)
+
 // NewBuffer returns a newly instantiated Buffer. It is
 // primarily intended for use by generated code.
 func NewBuffer(state wire.State) *Buffer {
-	return &Buffer{state: state}
-}
-
-func (obj *Buffer) State() wire.State {
-	return obj.state
+	return &Buffer{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Buffer) Dispatch(msg *wire.MessageBuffer) error {
@@ -1589,6 +1558,9 @@ func (obj *Buffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRelease != nil {
+			obj.OnRelease(BufferReleaseEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1603,22 +1575,8 @@ func (obj *Buffer) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Buffer) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Buffer) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Buffer) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Buffer) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_buffer", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_buffer", obj.ID())
 }
 
 func (obj *Buffer) MethodName(op uint16) string {
@@ -1634,7 +1592,19 @@ func (obj *Buffer) Interface() string {
 	return BufferInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// BufferVersion, the same as MaxVersion.
 func (obj *Buffer) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return BufferVersion
+}
+
+// MaxVersion returns BufferVersion, the highest version of
+// wl_buffer that is supported.
+func (obj *Buffer) MaxVersion() uint32 {
 	return BufferVersion
 }
 
@@ -1647,7 +1617,7 @@ func (obj *Buffer) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1706,6 +1676,24 @@ type DataOfferListener interface {
 	Action(dndAction DataDeviceManagerDndAction)
 }
 
+// DataOfferOfferEvent holds the arguments of a wl_data_offer.offer
+// event.
+type DataOfferOfferEvent struct {
+	MimeType string
+}
+
+// DataOfferSourceActionsEvent holds the arguments of a wl_data_offer.source_actions
+// event.
+type DataOfferSourceActionsEvent struct {
+	SourceActions DataDeviceManagerDndAction
+}
+
+// DataOfferActionEvent holds the arguments of a wl_data_offer.action
+// event.
+type DataOfferActionEvent struct {
+	DndAction DataDeviceManagerDndAction
+}
+
 // A wl_data_offer represents a piece of data offered for transfer
 // by another client (the source client).  It is used by the
 // copy-and-paste and drag-and-drop mechanisms.  The offer
@@ -1713,27 +1701,36 @@ type DataOfferListener interface {
 // converted to and provides the mechanism for transferring the
 // data directly from the source client.
 type DataOffer struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DataOfferListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnOffer, if not nil, is called with the arguments of
+	// each incoming offer event before Listener is.
+	OnOffer func(DataOfferOfferEvent)
+
+	// OnSourceActions, if not nil, is called with the arguments of
+	// each incoming source_actions event before Listener is.
+	OnSourceActions func(DataOfferSourceActionsEvent)
 
-	state wire.State
-	id    uint32
+	// OnAction, if not nil, is called with the arguments of
+	// each incoming action event before Listener is.
+	OnAction func(DataOfferActionEvent)
 }
 
+var (
+	_ wire.Object      = (*DataOffer)(nil)
+	_ wire.DebugObject = (*DataOffer)(nil)
+)
+
 // NewDataOffer returns a newly instantiated DataOffer. It is
 // primarily intended for use by generated code.
 func NewDataOffer(state wire.State) *DataOffer {
-	return &DataOffer{state: state}
-}
-
-func (obj *DataOffer) State() wire.State {
-	return obj.state
+	return &DataOffer{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
@@ -1746,6 +1743,11 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnOffer != nil {
+			obj.OnOffer(DataOfferOfferEvent{
+				MimeType: mimeType,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1762,6 +1764,11 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSourceActions != nil {
+			obj.OnSourceActions(DataOfferSourceActionsEvent{
+				SourceActions: sourceActions,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1778,6 +1785,11 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAction != nil {
+			obj.OnAction(DataOfferActionEvent{
+				DndAction: dndAction,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1794,22 +1806,8 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataOffer) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataOffer) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataOffer) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataOffer) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_offer", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_offer", obj.ID())
 }
 
 func (obj *DataOffer) MethodName(op uint16) string {
@@ -1831,7 +1829,19 @@ func (obj *DataOffer) Interface() string {
 	return DataOfferInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataOfferVersion, the same as MaxVersion.
 func (obj *DataOffer) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataOfferVersion
+}
+
+// MaxVersion returns DataOfferVersion, the highest version of
+// wl_data_offer that is supported.
+func (obj *DataOffer) MaxVersion() uint32 {
 	return DataOfferVersion
 }
 
@@ -1849,15 +1859,17 @@ func (obj *DataOffer) Version() uint32 {
 // will be cancelled and the corresponding drag source will receive
 // wl_data_source.cancelled. Clients may still use this event in
 // conjunction with wl_data_source.action for feedback.
-func (obj *DataOffer) Accept(serial uint32, mimeType string) {
+func (obj *DataOffer) Accept(serial uint32, mimeType *string) {
 	builder := wire.NewMessage(obj, 0)
 
 	builder.WriteUint(serial)
-	builder.WriteString(mimeType)
+	builder.WriteNullableString(mimeType)
+
+	builder.Fail(wire.CheckMessageSize(DataOfferInterface, "accept", builder))
 
 	builder.Method = "accept"
 	builder.Args = []any{serial, mimeType}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1882,9 +1894,11 @@ func (obj *DataOffer) Receive(mimeType string, fd *os.File) {
 	builder.WriteString(mimeType)
 	builder.WriteFile(fd)
 
+	builder.Fail(wire.CheckMessageSize(DataOfferInterface, "receive", builder))
+
 	builder.Method = "receive"
 	builder.Args = []any{mimeType, fd}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1894,7 +1908,7 @@ func (obj *DataOffer) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1917,7 +1931,7 @@ func (obj *DataOffer) Finish() {
 
 	builder.Method = "finish"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1960,7 +1974,7 @@ func (obj *DataOffer) SetActions(dndActions DataDeviceManagerDndAction, preferre
 
 	builder.Method = "set_actions"
 	builder.Args = []any{dndActions, preferredAction}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1980,22 +1994,25 @@ const (
 	DataOfferErrorInvalidOffer DataOfferError = 3
 )
 
-func (enum DataOfferError) String() string {
-	switch enum {
-	case 0:
-		return "DataOfferErrorInvalidFinish"
-
-	case 1:
-		return "DataOfferErrorInvalidActionMask"
-
-	case 2:
-		return "DataOfferErrorInvalidAction"
+// DataOfferErrorNames maps the values of DataOfferError to their names.
+var DataOfferErrorNames = map[DataOfferError]string{
+	DataOfferErrorInvalidFinish:     "DataOfferErrorInvalidFinish",
+	DataOfferErrorInvalidActionMask: "DataOfferErrorInvalidActionMask",
+	DataOfferErrorInvalidAction:     "DataOfferErrorInvalidAction",
+	DataOfferErrorInvalidOffer:      "DataOfferErrorInvalidOffer",
+}
 
-	case 3:
-		return "DataOfferErrorInvalidOffer"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum DataOfferError) String() string {
+	return wire.EnumString(enum, DataOfferErrorNames)
+}
 
-	return "<invalid DataOfferError>"
+// Since returns the version of wl_data_offer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataOfferError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2010,7 +2027,7 @@ type DataSourceListener interface {
 	// a target does not accept any of the offered types, type is NULL.
 	//
 	// Used for feedback during drag-and-drop.
-	Target(mimeType string)
+	Target(mimeType *string)
 
 	// Request for data from the client.  Send the data as the
 	// specified mime type over the passed file descriptor, then
@@ -2086,44 +2103,104 @@ type DataSourceListener interface {
 	Action(dndAction DataDeviceManagerDndAction)
 }
 
+// DataSourceTargetEvent holds the arguments of a wl_data_source.target
+// event.
+type DataSourceTargetEvent struct {
+	MimeType *string
+}
+
+// DataSourceSendEvent holds the arguments of a wl_data_source.send
+// event.
+type DataSourceSendEvent struct {
+	MimeType string
+	Fd       *os.File
+}
+
+// DataSourceCancelledEvent holds the arguments of a wl_data_source.cancelled
+// event.
+type DataSourceCancelledEvent struct {
+}
+
+// DataSourceDndDropPerformedEvent holds the arguments of a wl_data_source.dnd_drop_performed
+// event.
+type DataSourceDndDropPerformedEvent struct {
+}
+
+// DataSourceDndFinishedEvent holds the arguments of a wl_data_source.dnd_finished
+// event.
+type DataSourceDndFinishedEvent struct {
+}
+
+// DataSourceActionEvent holds the arguments of a wl_data_source.action
+// event.
+type DataSourceActionEvent struct {
+	DndAction DataDeviceManagerDndAction
+}
+
 // The wl_data_source object is the source side of a wl_data_offer.
 // It is created by the source client in a data transfer and
 // provides a way to describe the offered data and a way to respond
 // to requests to transfer the data.
 type DataSource struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DataSourceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnTarget, if not nil, is called with the arguments of
+	// each incoming target event before Listener is.
+	OnTarget func(DataSourceTargetEvent)
+
+	// OnSend, if not nil, is called with the arguments of
+	// each incoming send event before Listener is.
+	OnSend func(DataSourceSendEvent)
+
+	// OnCancelled, if not nil, is called with the arguments of
+	// each incoming cancelled event before Listener is.
+	OnCancelled func(DataSourceCancelledEvent)
 
-	state wire.State
-	id    uint32
+	// OnDndDropPerformed, if not nil, is called with the arguments of
+	// each incoming dnd_drop_performed event before Listener is.
+	OnDndDropPerformed func(DataSourceDndDropPerformedEvent)
+
+	// OnDndFinished, if not nil, is called with the arguments of
+	// each incoming dnd_finished event before Listener is.
+	OnDndFinished func(DataSourceDndFinishedEvent)
+
+	// OnAction, if not nil, is called with the arguments of
+	// each incoming action event before Listener is.
+	OnAction func(DataSourceActionEvent)
 }
 
+var (
+	_ wire.Object      = (*DataSource)(nil)
+	_ wire.DebugObject = (*DataSource)(nil)
+)
+
 // NewDataSource returns a newly instantiated DataSource. It is
 // primarily intended for use by generated code.
 func NewDataSource(state wire.State) *DataSource {
-	return &DataSource{state: state}
-}
-
-func (obj *DataSource) State() wire.State {
-	return obj.state
+	return &DataSource{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		mimeType := msg.ReadString()
+		mimeType := msg.ReadNullableString()
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnTarget != nil {
+			obj.OnTarget(DataSourceTargetEvent{
+				MimeType: mimeType,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2133,6 +2210,9 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 		return nil
 
 	case 1:
+		if have := msg.RemainingFDs(); have < 1 {
+			return wire.MissingFDsError{Interface: "wl_data_source", Method: "send", Want: 1, Have: have}
+		}
 
 		mimeType := msg.ReadString()
 
@@ -2142,6 +2222,17 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if (obj.OnSend == nil) && (obj.Listener == nil) {
+			obj.AddCleanup(fd.Close)
+			return nil
+		}
+
+		if obj.OnSend != nil {
+			obj.OnSend(DataSourceSendEvent{
+				MimeType: mimeType,
+				Fd:       fd,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2156,6 +2247,9 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnCancelled != nil {
+			obj.OnCancelled(DataSourceCancelledEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2167,6 +2261,9 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDndDropPerformed != nil {
+			obj.OnDndDropPerformed(DataSourceDndDropPerformedEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2178,6 +2275,9 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDndFinished != nil {
+			obj.OnDndFinished(DataSourceDndFinishedEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2192,6 +2292,11 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAction != nil {
+			obj.OnAction(DataSourceActionEvent{
+				DndAction: dndAction,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2208,22 +2313,8 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataSource) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataSource) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataSource) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataSource) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_source", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_source", obj.ID())
 }
 
 func (obj *DataSource) MethodName(op uint16) string {
@@ -2254,7 +2345,19 @@ func (obj *DataSource) Interface() string {
 	return DataSourceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataSourceVersion, the same as MaxVersion.
 func (obj *DataSource) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataSourceVersion
+}
+
+// MaxVersion returns DataSourceVersion, the highest version of
+// wl_data_source that is supported.
+func (obj *DataSource) MaxVersion() uint32 {
 	return DataSourceVersion
 }
 
@@ -2266,9 +2369,11 @@ func (obj *DataSource) Offer(mimeType string) {
 
 	builder.WriteString(mimeType)
 
+	builder.Fail(wire.CheckMessageSize(DataSourceInterface, "offer", builder))
+
 	builder.Method = "offer"
 	builder.Args = []any{mimeType}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2278,7 +2383,7 @@ func (obj *DataSource) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2302,7 +2407,7 @@ func (obj *DataSource) SetActions(dndActions DataDeviceManagerDndAction) {
 
 	builder.Method = "set_actions"
 	builder.Args = []any{dndActions}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2316,16 +2421,23 @@ const (
 	DataSourceErrorInvalidSource DataSourceError = 1
 )
 
-func (enum DataSourceError) String() string {
-	switch enum {
-	case 0:
-		return "DataSourceErrorInvalidActionMask"
+// DataSourceErrorNames maps the values of DataSourceError to their names.
+var DataSourceErrorNames = map[DataSourceError]string{
+	DataSourceErrorInvalidActionMask: "DataSourceErrorInvalidActionMask",
+	DataSourceErrorInvalidSource:     "DataSourceErrorInvalidSource",
+}
 
-	case 1:
-		return "DataSourceErrorInvalidSource"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum DataSourceError) String() string {
+	return wire.EnumString(enum, DataSourceErrorNames)
+}
 
-	return "<invalid DataSourceError>"
+// Since returns the version of wl_data_source that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataSourceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2391,48 +2503,114 @@ type DataDeviceListener interface {
 	Selection(id *DataOffer)
 }
 
+// DataDeviceDataOfferEvent holds the arguments of a wl_data_device.data_offer
+// event.
+type DataDeviceDataOfferEvent struct {
+	Id *DataOffer
+}
+
+// DataDeviceEnterEvent holds the arguments of a wl_data_device.enter
+// event.
+type DataDeviceEnterEvent struct {
+	Serial  uint32
+	Surface *Surface
+	X       wire.Fixed
+	Y       wire.Fixed
+	Id      *DataOffer
+}
+
+// DataDeviceLeaveEvent holds the arguments of a wl_data_device.leave
+// event.
+type DataDeviceLeaveEvent struct {
+}
+
+// DataDeviceMotionEvent holds the arguments of a wl_data_device.motion
+// event.
+type DataDeviceMotionEvent struct {
+	Time uint32
+	X    wire.Fixed
+	Y    wire.Fixed
+}
+
+// DataDeviceDropEvent holds the arguments of a wl_data_device.drop
+// event.
+type DataDeviceDropEvent struct {
+}
+
+// DataDeviceSelectionEvent holds the arguments of a wl_data_device.selection
+// event.
+type DataDeviceSelectionEvent struct {
+	Id *DataOffer
+}
+
 // There is one wl_data_device per seat which can be obtained
 // from the global wl_data_device_manager singleton.
 //
 // A wl_data_device provides access to inter-client data transfer
 // mechanisms such as copy-and-paste and drag-and-drop.
 type DataDevice struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DataDeviceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDataOffer, if not nil, is called with the arguments of
+	// each incoming data_offer event before Listener is.
+	OnDataOffer func(DataDeviceDataOfferEvent)
+
+	// OnEnter, if not nil, is called with the arguments of
+	// each incoming enter event before Listener is.
+	OnEnter func(DataDeviceEnterEvent)
+
+	// OnLeave, if not nil, is called with the arguments of
+	// each incoming leave event before Listener is.
+	OnLeave func(DataDeviceLeaveEvent)
+
+	// OnMotion, if not nil, is called with the arguments of
+	// each incoming motion event before Listener is.
+	OnMotion func(DataDeviceMotionEvent)
 
-	state wire.State
-	id    uint32
+	// OnDrop, if not nil, is called with the arguments of
+	// each incoming drop event before Listener is.
+	OnDrop func(DataDeviceDropEvent)
+
+	// OnSelection, if not nil, is called with the arguments of
+	// each incoming selection event before Listener is.
+	OnSelection func(DataDeviceSelectionEvent)
 }
 
+var (
+	_ wire.Object      = (*DataDevice)(nil)
+	_ wire.DebugObject = (*DataDevice)(nil)
+)
+
 // NewDataDevice returns a newly instantiated DataDevice. It is
 // primarily intended for use by generated code.
 func NewDataDevice(state wire.State) *DataDevice {
-	return &DataDevice{state: state}
-}
-
-func (obj *DataDevice) State() wire.State {
-	return obj.state
+	return &DataDevice{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		id := NewDataOffer(obj.state)
+		id := NewDataOffer(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnDataOffer != nil {
+			obj.OnDataOffer(DataDeviceDataOfferEvent{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2445,22 +2623,27 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 
 		serial := msg.ReadUint()
 
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		x := msg.ReadFixed()
 
 		y := msg.ReadFixed()
 
-		id, _ := obj.state.Get(msg.ReadUint()).(*DataOffer)
-
-		obj.state.Add(id)
+		id, _ := obj.State().Get(msg.ReadUint()).(*DataOffer)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnEnter != nil {
+			obj.OnEnter(DataDeviceEnterEvent{
+				Serial:  serial,
+				Surface: surface,
+				X:       x,
+				Y:       y,
+				Id:      id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2478,6 +2661,9 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnLeave != nil {
+			obj.OnLeave(DataDeviceLeaveEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2496,6 +2682,13 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnMotion != nil {
+			obj.OnMotion(DataDeviceMotionEvent{
+				Time: time,
+				X:    x,
+				Y:    y,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2511,6 +2704,9 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDrop != nil {
+			obj.OnDrop(DataDeviceDropEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2519,14 +2715,17 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 5:
 
-		id, _ := obj.state.Get(msg.ReadUint()).(*DataOffer)
-
-		obj.state.Add(id)
+		id, _ := obj.State().Get(msg.ReadUint()).(*DataOffer)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnSelection != nil {
+			obj.OnSelection(DataDeviceSelectionEvent{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2543,22 +2742,8 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataDevice) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataDevice) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataDevice) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataDevice) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_device", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_device", obj.ID())
 }
 
 func (obj *DataDevice) MethodName(op uint16) string {
@@ -2589,7 +2774,19 @@ func (obj *DataDevice) Interface() string {
 	return DataDeviceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataDeviceVersion, the same as MaxVersion.
 func (obj *DataDevice) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataDeviceVersion
+}
+
+// MaxVersion returns DataDeviceVersion, the highest version of
+// wl_data_device that is supported.
+func (obj *DataDevice) MaxVersion() uint32 {
 	return DataDeviceVersion
 }
 
@@ -2631,7 +2828,7 @@ func (obj *DataDevice) StartDrag(source *DataSource, origin *Surface, icon *Surf
 
 	builder.Method = "start_drag"
 	builder.Args = []any{source, origin, icon, serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2647,7 +2844,7 @@ func (obj *DataDevice) SetSelection(source *DataSource, serial uint32) {
 
 	builder.Method = "set_selection"
 	builder.Args = []any{source, serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2657,7 +2854,7 @@ func (obj *DataDevice) Release() {
 
 	builder.Method = "release"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2668,13 +2865,22 @@ const (
 	DataDeviceErrorRole DataDeviceError = 0
 )
 
+// DataDeviceErrorNames maps the values of DataDeviceError to their names.
+var DataDeviceErrorNames = map[DataDeviceError]string{
+	DataDeviceErrorRole: "DataDeviceErrorRole",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum DataDeviceError) String() string {
-	switch enum {
-	case 0:
-		return "DataDeviceErrorRole"
-	}
+	return wire.EnumString(enum, DataDeviceErrorNames)
+}
 
-	return "<invalid DataDeviceError>"
+// Since returns the version of wl_data_device that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataDeviceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2693,30 +2899,37 @@ const (
 // functioning properly. See wl_data_source.set_actions,
 // wl_data_offer.accept and wl_data_offer.finish for details.
 type DataDeviceManager struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*DataDeviceManager)(nil)
+	_ wire.DebugObject = (*DataDeviceManager)(nil)
+)
+
 // NewDataDeviceManager returns a newly instantiated DataDeviceManager. It is
 // primarily intended for use by generated code.
 func NewDataDeviceManager(state wire.State) *DataDeviceManager {
-	return &DataDeviceManager{state: state}
-}
+	return &DataDeviceManager{Proxy: wire.NewProxy(state)}
+}
+
+// BindDataDeviceManager binds the global identified by name to a new
+// DataDeviceManager. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and DataDeviceManagerVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindDataDeviceManager(state wire.State, registry wire.Binder, name, version uint32) (*DataDeviceManager, error) {
+	v := wire.NegotiateVersion(DataDeviceManagerVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: DataDeviceManagerInterface, Local: DataDeviceManagerVersion, Remote: version}
+	}
 
-func BindDataDeviceManager(state wire.State, registry wire.Binder, name, version uint32) *DataDeviceManager {
 	obj := NewDataDeviceManager(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: DataDeviceManagerInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *DataDeviceManager) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: DataDeviceManagerInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *DataDeviceManager) Dispatch(msg *wire.MessageBuffer) error {
@@ -2728,22 +2941,8 @@ func (obj *DataDeviceManager) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataDeviceManager) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataDeviceManager) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataDeviceManager) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataDeviceManager) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_device_manager", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_device_manager", obj.ID())
 }
 
 func (obj *DataDeviceManager) MethodName(op uint16) string {
@@ -2757,7 +2956,19 @@ func (obj *DataDeviceManager) Interface() string {
 	return DataDeviceManagerInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataDeviceManagerVersion, the same as MaxVersion.
 func (obj *DataDeviceManager) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataDeviceManagerVersion
+}
+
+// MaxVersion returns DataDeviceManagerVersion, the highest version of
+// wl_data_device_manager that is supported.
+func (obj *DataDeviceManager) MaxVersion() uint32 {
 	return DataDeviceManagerVersion
 }
 
@@ -2765,13 +2976,14 @@ func (obj *DataDeviceManager) Version() uint32 {
 func (obj *DataDeviceManager) CreateDataSource() (id *DataSource) {
 	builder := wire.NewMessage(obj, 0)
 
-	id = NewDataSource(obj.state)
-	obj.state.Add(id)
+	id = NewDataSource(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "create_data_source"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -2779,14 +2991,15 @@ func (obj *DataDeviceManager) CreateDataSource() (id *DataSource) {
 func (obj *DataDeviceManager) GetDataDevice(seat *Seat) (id *DataDevice) {
 	builder := wire.NewMessage(obj, 1)
 
-	id = NewDataDevice(obj.state)
-	obj.state.Add(id)
+	id = NewDataDevice(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 	builder.WriteObject(seat)
 
 	builder.Method = "get_data_device"
 	builder.Args = []any{id, seat}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -2829,22 +3042,25 @@ const (
 	DataDeviceManagerDndActionAsk DataDeviceManagerDndAction = 4
 )
 
-func (enum DataDeviceManagerDndAction) String() string {
-	switch enum {
-	case 0:
-		return "DataDeviceManagerDndActionNone"
-
-	case 1:
-		return "DataDeviceManagerDndActionCopy"
-
-	case 2:
-		return "DataDeviceManagerDndActionMove"
+// DataDeviceManagerDndActionNames maps the values of DataDeviceManagerDndAction to their names.
+var DataDeviceManagerDndActionNames = map[DataDeviceManagerDndAction]string{
+	DataDeviceManagerDndActionNone: "DataDeviceManagerDndActionNone",
+	DataDeviceManagerDndActionCopy: "DataDeviceManagerDndActionCopy",
+	DataDeviceManagerDndActionMove: "DataDeviceManagerDndActionMove",
+	DataDeviceManagerDndActionAsk:  "DataDeviceManagerDndActionAsk",
+}
 
-	case 4:
-		return "DataDeviceManagerDndActionAsk"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum DataDeviceManagerDndAction) String() string {
+	return wire.FlagString(enum, DataDeviceManagerDndActionNames)
+}
 
-	return "<invalid DataDeviceManagerDndAction>"
+// Since returns the version of wl_data_device_manager that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataDeviceManagerDndAction) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2861,30 +3077,37 @@ const (
 // Note! This protocol is deprecated and not intended for production use.
 // For desktop-style user interfaces, use xdg_shell.
 type Shell struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*Shell)(nil)
+	_ wire.DebugObject = (*Shell)(nil)
+)
+
 // NewShell returns a newly instantiated Shell. It is
 // primarily intended for use by generated code.
 func NewShell(state wire.State) *Shell {
-	return &Shell{state: state}
-}
+	return &Shell{Proxy: wire.NewProxy(state)}
+}
+
+// BindShell binds the global identified by name to a new
+// Shell. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and ShellVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindShell(state wire.State, registry wire.Binder, name, version uint32) (*Shell, error) {
+	v := wire.NegotiateVersion(ShellVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: ShellInterface, Local: ShellVersion, Remote: version}
+	}
 
-func BindShell(state wire.State, registry wire.Binder, name, version uint32) *Shell {
 	obj := NewShell(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: ShellInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *Shell) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: ShellInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *Shell) Dispatch(msg *wire.MessageBuffer) error {
@@ -2896,22 +3119,8 @@ func (obj *Shell) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Shell) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Shell) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Shell) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Shell) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shell", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shell", obj.ID())
 }
 
 func (obj *Shell) MethodName(op uint16) string {
@@ -2925,7 +3134,19 @@ func (obj *Shell) Interface() string {
 	return ShellInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShellVersion, the same as MaxVersion.
 func (obj *Shell) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShellVersion
+}
+
+// MaxVersion returns ShellVersion, the highest version of
+// wl_shell that is supported.
+func (obj *Shell) MaxVersion() uint32 {
 	return ShellVersion
 }
 
@@ -2937,14 +3158,15 @@ func (obj *Shell) Version() uint32 {
 func (obj *Shell) GetShellSurface(surface *Surface) (id *ShellSurface) {
 	builder := wire.NewMessage(obj, 0)
 
-	id = NewShellSurface(obj.state)
-	obj.state.Add(id)
+	id = NewShellSurface(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 	builder.WriteObject(surface)
 
 	builder.Method = "get_shell_surface"
 	builder.Args = []any{id, surface}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -2955,13 +3177,22 @@ const (
 	ShellErrorRole ShellError = 0
 )
 
+// ShellErrorNames maps the values of ShellError to their names.
+var ShellErrorNames = map[ShellError]string{
+	ShellErrorRole: "ShellErrorRole",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum ShellError) String() string {
-	switch enum {
-	case 0:
-		return "ShellErrorRole"
-	}
+	return wire.EnumString(enum, ShellErrorNames)
+}
 
-	return "<invalid ShellError>"
+// Since returns the version of wl_shell that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -3001,6 +3232,25 @@ type ShellSurfaceListener interface {
 	PopupDone()
 }
 
+// ShellSurfacePingEvent holds the arguments of a wl_shell_surface.ping
+// event.
+type ShellSurfacePingEvent struct {
+	Serial uint32
+}
+
+// ShellSurfaceConfigureEvent holds the arguments of a wl_shell_surface.configure
+// event.
+type ShellSurfaceConfigureEvent struct {
+	Edges  ShellSurfaceResize
+	Width  int32
+	Height int32
+}
+
+// ShellSurfacePopupDoneEvent holds the arguments of a wl_shell_surface.popup_done
+// event.
+type ShellSurfacePopupDoneEvent struct {
+}
+
 // An interface that may be implemented by a wl_surface, for
 // implementations that provide a desktop-style user interface.
 //
@@ -3013,27 +3263,36 @@ type ShellSurfaceListener interface {
 // wl_shell_surface_destroy() must be called before destroying
 // the wl_surface object.
 type ShellSurface struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ShellSurfaceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnPing, if not nil, is called with the arguments of
+	// each incoming ping event before Listener is.
+	OnPing func(ShellSurfacePingEvent)
 
-	state wire.State
-	id    uint32
+	// OnConfigure, if not nil, is called with the arguments of
+	// each incoming configure event before Listener is.
+	OnConfigure func(ShellSurfaceConfigureEvent)
+
+	// OnPopupDone, if not nil, is called with the arguments of
+	// each incoming popup_done event before Listener is.
+	OnPopupDone func(ShellSurfacePopupDoneEvent)
 }
 
+var (
+	_ wire.Object      = (*ShellSurface)(nil)
+	_ wire.DebugObject = (*ShellSurface)(nil)
+)
+
 // NewShellSurface returns a newly instantiated ShellSurface. It is
 // primarily intended for use by generated code.
 func NewShellSurface(state wire.State) *ShellSurface {
-	return &ShellSurface{state: state}
-}
-
-func (obj *ShellSurface) State() wire.State {
-	return obj.state
+	return &ShellSurface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
@@ -3046,6 +3305,11 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnPing != nil {
+			obj.OnPing(ShellSurfacePingEvent{
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3066,6 +3330,13 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnConfigure != nil {
+			obj.OnConfigure(ShellSurfaceConfigureEvent{
+				Edges:  edges,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3081,6 +3352,9 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnPopupDone != nil {
+			obj.OnPopupDone(ShellSurfacePopupDoneEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3095,22 +3369,8 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *ShellSurface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *ShellSurface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *ShellSurface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *ShellSurface) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shell_surface", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shell_surface", obj.ID())
 }
 
 func (obj *ShellSurface) MethodName(op uint16) string {
@@ -3132,7 +3392,19 @@ func (obj *ShellSurface) Interface() string {
 	return ShellSurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShellSurfaceVersion, the same as MaxVersion.
 func (obj *ShellSurface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShellSurfaceVersion
+}
+
+// MaxVersion returns ShellSurfaceVersion, the highest version of
+// wl_shell_surface that is supported.
+func (obj *ShellSurface) MaxVersion() uint32 {
 	return ShellSurfaceVersion
 }
 
@@ -3145,7 +3417,7 @@ func (obj *ShellSurface) Pong(serial uint32) {
 
 	builder.Method = "pong"
 	builder.Args = []any{serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3162,7 +3434,7 @@ func (obj *ShellSurface) Move(seat *Seat, serial uint32) {
 
 	builder.Method = "move"
 	builder.Args = []any{seat, serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3180,7 +3452,7 @@ func (obj *ShellSurface) Resize(seat *Seat, serial uint32, edges ShellSurfaceRes
 
 	builder.Method = "resize"
 	builder.Args = []any{seat, serial, edges}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3192,7 +3464,7 @@ func (obj *ShellSurface) SetToplevel() {
 
 	builder.Method = "set_toplevel"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3213,7 +3485,7 @@ func (obj *ShellSurface) SetTransient(parent *Surface, x int32, y int32, flags S
 
 	builder.Method = "set_transient"
 	builder.Args = []any{parent, x, y, flags}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3259,7 +3531,7 @@ func (obj *ShellSurface) SetFullscreen(method ShellSurfaceFullscreenMethod, fram
 
 	builder.Method = "set_fullscreen"
 	builder.Args = []any{method, framerate, output}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3294,7 +3566,7 @@ func (obj *ShellSurface) SetPopup(seat *Seat, serial uint32, parent *Surface, x
 
 	builder.Method = "set_popup"
 	builder.Args = []any{seat, serial, parent, x, y, flags}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3323,7 +3595,7 @@ func (obj *ShellSurface) SetMaximized(output *Output) {
 
 	builder.Method = "set_maximized"
 	builder.Args = []any{output}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3339,9 +3611,11 @@ func (obj *ShellSurface) SetTitle(title string) {
 
 	builder.WriteString(title)
 
+	builder.Fail(wire.CheckMessageSize(ShellSurfaceInterface, "set_title", builder))
+
 	builder.Method = "set_title"
 	builder.Args = []any{title}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3356,9 +3630,11 @@ func (obj *ShellSurface) SetClass(class string) {
 
 	builder.WriteString(class)
 
+	builder.Fail(wire.CheckMessageSize(ShellSurfaceInterface, "set_class", builder))
+
 	builder.Method = "set_class"
 	builder.Args = []any{class}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3397,37 +3673,30 @@ const (
 	ShellSurfaceResizeBottomRight ShellSurfaceResize = 10
 )
 
+// ShellSurfaceResizeNames maps the values of ShellSurfaceResize to their names.
+var ShellSurfaceResizeNames = map[ShellSurfaceResize]string{
+	ShellSurfaceResizeNone:        "ShellSurfaceResizeNone",
+	ShellSurfaceResizeTop:         "ShellSurfaceResizeTop",
+	ShellSurfaceResizeBottom:      "ShellSurfaceResizeBottom",
+	ShellSurfaceResizeLeft:        "ShellSurfaceResizeLeft",
+	ShellSurfaceResizeTopLeft:     "ShellSurfaceResizeTopLeft",
+	ShellSurfaceResizeBottomLeft:  "ShellSurfaceResizeBottomLeft",
+	ShellSurfaceResizeRight:       "ShellSurfaceResizeRight",
+	ShellSurfaceResizeTopRight:    "ShellSurfaceResizeTopRight",
+	ShellSurfaceResizeBottomRight: "ShellSurfaceResizeBottomRight",
+}
+
+// String returns the names of the flags set in enum, joined
+// with "|".
 func (enum ShellSurfaceResize) String() string {
-	switch enum {
-	case 0:
-		return "ShellSurfaceResizeNone"
-
-	case 1:
-		return "ShellSurfaceResizeTop"
-
-	case 2:
-		return "ShellSurfaceResizeBottom"
-
-	case 4:
-		return "ShellSurfaceResizeLeft"
-
-	case 5:
-		return "ShellSurfaceResizeTopLeft"
-
-	case 6:
-		return "ShellSurfaceResizeBottomLeft"
-
-	case 8:
-		return "ShellSurfaceResizeRight"
-
-	case 9:
-		return "ShellSurfaceResizeTopRight"
-
-	case 10:
-		return "ShellSurfaceResizeBottomRight"
-	}
+	return wire.FlagString(enum, ShellSurfaceResizeNames)
+}
 
-	return "<invalid ShellSurfaceResize>"
+// Since returns the version of wl_shell_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellSurfaceResize) Since() uint32 {
+	return 1
 }
 
 // These flags specify details of the expected behaviour
@@ -3439,13 +3708,22 @@ const (
 	ShellSurfaceTransientInactive ShellSurfaceTransient = 1
 )
 
+// ShellSurfaceTransientNames maps the values of ShellSurfaceTransient to their names.
+var ShellSurfaceTransientNames = map[ShellSurfaceTransient]string{
+	ShellSurfaceTransientInactive: "ShellSurfaceTransientInactive",
+}
+
+// String returns the names of the flags set in enum, joined
+// with "|".
 func (enum ShellSurfaceTransient) String() string {
-	switch enum {
-	case 1:
-		return "ShellSurfaceTransientInactive"
-	}
+	return wire.FlagString(enum, ShellSurfaceTransientNames)
+}
 
-	return "<invalid ShellSurfaceTransient>"
+// Since returns the version of wl_shell_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellSurfaceTransient) Since() uint32 {
+	return 1
 }
 
 // Hints to indicate to the compositor how to deal with a conflict
@@ -3467,22 +3745,25 @@ const (
 	ShellSurfaceFullscreenMethodFill ShellSurfaceFullscreenMethod = 3
 )
 
-func (enum ShellSurfaceFullscreenMethod) String() string {
-	switch enum {
-	case 0:
-		return "ShellSurfaceFullscreenMethodDefault"
-
-	case 1:
-		return "ShellSurfaceFullscreenMethodScale"
-
-	case 2:
-		return "ShellSurfaceFullscreenMethodDriver"
+// ShellSurfaceFullscreenMethodNames maps the values of ShellSurfaceFullscreenMethod to their names.
+var ShellSurfaceFullscreenMethodNames = map[ShellSurfaceFullscreenMethod]string{
+	ShellSurfaceFullscreenMethodDefault: "ShellSurfaceFullscreenMethodDefault",
+	ShellSurfaceFullscreenMethodScale:   "ShellSurfaceFullscreenMethodScale",
+	ShellSurfaceFullscreenMethodDriver:  "ShellSurfaceFullscreenMethodDriver",
+	ShellSurfaceFullscreenMethodFill:    "ShellSurfaceFullscreenMethodFill",
+}
 
-	case 3:
-		return "ShellSurfaceFullscreenMethodFill"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ShellSurfaceFullscreenMethod) String() string {
+	return wire.EnumString(enum, ShellSurfaceFullscreenMethodNames)
+}
 
-	return "<invalid ShellSurfaceFullscreenMethod>"
+// Since returns the version of wl_shell_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellSurfaceFullscreenMethod) Since() uint32 {
+	return 1
 }
 
 const (
@@ -3512,6 +3793,18 @@ type SurfaceListener interface {
 	Leave(output *Output)
 }
 
+// SurfaceEnterEvent holds the arguments of a wl_surface.enter
+// event.
+type SurfaceEnterEvent struct {
+	Output *Output
+}
+
+// SurfaceLeaveEvent holds the arguments of a wl_surface.leave
+// event.
+type SurfaceLeaveEvent struct {
+	Output *Output
+}
+
 // A surface is a rectangular area that may be displayed on zero
 // or more outputs, and shown any number of times at the compositor's
 // discretion. They can present wl_buffers, receive user input, and
@@ -3554,41 +3847,49 @@ type SurfaceListener interface {
 // a cursor (cursor is a different role than sub-surface, and role
 // switching is not allowed).
 type Surface struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SurfaceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnEnter, if not nil, is called with the arguments of
+	// each incoming enter event before Listener is.
+	OnEnter func(SurfaceEnterEvent)
 
-	state wire.State
-	id    uint32
+	// OnLeave, if not nil, is called with the arguments of
+	// each incoming leave event before Listener is.
+	OnLeave func(SurfaceLeaveEvent)
 }
 
+var (
+	_ wire.Object      = (*Surface)(nil)
+	_ wire.DebugObject = (*Surface)(nil)
+)
+
 // NewSurface returns a newly instantiated Surface. It is
 // primarily intended for use by generated code.
 func NewSurface(state wire.State) *Surface {
-	return &Surface{state: state}
-}
-
-func (obj *Surface) State() wire.State {
-	return obj.state
+	return &Surface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		output, _ := obj.state.Get(msg.ReadUint()).(*Output)
-
-		obj.state.Add(output)
+		output, _ := obj.State().Get(msg.ReadUint()).(*Output)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnEnter != nil {
+			obj.OnEnter(SurfaceEnterEvent{
+				Output: output,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3599,14 +3900,17 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		output, _ := obj.state.Get(msg.ReadUint()).(*Output)
-
-		obj.state.Add(output)
+		output, _ := obj.State().Get(msg.ReadUint()).(*Output)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnLeave != nil {
+			obj.OnLeave(SurfaceLeaveEvent{
+				Output: output,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3623,22 +3927,8 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Surface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Surface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Surface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Surface) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_surface", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_surface", obj.ID())
 }
 
 func (obj *Surface) MethodName(op uint16) string {
@@ -3657,7 +3947,19 @@ func (obj *Surface) Interface() string {
 	return SurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SurfaceVersion, the same as MaxVersion.
 func (obj *Surface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SurfaceVersion
+}
+
+// MaxVersion returns SurfaceVersion, the highest version of
+// wl_surface that is supported.
+func (obj *Surface) MaxVersion() uint32 {
 	return SurfaceVersion
 }
 
@@ -3667,7 +3969,7 @@ func (obj *Surface) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3726,7 +4028,7 @@ func (obj *Surface) Attach(buffer *Buffer, x int32, y int32) {
 
 	builder.Method = "attach"
 	builder.Args = []any{buffer, x, y}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3761,7 +4063,7 @@ func (obj *Surface) Damage(x int32, y int32, width int32, height int32) {
 
 	builder.Method = "damage"
 	builder.Args = []any{x, y, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3800,13 +4102,14 @@ func (obj *Surface) Damage(x int32, y int32, width int32, height int32) {
 func (obj *Surface) Frame() (callback *Callback) {
 	builder := wire.NewMessage(obj, 3)
 
-	callback = NewCallback(obj.state)
-	obj.state.Add(callback)
+	callback = NewCallback(obj.State())
+	callback.SetVersion(obj.Version())
+	obj.State().Add(callback)
 	builder.WriteObject(callback)
 
 	builder.Method = "frame"
 	builder.Args = []any{callback}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return callback
 }
 
@@ -3841,7 +4144,7 @@ func (obj *Surface) SetOpaqueRegion(region *Region) {
 
 	builder.Method = "set_opaque_region"
 	builder.Args = []any{region}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3874,7 +4177,7 @@ func (obj *Surface) SetInputRegion(region *Region) {
 
 	builder.Method = "set_input_region"
 	builder.Args = []any{region}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3900,7 +4203,7 @@ func (obj *Surface) Commit() {
 
 	builder.Method = "commit"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3940,7 +4243,7 @@ func (obj *Surface) SetBufferTransform(transform OutputTransform) {
 
 	builder.Method = "set_buffer_transform"
 	builder.Args = []any{transform}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3974,7 +4277,7 @@ func (obj *Surface) SetBufferScale(scale int32) {
 
 	builder.Method = "set_buffer_scale"
 	builder.Args = []any{scale}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4020,7 +4323,7 @@ func (obj *Surface) DamageBuffer(x int32, y int32, width int32, height int32) {
 
 	builder.Method = "damage_buffer"
 	builder.Args = []any{x, y, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4038,19 +4341,24 @@ const (
 	SurfaceErrorInvalidSize SurfaceError = 2
 )
 
-func (enum SurfaceError) String() string {
-	switch enum {
-	case 0:
-		return "SurfaceErrorInvalidScale"
-
-	case 1:
-		return "SurfaceErrorInvalidTransform"
+// SurfaceErrorNames maps the values of SurfaceError to their names.
+var SurfaceErrorNames = map[SurfaceError]string{
+	SurfaceErrorInvalidScale:     "SurfaceErrorInvalidScale",
+	SurfaceErrorInvalidTransform: "SurfaceErrorInvalidTransform",
+	SurfaceErrorInvalidSize:      "SurfaceErrorInvalidSize",
+}
 
-	case 2:
-		return "SurfaceErrorInvalidSize"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum SurfaceError) String() string {
+	return wire.EnumString(enum, SurfaceErrorNames)
+}
 
-	return "<invalid SurfaceError>"
+// Since returns the version of wl_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SurfaceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -4093,39 +4401,68 @@ type SeatListener interface {
 	Name(name string)
 }
 
+// SeatCapabilitiesEvent holds the arguments of a wl_seat.capabilities
+// event.
+type SeatCapabilitiesEvent struct {
+	Capabilities SeatCapability
+}
+
+// SeatNameEvent holds the arguments of a wl_seat.name
+// event.
+type SeatNameEvent struct {
+	Name string
+}
+
 // A seat is a group of keyboards, pointer and touch devices. This
 // object is published as a global during start up, or when such a
 // device is hot plugged.  A seat typically has a pointer and
 // maintains a keyboard focus and a pointer focus.
 type Seat struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SeatListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnCapabilities, if not nil, is called with the arguments of
+	// each incoming capabilities event before Listener is.
+	OnCapabilities func(SeatCapabilitiesEvent)
 
-	state wire.State
-	id    uint32
+	// OnName, if not nil, is called with the arguments of
+	// each incoming name event before Listener is.
+	OnName func(SeatNameEvent)
 }
 
+var (
+	_ wire.Object      = (*Seat)(nil)
+	_ wire.DebugObject = (*Seat)(nil)
+)
+
 // NewSeat returns a newly instantiated Seat. It is
 // primarily intended for use by generated code.
 func NewSeat(state wire.State) *Seat {
-	return &Seat{state: state}
-}
+	return &Seat{Proxy: wire.NewProxy(state)}
+}
+
+// BindSeat binds the global identified by name to a new
+// Seat. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and SeatVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindSeat(state wire.State, registry wire.Binder, name, version uint32) (*Seat, error) {
+	v := wire.NegotiateVersion(SeatVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: SeatInterface, Local: SeatVersion, Remote: version}
+	}
 
-func BindSeat(state wire.State, registry wire.Binder, name, version uint32) *Seat {
 	obj := NewSeat(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: SeatInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *Seat) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: SeatInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
@@ -4138,6 +4475,11 @@ func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnCapabilities != nil {
+			obj.OnCapabilities(SeatCapabilitiesEvent{
+				Capabilities: capabilities,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4154,6 +4496,11 @@ func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnName != nil {
+			obj.OnName(SeatNameEvent{
+				Name: name,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4170,22 +4517,8 @@ func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Seat) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Seat) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Seat) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Seat) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_seat", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_seat", obj.ID())
 }
 
 func (obj *Seat) MethodName(op uint16) string {
@@ -4204,7 +4537,19 @@ func (obj *Seat) Interface() string {
 	return SeatInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SeatVersion, the same as MaxVersion.
 func (obj *Seat) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SeatVersion
+}
+
+// MaxVersion returns SeatVersion, the highest version of
+// wl_seat that is supported.
+func (obj *Seat) MaxVersion() uint32 {
 	return SeatVersion
 }
 
@@ -4219,13 +4564,14 @@ func (obj *Seat) Version() uint32 {
 func (obj *Seat) GetPointer() (id *Pointer) {
 	builder := wire.NewMessage(obj, 0)
 
-	id = NewPointer(obj.state)
-	obj.state.Add(id)
+	id = NewPointer(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "get_pointer"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -4240,13 +4586,14 @@ func (obj *Seat) GetPointer() (id *Pointer) {
 func (obj *Seat) GetKeyboard() (id *Keyboard) {
 	builder := wire.NewMessage(obj, 1)
 
-	id = NewKeyboard(obj.state)
-	obj.state.Add(id)
+	id = NewKeyboard(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "get_keyboard"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -4261,13 +4608,14 @@ func (obj *Seat) GetKeyboard() (id *Keyboard) {
 func (obj *Seat) GetTouch() (id *Touch) {
 	builder := wire.NewMessage(obj, 2)
 
-	id = NewTouch(obj.state)
-	obj.state.Add(id)
+	id = NewTouch(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "get_touch"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -4278,7 +4626,7 @@ func (obj *Seat) Release() {
 
 	builder.Method = "release"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4297,19 +4645,24 @@ const (
 	SeatCapabilityTouch SeatCapability = 4
 )
 
-func (enum SeatCapability) String() string {
-	switch enum {
-	case 1:
-		return "SeatCapabilityPointer"
-
-	case 2:
-		return "SeatCapabilityKeyboard"
+// SeatCapabilityNames maps the values of SeatCapability to their names.
+var SeatCapabilityNames = map[SeatCapability]string{
+	SeatCapabilityPointer:  "SeatCapabilityPointer",
+	SeatCapabilityKeyboard: "SeatCapabilityKeyboard",
+	SeatCapabilityTouch:    "SeatCapabilityTouch",
+}
 
-	case 4:
-		return "SeatCapabilityTouch"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum SeatCapability) String() string {
+	return wire.FlagString(enum, SeatCapabilityNames)
+}
 
-	return "<invalid SeatCapability>"
+// Since returns the version of wl_seat that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SeatCapability) Since() uint32 {
+	return 1
 }
 
 // These errors can be emitted in response to wl_seat requests.
@@ -4320,13 +4673,22 @@ const (
 	SeatErrorMissingCapability SeatError = 0
 )
 
+// SeatErrorNames maps the values of SeatError to their names.
+var SeatErrorNames = map[SeatError]string{
+	SeatErrorMissingCapability: "SeatErrorMissingCapability",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum SeatError) String() string {
-	switch enum {
-	case 0:
-		return "SeatErrorMissingCapability"
-	}
+	return wire.EnumString(enum, SeatErrorNames)
+}
 
-	return "<invalid SeatError>"
+// Since returns the version of wl_seat that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SeatError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -4499,6 +4861,72 @@ type PointerListener interface {
 	AxisDiscrete(axis PointerAxis, discrete int32)
 }
 
+// PointerEnterEvent holds the arguments of a wl_pointer.enter
+// event.
+type PointerEnterEvent struct {
+	Serial   uint32
+	Surface  *Surface
+	SurfaceX wire.Fixed
+	SurfaceY wire.Fixed
+}
+
+// PointerLeaveEvent holds the arguments of a wl_pointer.leave
+// event.
+type PointerLeaveEvent struct {
+	Serial  uint32
+	Surface *Surface
+}
+
+// PointerMotionEvent holds the arguments of a wl_pointer.motion
+// event.
+type PointerMotionEvent struct {
+	Time     uint32
+	SurfaceX wire.Fixed
+	SurfaceY wire.Fixed
+}
+
+// PointerButtonEvent holds the arguments of a wl_pointer.button
+// event.
+type PointerButtonEvent struct {
+	Serial uint32
+	Time   uint32
+	Button uint32
+	State  PointerButtonState
+}
+
+// PointerAxisEvent holds the arguments of a wl_pointer.axis
+// event.
+type PointerAxisEvent struct {
+	Time  uint32
+	Axis  PointerAxis
+	Value wire.Fixed
+}
+
+// PointerFrameEvent holds the arguments of a wl_pointer.frame
+// event.
+type PointerFrameEvent struct {
+}
+
+// PointerAxisSourceEvent holds the arguments of a wl_pointer.axis_source
+// event.
+type PointerAxisSourceEvent struct {
+	AxisSource PointerAxisSource
+}
+
+// PointerAxisStopEvent holds the arguments of a wl_pointer.axis_stop
+// event.
+type PointerAxisStopEvent struct {
+	Time uint32
+	Axis PointerAxis
+}
+
+// PointerAxisDiscreteEvent holds the arguments of a wl_pointer.axis_discrete
+// event.
+type PointerAxisDiscreteEvent struct {
+	Axis     PointerAxis
+	Discrete int32
+}
+
 // The wl_pointer interface represents one or more input devices,
 // such as mice, which control the pointer location and pointer_focus
 // of a seat.
@@ -4508,27 +4936,60 @@ type PointerListener interface {
 // and button and axis events for button presses, button releases
 // and scrolling.
 type Pointer struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener PointerListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnEnter, if not nil, is called with the arguments of
+	// each incoming enter event before Listener is.
+	OnEnter func(PointerEnterEvent)
+
+	// OnLeave, if not nil, is called with the arguments of
+	// each incoming leave event before Listener is.
+	OnLeave func(PointerLeaveEvent)
+
+	// OnMotion, if not nil, is called with the arguments of
+	// each incoming motion event before Listener is.
+	OnMotion func(PointerMotionEvent)
 
-	state wire.State
-	id    uint32
+	// OnButton, if not nil, is called with the arguments of
+	// each incoming button event before Listener is.
+	OnButton func(PointerButtonEvent)
+
+	// OnAxis, if not nil, is called with the arguments of
+	// each incoming axis event before Listener is.
+	OnAxis func(PointerAxisEvent)
+
+	// OnFrame, if not nil, is called with the arguments of
+	// each incoming frame event before Listener is.
+	OnFrame func(PointerFrameEvent)
+
+	// OnAxisSource, if not nil, is called with the arguments of
+	// each incoming axis_source event before Listener is.
+	OnAxisSource func(PointerAxisSourceEvent)
+
+	// OnAxisStop, if not nil, is called with the arguments of
+	// each incoming axis_stop event before Listener is.
+	OnAxisStop func(PointerAxisStopEvent)
+
+	// OnAxisDiscrete, if not nil, is called with the arguments of
+	// each incoming axis_discrete event before Listener is.
+	OnAxisDiscrete func(PointerAxisDiscreteEvent)
 }
 
+var (
+	_ wire.Object      = (*Pointer)(nil)
+	_ wire.DebugObject = (*Pointer)(nil)
+)
+
 // NewPointer returns a newly instantiated Pointer. It is
 // primarily intended for use by generated code.
 func NewPointer(state wire.State) *Pointer {
-	return &Pointer{state: state}
-}
-
-func (obj *Pointer) State() wire.State {
-	return obj.state
+	return &Pointer{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
@@ -4537,9 +4998,7 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 
 		serial := msg.ReadUint()
 
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		surfaceX := msg.ReadFixed()
 
@@ -4549,6 +5008,14 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnEnter != nil {
+			obj.OnEnter(PointerEnterEvent{
+				Serial:   serial,
+				Surface:  surface,
+				SurfaceX: surfaceX,
+				SurfaceY: surfaceY,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4564,14 +5031,18 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 
 		serial := msg.ReadUint()
 
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnLeave != nil {
+			obj.OnLeave(PointerLeaveEvent{
+				Serial:  serial,
+				Surface: surface,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4593,6 +5064,13 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnMotion != nil {
+			obj.OnMotion(PointerMotionEvent{
+				Time:     time,
+				SurfaceX: surfaceX,
+				SurfaceY: surfaceY,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4617,6 +5095,14 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnButton != nil {
+			obj.OnButton(PointerButtonEvent{
+				Serial: serial,
+				Time:   time,
+				Button: button,
+				State:  state,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4640,6 +5126,13 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAxis != nil {
+			obj.OnAxis(PointerAxisEvent{
+				Time:  time,
+				Axis:  axis,
+				Value: value,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4655,6 +5148,9 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnFrame != nil {
+			obj.OnFrame(PointerFrameEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4668,7 +5164,15 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		if err := wire.CheckEnumVersion(PointerInterface, "axis_source", obj.Version(), axisSource); err != nil {
+			return err
+		}
 
+		if obj.OnAxisSource != nil {
+			obj.OnAxisSource(PointerAxisSourceEvent{
+				AxisSource: axisSource,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4687,6 +5191,12 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAxisStop != nil {
+			obj.OnAxisStop(PointerAxisStopEvent{
+				Time: time,
+				Axis: axis,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4706,6 +5216,12 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAxisDiscrete != nil {
+			obj.OnAxisDiscrete(PointerAxisDiscreteEvent{
+				Axis:     axis,
+				Discrete: discrete,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4723,22 +5239,8 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Pointer) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Pointer) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Pointer) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Pointer) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_pointer", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_pointer", obj.ID())
 }
 
 func (obj *Pointer) MethodName(op uint16) string {
@@ -4778,7 +5280,19 @@ func (obj *Pointer) Interface() string {
 	return PointerInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// PointerVersion, the same as MaxVersion.
 func (obj *Pointer) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return PointerVersion
+}
+
+// MaxVersion returns PointerVersion, the highest version of
+// wl_pointer that is supported.
+func (obj *Pointer) MaxVersion() uint32 {
 	return PointerVersion
 }
 
@@ -4823,7 +5337,7 @@ func (obj *Pointer) SetCursor(serial uint32, surface *Surface, hotspotX int32, h
 
 	builder.Method = "set_cursor"
 	builder.Args = []any{serial, surface, hotspotX, hotspotY}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4837,7 +5351,7 @@ func (obj *Pointer) Release() {
 
 	builder.Method = "release"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4848,13 +5362,22 @@ const (
 	PointerErrorRole PointerError = 0
 )
 
+// PointerErrorNames maps the values of PointerError to their names.
+var PointerErrorNames = map[PointerError]string{
+	PointerErrorRole: "PointerErrorRole",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PointerError) String() string {
-	switch enum {
-	case 0:
-		return "PointerErrorRole"
-	}
+	return wire.EnumString(enum, PointerErrorNames)
+}
 
-	return "<invalid PointerError>"
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerError) Since() uint32 {
+	return 1
 }
 
 // Describes the physical state of a button that produced the button
@@ -4869,16 +5392,23 @@ const (
 	PointerButtonStatePressed PointerButtonState = 1
 )
 
-func (enum PointerButtonState) String() string {
-	switch enum {
-	case 0:
-		return "PointerButtonStateReleased"
+// PointerButtonStateNames maps the values of PointerButtonState to their names.
+var PointerButtonStateNames = map[PointerButtonState]string{
+	PointerButtonStateReleased: "PointerButtonStateReleased",
+	PointerButtonStatePressed:  "PointerButtonStatePressed",
+}
 
-	case 1:
-		return "PointerButtonStatePressed"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum PointerButtonState) String() string {
+	return wire.EnumString(enum, PointerButtonStateNames)
+}
 
-	return "<invalid PointerButtonState>"
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerButtonState) Since() uint32 {
+	return 1
 }
 
 // Describes the axis types of scroll events.
@@ -4892,16 +5422,23 @@ const (
 	PointerAxisHorizontalScroll PointerAxis = 1
 )
 
-func (enum PointerAxis) String() string {
-	switch enum {
-	case 0:
-		return "PointerAxisVerticalScroll"
+// PointerAxisNames maps the values of PointerAxis to their names.
+var PointerAxisNames = map[PointerAxis]string{
+	PointerAxisVerticalScroll:   "PointerAxisVerticalScroll",
+	PointerAxisHorizontalScroll: "PointerAxisHorizontalScroll",
+}
 
-	case 1:
-		return "PointerAxisHorizontalScroll"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum PointerAxis) String() string {
+	return wire.EnumString(enum, PointerAxisNames)
+}
 
-	return "<invalid PointerAxis>"
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerAxis) Since() uint32 {
+	return 1
 }
 
 // Describes the source types for axis events. This indicates to the
@@ -4933,25 +5470,34 @@ const (
 	PointerAxisSourceContinuous PointerAxisSource = 2
 
 	// a physical wheel tilt
+	//
+	// Since version 6.
 	PointerAxisSourceWheelTilt PointerAxisSource = 3
 )
 
-func (enum PointerAxisSource) String() string {
-	switch enum {
-	case 0:
-		return "PointerAxisSourceWheel"
-
-	case 1:
-		return "PointerAxisSourceFinger"
+// PointerAxisSourceNames maps the values of PointerAxisSource to their names.
+var PointerAxisSourceNames = map[PointerAxisSource]string{
+	PointerAxisSourceWheel:      "PointerAxisSourceWheel",
+	PointerAxisSourceFinger:     "PointerAxisSourceFinger",
+	PointerAxisSourceContinuous: "PointerAxisSourceContinuous",
+	PointerAxisSourceWheelTilt:  "PointerAxisSourceWheelTilt",
+}
 
-	case 2:
-		return "PointerAxisSourceContinuous"
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum PointerAxisSource) String() string {
+	return wire.EnumString(enum, PointerAxisSourceNames)
+}
 
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerAxisSource) Since() uint32 {
+	switch enum {
 	case 3:
-		return "PointerAxisSourceWheelTilt"
+		return 6
 	}
-
-	return "<invalid PointerAxisSource>"
+	return 1
 }
 
 const (
@@ -5001,50 +5547,123 @@ type KeyboardListener interface {
 	// changed, and it should update its local state.
 	Modifiers(serial uint32, modsDepressed uint32, modsLatched uint32, modsLocked uint32, group uint32)
 
-	// Informs the client about the keyboard's repeat rate and delay.
-	//
-	// This event is sent as soon as the wl_keyboard object has been created,
-	// and is guaranteed to be received by the client before any key press
-	// event.
-	//
-	// Negative values for either rate or delay are illegal. A rate of zero
-	// will disable any repeating (regardless of the value of delay).
-	//
-	// This event can be sent later on as well with a new value if necessary,
-	// so clients should continue listening for the event past the creation
-	// of wl_keyboard.
-	RepeatInfo(rate int32, delay int32)
+	// Informs the client about the keyboard's repeat rate and delay.
+	//
+	// This event is sent as soon as the wl_keyboard object has been created,
+	// and is guaranteed to be received by the client before any key press
+	// event.
+	//
+	// Negative values for either rate or delay are illegal. A rate of zero
+	// will disable any repeating (regardless of the value of delay).
+	//
+	// This event can be sent later on as well with a new value if necessary,
+	// so clients should continue listening for the event past the creation
+	// of wl_keyboard.
+	RepeatInfo(rate int32, delay int32)
+}
+
+// KeyboardKeymapEvent holds the arguments of a wl_keyboard.keymap
+// event.
+type KeyboardKeymapEvent struct {
+	Format KeyboardKeymapFormat
+	Fd     *os.File
+	Size   uint32
+}
+
+// KeyboardEnterEvent holds the arguments of a wl_keyboard.enter
+// event.
+type KeyboardEnterEvent struct {
+	Serial  uint32
+	Surface *Surface
+	Keys    []byte
+}
+
+// KeyboardLeaveEvent holds the arguments of a wl_keyboard.leave
+// event.
+type KeyboardLeaveEvent struct {
+	Serial  uint32
+	Surface *Surface
+}
+
+// KeyboardKeyEvent holds the arguments of a wl_keyboard.key
+// event.
+type KeyboardKeyEvent struct {
+	Serial uint32
+	Time   uint32
+	Key    uint32
+	State  KeyboardKeyState
+}
+
+// KeyboardModifiersEvent holds the arguments of a wl_keyboard.modifiers
+// event.
+type KeyboardModifiersEvent struct {
+	Serial        uint32
+	ModsDepressed uint32
+	ModsLatched   uint32
+	ModsLocked    uint32
+	Group         uint32
+}
+
+// KeyboardRepeatInfoEvent holds the arguments of a wl_keyboard.repeat_info
+// event.
+type KeyboardRepeatInfoEvent struct {
+	Rate  int32
+	Delay int32
 }
 
 // The wl_keyboard interface represents one or more keyboards
 // associated with a seat.
 type Keyboard struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener KeyboardListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnKeymap, if not nil, is called with the arguments of
+	// each incoming keymap event before Listener is.
+	OnKeymap func(KeyboardKeymapEvent)
 
-	state wire.State
-	id    uint32
+	// OnEnter, if not nil, is called with the arguments of
+	// each incoming enter event before Listener is.
+	OnEnter func(KeyboardEnterEvent)
+
+	// OnLeave, if not nil, is called with the arguments of
+	// each incoming leave event before Listener is.
+	OnLeave func(KeyboardLeaveEvent)
+
+	// OnKey, if not nil, is called with the arguments of
+	// each incoming key event before Listener is.
+	OnKey func(KeyboardKeyEvent)
+
+	// OnModifiers, if not nil, is called with the arguments of
+	// each incoming modifiers event before Listener is.
+	OnModifiers func(KeyboardModifiersEvent)
+
+	// OnRepeatInfo, if not nil, is called with the arguments of
+	// each incoming repeat_info event before Listener is.
+	OnRepeatInfo func(KeyboardRepeatInfoEvent)
 }
 
+var (
+	_ wire.Object      = (*Keyboard)(nil)
+	_ wire.DebugObject = (*Keyboard)(nil)
+)
+
 // NewKeyboard returns a newly instantiated Keyboard. It is
 // primarily intended for use by generated code.
 func NewKeyboard(state wire.State) *Keyboard {
-	return &Keyboard{state: state}
-}
-
-func (obj *Keyboard) State() wire.State {
-	return obj.state
+	return &Keyboard{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
+		if have := msg.RemainingFDs(); have < 1 {
+			return wire.MissingFDsError{Interface: "wl_keyboard", Method: "keymap", Want: 1, Have: have}
+		}
 
 		format := KeyboardKeymapFormat(msg.ReadUint())
 
@@ -5056,6 +5675,18 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if (obj.OnKeymap == nil) && (obj.Listener == nil) {
+			obj.AddCleanup(fd.Close)
+			return nil
+		}
+
+		if obj.OnKeymap != nil {
+			obj.OnKeymap(KeyboardKeymapEvent{
+				Format: format,
+				Fd:     fd,
+				Size:   size,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5070,9 +5701,7 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 
 		serial := msg.ReadUint()
 
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		keys := msg.ReadArray()
 
@@ -5080,6 +5709,13 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnEnter != nil {
+			obj.OnEnter(KeyboardEnterEvent{
+				Serial:  serial,
+				Surface: surface,
+				Keys:    keys,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5094,14 +5730,18 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 
 		serial := msg.ReadUint()
 
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnLeave != nil {
+			obj.OnLeave(KeyboardLeaveEvent{
+				Serial:  serial,
+				Surface: surface,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5125,6 +5765,14 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnKey != nil {
+			obj.OnKey(KeyboardKeyEvent{
+				Serial: serial,
+				Time:   time,
+				Key:    key,
+				State:  state,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5152,6 +5800,15 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnModifiers != nil {
+			obj.OnModifiers(KeyboardModifiersEvent{
+				Serial:        serial,
+				ModsDepressed: modsDepressed,
+				ModsLatched:   modsLatched,
+				ModsLocked:    modsLocked,
+				Group:         group,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5174,6 +5831,12 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRepeatInfo != nil {
+			obj.OnRepeatInfo(KeyboardRepeatInfoEvent{
+				Rate:  rate,
+				Delay: delay,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5191,22 +5854,8 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Keyboard) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Keyboard) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Keyboard) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Keyboard) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_keyboard", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_keyboard", obj.ID())
 }
 
 func (obj *Keyboard) MethodName(op uint16) string {
@@ -5237,7 +5886,19 @@ func (obj *Keyboard) Interface() string {
 	return KeyboardInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// KeyboardVersion, the same as MaxVersion.
 func (obj *Keyboard) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return KeyboardVersion
+}
+
+// MaxVersion returns KeyboardVersion, the highest version of
+// wl_keyboard that is supported.
+func (obj *Keyboard) MaxVersion() uint32 {
 	return KeyboardVersion
 }
 
@@ -5246,7 +5907,7 @@ func (obj *Keyboard) Release() {
 
 	builder.Method = "release"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5262,16 +5923,23 @@ const (
 	KeyboardKeymapFormatXkbV1 KeyboardKeymapFormat = 1
 )
 
-func (enum KeyboardKeymapFormat) String() string {
-	switch enum {
-	case 0:
-		return "KeyboardKeymapFormatNoKeymap"
+// KeyboardKeymapFormatNames maps the values of KeyboardKeymapFormat to their names.
+var KeyboardKeymapFormatNames = map[KeyboardKeymapFormat]string{
+	KeyboardKeymapFormatNoKeymap: "KeyboardKeymapFormatNoKeymap",
+	KeyboardKeymapFormatXkbV1:    "KeyboardKeymapFormatXkbV1",
+}
 
-	case 1:
-		return "KeyboardKeymapFormatXkbV1"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum KeyboardKeymapFormat) String() string {
+	return wire.EnumString(enum, KeyboardKeymapFormatNames)
+}
 
-	return "<invalid KeyboardKeymapFormat>"
+// Since returns the version of wl_keyboard that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum KeyboardKeymapFormat) Since() uint32 {
+	return 1
 }
 
 // Describes the physical state of a key that produced the key event.
@@ -5285,16 +5953,23 @@ const (
 	KeyboardKeyStatePressed KeyboardKeyState = 1
 )
 
-func (enum KeyboardKeyState) String() string {
-	switch enum {
-	case 0:
-		return "KeyboardKeyStateReleased"
+// KeyboardKeyStateNames maps the values of KeyboardKeyState to their names.
+var KeyboardKeyStateNames = map[KeyboardKeyState]string{
+	KeyboardKeyStateReleased: "KeyboardKeyStateReleased",
+	KeyboardKeyStatePressed:  "KeyboardKeyStatePressed",
+}
 
-	case 1:
-		return "KeyboardKeyStatePressed"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum KeyboardKeyState) String() string {
+	return wire.EnumString(enum, KeyboardKeyStateNames)
+}
 
-	return "<invalid KeyboardKeyState>"
+// Since returns the version of wl_keyboard that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum KeyboardKeyState) Since() uint32 {
+	return 1
 }
 
 const (
@@ -5390,6 +6065,59 @@ type TouchListener interface {
 	Orientation(id int32, orientation wire.Fixed)
 }
 
+// TouchDownEvent holds the arguments of a wl_touch.down
+// event.
+type TouchDownEvent struct {
+	Serial  uint32
+	Time    uint32
+	Surface *Surface
+	Id      int32
+	X       wire.Fixed
+	Y       wire.Fixed
+}
+
+// TouchUpEvent holds the arguments of a wl_touch.up
+// event.
+type TouchUpEvent struct {
+	Serial uint32
+	Time   uint32
+	Id     int32
+}
+
+// TouchMotionEvent holds the arguments of a wl_touch.motion
+// event.
+type TouchMotionEvent struct {
+	Time uint32
+	Id   int32
+	X    wire.Fixed
+	Y    wire.Fixed
+}
+
+// TouchFrameEvent holds the arguments of a wl_touch.frame
+// event.
+type TouchFrameEvent struct {
+}
+
+// TouchCancelEvent holds the arguments of a wl_touch.cancel
+// event.
+type TouchCancelEvent struct {
+}
+
+// TouchShapeEvent holds the arguments of a wl_touch.shape
+// event.
+type TouchShapeEvent struct {
+	Id    int32
+	Major wire.Fixed
+	Minor wire.Fixed
+}
+
+// TouchOrientationEvent holds the arguments of a wl_touch.orientation
+// event.
+type TouchOrientationEvent struct {
+	Id          int32
+	Orientation wire.Fixed
+}
+
 // The wl_touch interface represents a touchscreen
 // associated with a seat.
 //
@@ -5399,27 +6127,52 @@ type TouchListener interface {
 // and ending with an up event. Events relating to the same
 // contact point can be identified by the ID of the sequence.
 type Touch struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener TouchListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDown, if not nil, is called with the arguments of
+	// each incoming down event before Listener is.
+	OnDown func(TouchDownEvent)
+
+	// OnUp, if not nil, is called with the arguments of
+	// each incoming up event before Listener is.
+	OnUp func(TouchUpEvent)
+
+	// OnMotion, if not nil, is called with the arguments of
+	// each incoming motion event before Listener is.
+	OnMotion func(TouchMotionEvent)
+
+	// OnFrame, if not nil, is called with the arguments of
+	// each incoming frame event before Listener is.
+	OnFrame func(TouchFrameEvent)
 
-	state wire.State
-	id    uint32
+	// OnCancel, if not nil, is called with the arguments of
+	// each incoming cancel event before Listener is.
+	OnCancel func(TouchCancelEvent)
+
+	// OnShape, if not nil, is called with the arguments of
+	// each incoming shape event before Listener is.
+	OnShape func(TouchShapeEvent)
+
+	// OnOrientation, if not nil, is called with the arguments of
+	// each incoming orientation event before Listener is.
+	OnOrientation func(TouchOrientationEvent)
 }
 
+var (
+	_ wire.Object      = (*Touch)(nil)
+	_ wire.DebugObject = (*Touch)(nil)
+)
+
 // NewTouch returns a newly instantiated Touch. It is
 // primarily intended for use by generated code.
 func NewTouch(state wire.State) *Touch {
-	return &Touch{state: state}
-}
-
-func (obj *Touch) State() wire.State {
-	return obj.state
+	return &Touch{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
@@ -5430,9 +6183,7 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 
 		time := msg.ReadUint()
 
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		id := msg.ReadInt()
 
@@ -5444,6 +6195,16 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDown != nil {
+			obj.OnDown(TouchDownEvent{
+				Serial:  serial,
+				Time:    time,
+				Surface: surface,
+				Id:      id,
+				X:       x,
+				Y:       y,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5469,6 +6230,13 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnUp != nil {
+			obj.OnUp(TouchUpEvent{
+				Serial: serial,
+				Time:   time,
+				Id:     id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5493,6 +6261,14 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnMotion != nil {
+			obj.OnMotion(TouchMotionEvent{
+				Time: time,
+				Id:   id,
+				X:    x,
+				Y:    y,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5509,6 +6285,9 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnFrame != nil {
+			obj.OnFrame(TouchFrameEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5520,6 +6299,9 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnCancel != nil {
+			obj.OnCancel(TouchCancelEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5538,6 +6320,13 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnShape != nil {
+			obj.OnShape(TouchShapeEvent{
+				Id:    id,
+				Major: major,
+				Minor: minor,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5558,6 +6347,12 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnOrientation != nil {
+			obj.OnOrientation(TouchOrientationEvent{
+				Id:          id,
+				Orientation: orientation,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5575,22 +6370,8 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Touch) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Touch) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Touch) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Touch) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_touch", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_touch", obj.ID())
 }
 
 func (obj *Touch) MethodName(op uint16) string {
@@ -5624,7 +6405,19 @@ func (obj *Touch) Interface() string {
 	return TouchInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// TouchVersion, the same as MaxVersion.
 func (obj *Touch) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return TouchVersion
+}
+
+// MaxVersion returns TouchVersion, the highest version of
+// wl_touch that is supported.
+func (obj *Touch) MaxVersion() uint32 {
 	return TouchVersion
 }
 
@@ -5633,7 +6426,7 @@ func (obj *Touch) Release() {
 
 	builder.Method = "release"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5720,6 +6513,39 @@ type OutputListener interface {
 	Scale(factor int32)
 }
 
+// OutputGeometryEvent holds the arguments of a wl_output.geometry
+// event.
+type OutputGeometryEvent struct {
+	X              int32
+	Y              int32
+	PhysicalWidth  int32
+	PhysicalHeight int32
+	Subpixel       OutputSubpixel
+	Make           string
+	Model          string
+	Transform      OutputTransform
+}
+
+// OutputModeEvent holds the arguments of a wl_output.mode
+// event.
+type OutputModeEvent struct {
+	Flags   OutputMode
+	Width   int32
+	Height  int32
+	Refresh int32
+}
+
+// OutputDoneEvent holds the arguments of a wl_output.done
+// event.
+type OutputDoneEvent struct {
+}
+
+// OutputScaleEvent holds the arguments of a wl_output.scale
+// event.
+type OutputScaleEvent struct {
+	Factor int32
+}
+
 // An output describes part of the compositor geometry.  The
 // compositor works in the 'compositor coordinate system' and an
 // output corresponds to a rectangular area in that space that is
@@ -5727,34 +6553,59 @@ type OutputListener interface {
 // displays part of the compositor space.  This object is published
 // as global during start up, or when a monitor is hotplugged.
 type Output struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener OutputListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnGeometry, if not nil, is called with the arguments of
+	// each incoming geometry event before Listener is.
+	OnGeometry func(OutputGeometryEvent)
 
-	state wire.State
-	id    uint32
+	// OnMode, if not nil, is called with the arguments of
+	// each incoming mode event before Listener is.
+	OnMode func(OutputModeEvent)
+
+	// OnDone, if not nil, is called with the arguments of
+	// each incoming done event before Listener is.
+	OnDone func(OutputDoneEvent)
+
+	// OnScale, if not nil, is called with the arguments of
+	// each incoming scale event before Listener is.
+	OnScale func(OutputScaleEvent)
 }
 
+var (
+	_ wire.Object      = (*Output)(nil)
+	_ wire.DebugObject = (*Output)(nil)
+)
+
 // NewOutput returns a newly instantiated Output. It is
 // primarily intended for use by generated code.
 func NewOutput(state wire.State) *Output {
-	return &Output{state: state}
-}
+	return &Output{Proxy: wire.NewProxy(state)}
+}
+
+// BindOutput binds the global identified by name to a new
+// Output. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and OutputVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindOutput(state wire.State, registry wire.Binder, name, version uint32) (*Output, error) {
+	v := wire.NegotiateVersion(OutputVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: OutputInterface, Local: OutputVersion, Remote: version}
+	}
 
-func BindOutput(state wire.State, registry wire.Binder, name, version uint32) *Output {
 	obj := NewOutput(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: OutputInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *Output) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: OutputInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
@@ -5781,6 +6632,18 @@ func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnGeometry != nil {
+			obj.OnGeometry(OutputGeometryEvent{
+				X:              x,
+				Y:              y,
+				PhysicalWidth:  physicalWidth,
+				PhysicalHeight: physicalHeight,
+				Subpixel:       subpixel,
+				Make:           make,
+				Model:          model,
+				Transform:      transform,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5810,6 +6673,14 @@ func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnMode != nil {
+			obj.OnMode(OutputModeEvent{
+				Flags:   flags,
+				Width:   width,
+				Height:  height,
+				Refresh: refresh,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5826,6 +6697,9 @@ func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDone != nil {
+			obj.OnDone(OutputDoneEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5840,6 +6714,11 @@ func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnScale != nil {
+			obj.OnScale(OutputScaleEvent{
+				Factor: factor,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5856,22 +6735,8 @@ func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Output) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Output) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Output) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Output) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_output", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_output", obj.ID())
 }
 
 func (obj *Output) MethodName(op uint16) string {
@@ -5896,7 +6761,19 @@ func (obj *Output) Interface() string {
 	return OutputInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// OutputVersion, the same as MaxVersion.
 func (obj *Output) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return OutputVersion
+}
+
+// MaxVersion returns OutputVersion, the highest version of
+// wl_output that is supported.
+func (obj *Output) MaxVersion() uint32 {
 	return OutputVersion
 }
 
@@ -5907,7 +6784,7 @@ func (obj *Output) Release() {
 
 	builder.Method = "release"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5935,28 +6812,27 @@ const (
 	OutputSubpixelVerticalBgr OutputSubpixel = 5
 )
 
-func (enum OutputSubpixel) String() string {
-	switch enum {
-	case 0:
-		return "OutputSubpixelUnknown"
-
-	case 1:
-		return "OutputSubpixelNone"
-
-	case 2:
-		return "OutputSubpixelHorizontalRgb"
-
-	case 3:
-		return "OutputSubpixelHorizontalBgr"
-
-	case 4:
-		return "OutputSubpixelVerticalRgb"
+// OutputSubpixelNames maps the values of OutputSubpixel to their names.
+var OutputSubpixelNames = map[OutputSubpixel]string{
+	OutputSubpixelUnknown:       "OutputSubpixelUnknown",
+	OutputSubpixelNone:          "OutputSubpixelNone",
+	OutputSubpixelHorizontalRgb: "OutputSubpixelHorizontalRgb",
+	OutputSubpixelHorizontalBgr: "OutputSubpixelHorizontalBgr",
+	OutputSubpixelVerticalRgb:   "OutputSubpixelVerticalRgb",
+	OutputSubpixelVerticalBgr:   "OutputSubpixelVerticalBgr",
+}
 
-	case 5:
-		return "OutputSubpixelVerticalBgr"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum OutputSubpixel) String() string {
+	return wire.EnumString(enum, OutputSubpixelNames)
+}
 
-	return "<invalid OutputSubpixel>"
+// Since returns the version of wl_output that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum OutputSubpixel) Since() uint32 {
+	return 1
 }
 
 // This describes the transform that a compositor will apply to a
@@ -5998,34 +6874,29 @@ const (
 	OutputTransformFlipped270 OutputTransform = 7
 )
 
-func (enum OutputTransform) String() string {
-	switch enum {
-	case 0:
-		return "OutputTransformNormal"
-
-	case 1:
-		return "OutputTransform90"
-
-	case 2:
-		return "OutputTransform180"
-
-	case 3:
-		return "OutputTransform270"
-
-	case 4:
-		return "OutputTransformFlipped"
-
-	case 5:
-		return "OutputTransformFlipped90"
-
-	case 6:
-		return "OutputTransformFlipped180"
+// OutputTransformNames maps the values of OutputTransform to their names.
+var OutputTransformNames = map[OutputTransform]string{
+	OutputTransformNormal:     "OutputTransformNormal",
+	OutputTransform90:         "OutputTransform90",
+	OutputTransform180:        "OutputTransform180",
+	OutputTransform270:        "OutputTransform270",
+	OutputTransformFlipped:    "OutputTransformFlipped",
+	OutputTransformFlipped90:  "OutputTransformFlipped90",
+	OutputTransformFlipped180: "OutputTransformFlipped180",
+	OutputTransformFlipped270: "OutputTransformFlipped270",
+}
 
-	case 7:
-		return "OutputTransformFlipped270"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum OutputTransform) String() string {
+	return wire.EnumString(enum, OutputTransformNames)
+}
 
-	return "<invalid OutputTransform>"
+// Since returns the version of wl_output that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum OutputTransform) Since() uint32 {
+	return 1
 }
 
 // These flags describe properties of an output mode.
@@ -6040,16 +6911,23 @@ const (
 	OutputModePreferred OutputMode = 2
 )
 
-func (enum OutputMode) String() string {
-	switch enum {
-	case 1:
-		return "OutputModeCurrent"
+// OutputModeNames maps the values of OutputMode to their names.
+var OutputModeNames = map[OutputMode]string{
+	OutputModeCurrent:   "OutputModeCurrent",
+	OutputModePreferred: "OutputModePreferred",
+}
 
-	case 2:
-		return "OutputModePreferred"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum OutputMode) String() string {
+	return wire.FlagString(enum, OutputModeNames)
+}
 
-	return "<invalid OutputMode>"
+// Since returns the version of wl_output that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum OutputMode) Since() uint32 {
+	return 1
 }
 
 const (
@@ -6062,23 +6940,18 @@ const (
 // Region objects are used to describe the opaque and input
 // regions of a surface.
 type Region struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*Region)(nil)
+	_ wire.DebugObject = (*Region)(nil)
+)
+
 // NewRegion returns a newly instantiated Region. It is
 // primarily intended for use by generated code.
 func NewRegion(state wire.State) *Region {
-	return &Region{state: state}
-}
-
-func (obj *Region) State() wire.State {
-	return obj.state
+	return &Region{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Region) Dispatch(msg *wire.MessageBuffer) error {
@@ -6090,22 +6963,8 @@ func (obj *Region) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Region) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Region) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Region) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Region) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_region", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_region", obj.ID())
 }
 
 func (obj *Region) MethodName(op uint16) string {
@@ -6119,7 +6978,19 @@ func (obj *Region) Interface() string {
 	return RegionInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// RegionVersion, the same as MaxVersion.
 func (obj *Region) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return RegionVersion
+}
+
+// MaxVersion returns RegionVersion, the highest version of
+// wl_region that is supported.
+func (obj *Region) MaxVersion() uint32 {
 	return RegionVersion
 }
 
@@ -6129,7 +7000,7 @@ func (obj *Region) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6144,7 +7015,7 @@ func (obj *Region) Add(x int32, y int32, width int32, height int32) {
 
 	builder.Method = "add"
 	builder.Args = []any{x, y, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6159,7 +7030,7 @@ func (obj *Region) Subtract(x int32, y int32, width int32, height int32) {
 
 	builder.Method = "subtract"
 	builder.Args = []any{x, y, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6188,30 +7059,37 @@ const (
 // objects. This should allow the compositor to pass YUV video buffer
 // processing to dedicated overlay hardware when possible.
 type Subcompositor struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*Subcompositor)(nil)
+	_ wire.DebugObject = (*Subcompositor)(nil)
+)
+
 // NewSubcompositor returns a newly instantiated Subcompositor. It is
 // primarily intended for use by generated code.
 func NewSubcompositor(state wire.State) *Subcompositor {
-	return &Subcompositor{state: state}
-}
+	return &Subcompositor{Proxy: wire.NewProxy(state)}
+}
+
+// BindSubcompositor binds the global identified by name to a new
+// Subcompositor. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and SubcompositorVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindSubcompositor(state wire.State, registry wire.Binder, name, version uint32) (*Subcompositor, error) {
+	v := wire.NegotiateVersion(SubcompositorVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: SubcompositorInterface, Local: SubcompositorVersion, Remote: version}
+	}
 
-func BindSubcompositor(state wire.State, registry wire.Binder, name, version uint32) *Subcompositor {
 	obj := NewSubcompositor(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: SubcompositorInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *Subcompositor) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: SubcompositorInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *Subcompositor) Dispatch(msg *wire.MessageBuffer) error {
@@ -6223,22 +7101,8 @@ func (obj *Subcompositor) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Subcompositor) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Subcompositor) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Subcompositor) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Subcompositor) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_subcompositor", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_subcompositor", obj.ID())
 }
 
 func (obj *Subcompositor) MethodName(op uint16) string {
@@ -6252,7 +7116,19 @@ func (obj *Subcompositor) Interface() string {
 	return SubcompositorInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SubcompositorVersion, the same as MaxVersion.
 func (obj *Subcompositor) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SubcompositorVersion
+}
+
+// MaxVersion returns SubcompositorVersion, the highest version of
+// wl_subcompositor that is supported.
+func (obj *Subcompositor) MaxVersion() uint32 {
 	return SubcompositorVersion
 }
 
@@ -6264,7 +7140,7 @@ func (obj *Subcompositor) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6286,15 +7162,16 @@ func (obj *Subcompositor) Destroy() {
 func (obj *Subcompositor) GetSubsurface(surface *Surface, parent *Surface) (id *Subsurface) {
 	builder := wire.NewMessage(obj, 1)
 
-	id = NewSubsurface(obj.state)
-	obj.state.Add(id)
+	id = NewSubsurface(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 	builder.WriteObject(surface)
 	builder.WriteObject(parent)
 
 	builder.Method = "get_subsurface"
 	builder.Args = []any{id, surface, parent}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -6305,13 +7182,22 @@ const (
 	SubcompositorErrorBadSurface SubcompositorError = 0
 )
 
+// SubcompositorErrorNames maps the values of SubcompositorError to their names.
+var SubcompositorErrorNames = map[SubcompositorError]string{
+	SubcompositorErrorBadSurface: "SubcompositorErrorBadSurface",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum SubcompositorError) String() string {
-	switch enum {
-	case 0:
-		return "SubcompositorErrorBadSurface"
-	}
+	return wire.EnumString(enum, SubcompositorErrorNames)
+}
 
-	return "<invalid SubcompositorError>"
+// Since returns the version of wl_subcompositor that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SubcompositorError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -6369,23 +7255,18 @@ const (
 // If the parent wl_surface object is destroyed, the sub-surface is
 // unmapped.
 type Subsurface struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*Subsurface)(nil)
+	_ wire.DebugObject = (*Subsurface)(nil)
+)
+
 // NewSubsurface returns a newly instantiated Subsurface. It is
 // primarily intended for use by generated code.
 func NewSubsurface(state wire.State) *Subsurface {
-	return &Subsurface{state: state}
-}
-
-func (obj *Subsurface) State() wire.State {
-	return obj.state
+	return &Subsurface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
@@ -6397,22 +7278,8 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Subsurface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Subsurface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Subsurface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Subsurface) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_subsurface", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_subsurface", obj.ID())
 }
 
 func (obj *Subsurface) MethodName(op uint16) string {
@@ -6426,7 +7293,19 @@ func (obj *Subsurface) Interface() string {
 	return SubsurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SubsurfaceVersion, the same as MaxVersion.
 func (obj *Subsurface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SubsurfaceVersion
+}
+
+// MaxVersion returns SubsurfaceVersion, the highest version of
+// wl_subsurface that is supported.
+func (obj *Subsurface) MaxVersion() uint32 {
 	return SubsurfaceVersion
 }
 
@@ -6440,7 +7319,7 @@ func (obj *Subsurface) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6468,7 +7347,7 @@ func (obj *Subsurface) SetPosition(x int32, y int32) {
 
 	builder.Method = "set_position"
 	builder.Args = []any{x, y}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6494,7 +7373,7 @@ func (obj *Subsurface) PlaceAbove(sibling *Surface) {
 
 	builder.Method = "place_above"
 	builder.Args = []any{sibling}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6507,7 +7386,7 @@ func (obj *Subsurface) PlaceBelow(sibling *Surface) {
 
 	builder.Method = "place_below"
 	builder.Args = []any{sibling}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6529,7 +7408,7 @@ func (obj *Subsurface) SetSync() {
 
 	builder.Method = "set_sync"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6557,7 +7436,7 @@ func (obj *Subsurface) SetDesync() {
 
 	builder.Method = "set_desync"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -6568,11 +7447,20 @@ const (
 	SubsurfaceErrorBadSurface SubsurfaceError = 0
 )
 
+// SubsurfaceErrorNames maps the values of SubsurfaceError to their names.
+var SubsurfaceErrorNames = map[SubsurfaceError]string{
+	SubsurfaceErrorBadSurface: "SubsurfaceErrorBadSurface",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum SubsurfaceError) String() string {
-	switch enum {
-	case 0:
-		return "SubsurfaceErrorBadSurface"
-	}
+	return wire.EnumString(enum, SubsurfaceErrorNames)
+}
 
-	return "<invalid SubsurfaceError>"
+// Since returns the version of wl_subsurface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SubsurfaceError) Since() uint32 {
+	return 1
 }
@@ -0,0 +1,14 @@
+package wl
+
+// Reset frees every object ID that is held by the client that r
+// belongs to, including r's own, and releases the resources that the
+// objects hold, the same as Client.Reset. It is meant for tearing down
+// the client's state when the connection has ended, such as before
+// reconnecting. It does nothing if r does not belong to a Client.
+func (r *Registry) Reset() error {
+	client, ok := r.State().(*Client)
+	if !ok {
+		return nil
+	}
+	return client.Reset()
+}
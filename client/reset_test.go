@@ -0,0 +1,139 @@
+package wl
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"testing"
+
+	"deedles.dev/wl/wire"
+	"golang.org/x/sys/unix"
+)
+
+func newPipe(t *testing.T) (r, w *os.File) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.Close()
+		w.Close()
+	})
+	return r, w
+}
+
+// assertReadEndOpen checks whether the read end of the pipe whose
+// write end is w is still open anywhere, which is the case if writing
+// to it does not fail with EPIPE.
+func assertReadEndOpen(t *testing.T, w *os.File, open bool) {
+	t.Helper()
+
+	_, err := w.Write([]byte{0})
+	if closed := errors.Is(err, unix.EPIPE); closed == open {
+		t.Fatalf("read end open: %v, want %v (write error: %v)", !closed, open, err)
+	}
+}
+
+func TestResetClosesEventFDs(t *testing.T) {
+	c, server, err := wire.SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	client := NewPolledClient(wire.NewConn(c))
+	defer client.Close()
+	registry := client.Display().GetRegistry()
+
+	// The keyboard has no handlers, so it holds on to the file
+	// descriptors that it receives.
+	keyboard := NewKeyboard(client)
+	client.Add(keyboard)
+
+	// The event is sent directly so that the only remaining copy of
+	// the read end of the pipe is the one that the client receives.
+	r, w := newPipe(t)
+	data := make([]byte, wire.HeaderSize+8)
+	wire.EncodeHeader(data, keyboard.ID(), 0, uint16(len(data)))
+	binary.NativeEndian.PutUint32(data[8:], uint32(KeyboardKeymapFormatXkbV1))
+	_, _, err = server.WriteMsgUnix(data, unix.UnixRights(int(r.Fd())), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+
+	ev, err := wire.ReadMessage(client.conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.dispatch(ev); err != nil {
+		t.Fatal(err)
+	}
+	assertReadEndOpen(t, w, true)
+
+	if err := registry.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	assertReadEndOpen(t, w, false)
+	if client.Get(keyboard.ID()) != nil {
+		t.Fatal("keyboard is still known to the client after Reset")
+	}
+}
+
+func TestResetRunsCleanups(t *testing.T) {
+	client, _ := newTestClient(t)
+	display := client.Display()
+	registry := display.GetRegistry()
+
+	var objs []*Surface
+	var pipes []*os.File
+	for range 3 {
+		r, w := newPipe(t)
+		surface := NewSurface(client)
+		surface.AddCleanup(r.Close)
+		client.Add(surface)
+
+		objs = append(objs, surface)
+		pipes = append(pipes, w)
+	}
+
+	if err := registry.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range pipes {
+		assertReadEndOpen(t, w, false)
+	}
+
+	// The display survives, so IDs are handed out from the start
+	// again, but without reusing its.
+	if client.Display() != display {
+		t.Fatal("display was not kept by Reset")
+	}
+	surface := NewSurface(client)
+	client.Add(surface)
+	if surface.ID() != wire.ClientIDMin+1 {
+		t.Fatalf("got ID %v after Reset, want %v", surface.ID(), wire.ClientIDMin+1)
+	}
+	if client.Get(display.ID()) != display {
+		t.Fatal("new object replaced the display")
+	}
+
+	// Cleanups only run once, so running them again doesn't try to
+	// close the pipes a second time.
+	if err := objs[0].Cleanup(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteRunsCleanups(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	r, w := newPipe(t)
+	surface := NewSurface(client)
+	surface.AddCleanup(r.Close)
+	client.Add(surface)
+
+	client.Delete(surface.ID())
+	assertReadEndOpen(t, w, false)
+}
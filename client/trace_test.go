@@ -0,0 +1,61 @@
+package wl
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"deedles.dev/wl/wire"
+)
+
+func TestSetLogger(t *testing.T) {
+	c, s, err := wire.SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, server := wire.NewConn(c), wire.NewConn(s)
+	defer server.Close()
+
+	var trace bytes.Buffer
+	conn.SetLogger(&trace)
+	client := NewPolledClient(conn)
+	defer client.Close()
+
+	callback := client.Display().Sync()
+	if err := client.DispatchPending(); err != nil {
+		t.Fatal(err)
+	}
+
+	done := wire.NewMessage(callback, 0)
+	done.WriteUint(7)
+	if err := done.Build(server); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := wire.ReadMessage(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.dispatch(msg); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := regexp.MustCompile(`(?m)^\[ *\d+\.\d{3}\] (.*)$`).FindAllStringSubmatch(trace.String(), -1)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 trace lines, got:\n%v", trace.String())
+	}
+	if want := " -> wl_display@1.sync(wl_callback@2)"; lines[0][1] != want {
+		t.Errorf("got %q, want %q", lines[0][1], want)
+	}
+	if want := "wl_callback@2.done(7)"; lines[1][1] != want {
+		t.Errorf("got %q, want %q", lines[1][1], want)
+	}
+
+	// Turning the logger off stops the trace.
+	conn.SetLogger(nil)
+	trace.Reset()
+	client.Display().Sync()
+	if trace.Len() != 0 {
+		t.Fatalf("trace written after logger was removed: %q", trace.String())
+	}
+}
@@ -0,0 +1,118 @@
+package wl
+
+import (
+	"errors"
+	"testing"
+
+	"deedles.dev/wl/wire"
+)
+
+func TestBoundVersion(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	display := client.Display()
+	if v := display.Version(); v != DisplayVersion {
+		t.Fatalf("display version is %v, want %v", v, DisplayVersion)
+	}
+
+	registry := display.GetRegistry()
+	if v := registry.Version(); v != 1 {
+		t.Fatalf("registry version is %v, want 1", v)
+	}
+
+	compositor, err := BindCompositor(client, registry, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := compositor.Version(); v != 3 {
+		t.Fatalf("compositor bound at version %v, want 3", v)
+	}
+	if v := compositor.MaxVersion(); v != CompositorVersion {
+		t.Fatalf("compositor max version is %v, want %v", v, CompositorVersion)
+	}
+
+	surface := compositor.CreateSurface()
+	if v := surface.Version(); v != 3 {
+		t.Fatalf("surface created at version %v, want its compositor's version 3", v)
+	}
+
+	compositor, err = BindCompositor(client, registry, 2, CompositorVersion+10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := compositor.Version(); v != CompositorVersion {
+		t.Fatalf("compositor bound at version %v, want %v", v, CompositorVersion)
+	}
+}
+
+func TestGlobalsBindVersion(t *testing.T) {
+	client, _ := newTestClient(t)
+	registry := client.Display().GetRegistry()
+
+	var g Globals
+	g.Add(1, OutputInterface, 2)
+
+	output, err := Bind(client, registry, &g, NewOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := output.Version(); v != 2 {
+		t.Fatalf("output bound at version %v, want 2", v)
+	}
+}
+
+func TestUnboundVersion(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	// Objects that have not been bound report the highest supported
+	// version, as Version did before objects tracked their versions.
+	compositor := NewCompositor(client)
+	if v := compositor.Version(); v != CompositorVersion {
+		t.Fatalf("unbound compositor version is %v, want %v", v, CompositorVersion)
+	}
+
+	compositor.SetVersion(2)
+	if v := compositor.Version(); v != 2 {
+		t.Fatalf("compositor version is %v, want 2", v)
+	}
+}
+
+func TestGlobalsBind(t *testing.T) {
+	tests := []struct {
+		name       string
+		advertised uint32
+		want       uint32
+	}{
+		{"Zero", 0, 0},
+		{"AboveMax", OutputVersion + 3, OutputVersion},
+		{"MidRange", OutputVersion - 1, OutputVersion - 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			registry := client.Display().GetRegistry()
+
+			var g Globals
+			g.Add(1, OutputInterface, test.advertised)
+
+			output, err := Bind(client, registry, &g, NewOutput)
+			if test.want == 0 {
+				var verr wire.VersionError
+				if !errors.As(err, &verr) {
+					t.Fatalf("expected VersionError, got %v", err)
+				}
+				if (verr.Local != OutputVersion) || (verr.Remote != test.advertised) {
+					t.Fatalf("unexpected error: %#v", verr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v := output.Version(); v != test.want {
+				t.Fatalf("output bound at version %v, want %v", v, test.want)
+			}
+		})
+	}
+}
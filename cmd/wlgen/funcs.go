@@ -12,6 +12,10 @@ import (
 )
 
 func (ctx Context) ident(v string) string {
+	if name, ok := ctx.Symbols.Interfaces[v]; ok {
+		return name
+	}
+
 	var pkg string
 	v, ok := strings.CutPrefix(v, ctx.Config.Prefix)
 	if !ok {
@@ -135,6 +139,9 @@ func (ctx Context) goType(arg protocol.Arg) (string, error) {
 		}
 		return "*" + ctx.ident(arg.Interface), nil
 	case "string":
+		if arg.AllowNull {
+			return "*string", nil
+		}
 		return "string", nil
 	case "array":
 		return "[]byte", nil
@@ -164,6 +171,9 @@ func (ctx Context) typeFuncSuffix(arg protocol.Arg) (string, error) {
 		}
 		return "Uint", nil
 	case "string":
+		if arg.AllowNull {
+			return "NullableString", nil
+		}
 		return "String", nil
 	case "array":
 		return "Array", nil
@@ -209,6 +219,94 @@ func (ctx Context) returns(op protocol.Op) (rets []protocol.Arg) {
 	return xslices.Filter(op.Args, ctx.isRet)
 }
 
+func (ctx Context) entryDoc(entry protocol.Entry) string {
+	doc := ctx.trimLines(strings.TrimSpace(entry.Summary))
+	if entry.Since <= 1 {
+		return doc
+	}
+
+	since := fmt.Sprintf("Since version %v.", entry.Since)
+	if doc == "" {
+		return since
+	}
+	return doc + "\n\n" + since
+}
+
+type sinceEntry struct {
+	Value int
+	Since int
+}
+
+// entriesSince returns the values of the entries of enum that were
+// added after the first version of its interface along with the
+// version that added them.
+func (ctx Context) entriesSince(enum protocol.Enum) ([]sinceEntry, error) {
+	var entries []sinceEntry
+	for _, entry := range enum.Entries {
+		if entry.Since <= 1 {
+			continue
+		}
+
+		v, err := entry.Int()
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, sinceEntry{Value: v, Since: entry.Since})
+	}
+	return entries, nil
+}
+
+// checkEnum returns whether the values of arg's enum need to
+// be checked against the version of the objects of the interface inter
+// when they are sent or received. That is only the case for enums that
+// belong to inter itself and that have entries that were added after
+// its first version. The entries of enums of other interfaces refer to
+// the versions of those interfaces instead.
+func (ctx Context) checkEnum(inter string, arg protocol.Arg) (bool, error) {
+	iface, name := arg.EnumRef()
+	if (name == "") || ((iface != "") && (iface != inter)) {
+		return false, nil
+	}
+
+	for _, i := range ctx.Protocol.Interfaces {
+		if i.Name != inter {
+			continue
+		}
+		for _, enum := range i.Enums {
+			if enum.Name != name {
+				continue
+			}
+			since, err := ctx.entriesSince(enum)
+			return len(since) > 0, err
+		}
+	}
+	return false, nil
+}
+
+func (ctx Context) fdCount(op protocol.Op) (n int) {
+	for _, arg := range op.Args {
+		if arg.Type == "fd" {
+			n++
+		}
+	}
+	return n
+}
+
+// checkSize returns whether op has arguments whose encoded size
+// depends on their values, so that the size of a message needs to be
+// checked before it is sent.
+func (ctx Context) checkSize(op protocol.Op) bool {
+	for _, arg := range op.Args {
+		switch {
+		case (arg.Type == "string") || (arg.Type == "array"):
+			return true
+		case (arg.Type == "new_id") && (arg.Interface == ""):
+			return true
+		}
+	}
+	return false
+}
+
 func (ctx Context) isRet(arg protocol.Arg) bool {
 	return (arg.Type == "new_id") && (arg.Interface != "")
 }
@@ -230,6 +328,10 @@ func (ctx Context) trimPackage(v string) string {
 }
 
 func (ctx Context) enumType(inter, v string) string {
+	if name, ok := ctx.Symbols.Enums[ctx.Symbols.enumKey(inter, v)]; ok {
+		return name
+	}
+
 	before, after, ok := strings.Cut(v, ".")
 	if ok {
 		inter = before
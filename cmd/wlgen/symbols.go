@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"deedles.dev/wl/protocol"
+)
+
+// Symbols maps the names used in a protocol to the Go identifiers
+// that are generated for them. It is built once, after the protocol
+// and config have been loaded, so that templates can look up resolved
+// names instead of rebuilding them from strings.
+type Symbols struct {
+	// Interfaces maps interface names, such as wl_surface, to the
+	// names of their Go types.
+	Interfaces map[string]string
+
+	// Enums maps fully-qualified enum names, such as wl_shm.format, to
+	// the names of their Go types.
+	Enums map[string]string
+}
+
+// resolveSymbols builds the symbol table for the interfaces and enums
+// defined by proto. It also checks that every enum referenced by an
+// arg either exists in proto or belongs to an interface that proto
+// doesn't define, in which case it is assumed to come from one of the
+// config's imports.
+func (ctx Context) resolveSymbols(proto protocol.Protocol) (Symbols, error) {
+	syms := Symbols{
+		Interfaces: make(map[string]string, len(proto.Interfaces)),
+		Enums:      make(map[string]string),
+	}
+	for _, i := range proto.Interfaces {
+		syms.Interfaces[i.Name] = ctx.ident(i.Name)
+		for _, enum := range i.Enums {
+			syms.Enums[i.Name+"."+enum.Name] = ctx.ident(i.Name) + ctx.export(ctx.camel(enum.Name))
+		}
+	}
+
+	var errs []error
+	for _, i := range proto.Interfaces {
+		for _, op := range slices.Concat(i.Requests, i.Events) {
+			for _, arg := range op.Args {
+				if arg.Enum == "" {
+					continue
+				}
+
+				iface, _ := arg.EnumRef()
+				if iface == "" {
+					iface = i.Name
+				}
+				if _, ok := syms.Interfaces[iface]; !ok {
+					continue
+				}
+				if _, ok := syms.Enums[syms.enumKey(i.Name, arg.Enum)]; !ok {
+					errs = append(errs, fmt.Errorf("%v.%v: arg %v: unknown enum %q", i.Name, op.Name, arg.Name, arg.Enum))
+				}
+			}
+		}
+	}
+
+	return syms, errors.Join(errs...)
+}
+
+// enumKey returns the fully-qualified name of the enum ref as
+// referenced from the interface inter.
+func (syms Symbols) enumKey(inter, ref string) string {
+	iface, name := protocol.Arg{Enum: ref}.EnumRef()
+	if iface == "" {
+		iface = inter
+	}
+	return iface + "." + name
+}
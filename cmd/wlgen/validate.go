@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// requiredAttrs lists the attributes that each element of a protocol
+// XML file must have for code to be generated from it.
+var requiredAttrs = map[string][]string{
+	"protocol":  {"name"},
+	"interface": {"name", "version"},
+	"request":   {"name"},
+	"event":     {"name"},
+	"arg":       {"name", "type"},
+	"enum":      {"name"},
+	"entry":     {"name", "value"},
+}
+
+// knownElements lists, for each element that the protocol package
+// models, the attributes and child elements that it models. Anything
+// else is silently dropped when decoding, so strict validation
+// rejects it.
+var knownElements = map[string]struct{ attrs, children []string }{
+	"protocol":    {[]string{"name"}, []string{"copyright", "interface"}},
+	"copyright":   {},
+	"interface":   {[]string{"name", "version"}, []string{"description", "request", "event", "enum"}},
+	"description": {[]string{"summary"}, nil},
+	"request":     {[]string{"name", "type", "since", "deprecated-since"}, []string{"description", "arg"}},
+	"event":       {[]string{"name", "type", "since", "deprecated-since"}, []string{"description", "arg"}},
+	"arg":         {[]string{"name", "type", "summary", "allow-null", "interface", "version", "enum"}, []string{"description"}},
+	"enum":        {[]string{"name", "bitfield", "since"}, []string{"description", "entry"}},
+	"entry":       {[]string{"name", "value", "summary", "since", "deprecated-since"}, []string{"description"}},
+}
+
+var argTypes = []string{"int", "uint", "fixed", "string", "object", "new_id", "array", "fd"}
+
+// validateXML checks that the protocol XML read from r has all of the
+// attributes that the generator depends on, reporting each problem
+// along with the position of the end of the offending element's start
+// tag. Without this, a missing attribute silently results in broken
+// output. If strict is true, elements and attributes that the
+// protocol package doesn't model are also reported, as they would
+// otherwise be silently ignored.
+func validateXML(r io.Reader, name string, strict bool) error {
+	d := xml.NewDecoder(r)
+
+	var errs []error
+	var parents []string
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("%v: %w", name, err)
+		}
+		line, col := d.InputPos()
+
+		var elem xml.StartElement
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			elem = tok
+		case xml.EndElement:
+			parents = parents[:len(parents)-1]
+			continue
+		default:
+			continue
+		}
+		allowed := []string{"protocol"}
+		if len(parents) > 0 {
+			allowed = knownElements[parents[len(parents)-1]].children
+		}
+		parents = append(parents, elem.Name.Local)
+		attrs := make(map[string]string, len(elem.Attr))
+		for _, attr := range elem.Attr {
+			attrs[attr.Name.Local] = attr.Value
+		}
+
+		report := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%v:%v:%v: <%v%v>: %v", name, line, col, elem.Name.Local, describe(attrs), fmt.Sprintf(format, args...)))
+		}
+		for _, attr := range requiredAttrs[elem.Name.Local] {
+			if attrs[attr] == "" {
+				report("missing %q attribute", attr)
+			}
+		}
+		if t, ok := attrs["type"]; ok && (elem.Name.Local == "arg") && (t != "") && !slices.Contains(argTypes, t) {
+			report("unknown arg type %q", t)
+		}
+
+		if strict {
+			if !slices.Contains(allowed, elem.Name.Local) {
+				report("unsupported element")
+				d.Skip()
+				parents = parents[:len(parents)-1]
+				continue
+			}
+			for _, attr := range elem.Attr {
+				if !slices.Contains(knownElements[elem.Name.Local].attrs, attr.Name.Local) {
+					report("unsupported attribute %q", attr.Name.Local)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// describe returns a short description of an element based on its
+// name attribute to help locate it.
+func describe(attrs map[string]string) string {
+	if name, ok := attrs["name"]; ok {
+		return fmt.Sprintf(" name=%q", name)
+	}
+	return ""
+}
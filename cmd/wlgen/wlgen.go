@@ -10,8 +10,12 @@ import (
 	"fmt"
 	"go/build"
 	"go/format"
+	"io"
+	"io/fs"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"text/template"
 
@@ -25,6 +29,12 @@ const baseTmpl = "wlgen.tmpl"
 var (
 	//go:embed *.tmpl
 	tmplFS embed.FS
+
+	// builtinFS holds protocols, along with their configs, that code
+	// can be generated for without having the XML file on disk.
+	//
+	//go:embed builtin
+	builtinFS embed.FS
 )
 
 func parseTemplates(ctx Context) *template.Template {
@@ -46,6 +56,11 @@ func parseTemplates(ctx Context) *template.Template {
 		"args":           ctx.args,
 		"returns":        ctx.returns,
 		"isRet":          ctx.isRet,
+		"fdCount":        ctx.fdCount,
+		"entryDoc":       ctx.entryDoc,
+		"entriesSince":   ctx.entriesSince,
+		"checkEnum":      ctx.checkEnum,
+		"checkSize":      ctx.checkSize,
 		"package":        ctx.pkg,
 		"trimPackage":    ctx.trimPackage,
 		"enumType":       ctx.enumType,
@@ -54,13 +69,61 @@ func parseTemplates(ctx Context) *template.Template {
 	return template.Must(template.New(baseTmpl).Funcs(tmplFuncs).ParseFS(tmplFS, "*.tmpl"))
 }
 
-func loadXML(path string) (proto protocol.Protocol, err error) {
-	file, err := os.Open(path)
+// overrideTemplates parses the .tmpl files in dir into t. Any
+// template defined in them replaces the embedded one of the same name,
+// either a whole file, such as wlgen.tmpl, or a template defined
+// inside of one with {{define}}.
+func overrideTemplates(t *template.Template, dir string) (*template.Template, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return t, nil
+	}
+
+	return t.ParseFiles(files...)
+}
+
+// builtins returns the names of the builtin protocols.
+func builtins() ([]string, error) {
+	files, err := fs.Glob(builtinFS, "builtin/*.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(path.Base(file), ".xml"))
+	}
+	return names, nil
+}
+
+// openInput opens the named file, either on disk or, if builtin is
+// true, in builtinFS.
+func openInput(name string, builtin bool) (fs.File, error) {
+	if builtin {
+		return builtinFS.Open(path.Join("builtin", name))
+	}
+	return os.Open(name)
+}
+
+func loadXML(name string, builtin, strict bool) (proto protocol.Protocol, err error) {
+	file, err := openInput(name, builtin)
 	if err != nil {
 		return proto, err
 	}
 	defer file.Close()
 
+	err = validateXML(file, name, strict)
+	if err != nil {
+		return proto, err
+	}
+	_, err = file.(io.Seeker).Seek(0, io.SeekStart)
+	if err != nil {
+		return proto, err
+	}
+
 	d := xml.NewDecoder(file)
 	err = d.Decode(&proto)
 	return proto, err
@@ -77,8 +140,8 @@ type Config struct {
 	Imports map[string]Import
 }
 
-func loadConfig(path string, isClient bool) (Config, error) {
-	file, err := os.Open(path)
+func loadConfig(name string, builtin, isClient bool) (Config, error) {
+	file, err := openInput(name, builtin)
 	if err != nil {
 		return Config{}, err
 	}
@@ -104,7 +167,7 @@ func loadConfig(path string, isClient bool) (Config, error) {
 				conf.Prefix = parts[2]
 			}
 		case "import":
-			path = parts[1]
+			path := parts[1]
 			if isClient {
 				path = parts[2]
 			}
@@ -129,45 +192,190 @@ func loadConfig(path string, isClient bool) (Config, error) {
 	return conf, errors.Join(errs...)
 }
 
+// listFlag is a flag that can be given more than once, with each
+// occurrence holding a comma-separated list of values.
+type listFlag []string
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *listFlag) Set(v string) error {
+	*f = append(*f, strings.Split(v, ",")...)
+	return nil
+}
+
+// input is a protocol XML file to generate code from.
+type input struct {
+	Path    string
+	Builtin bool
+}
+
+// expandInputs returns the inputs for the given XML file patterns and
+// builtin protocol names, with the builtins first.
+func expandInputs(patterns, builtins []string) ([]input, error) {
+	var inputs []input
+	for _, name := range builtins {
+		inputs = append(inputs, input{Path: name + ".xml", Builtin: true})
+	}
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("%v: no such file", pattern)
+		}
+		for _, file := range files {
+			inputs = append(inputs, input{Path: file})
+		}
+	}
+	return inputs, nil
+}
+
+// mergeProtocols combines protos into a single protocol containing
+// all of their interfaces, named after the first of them.
+func mergeProtocols(protos []protocol.Protocol) protocol.Protocol {
+	merged := protos[0]
+	merged.Interfaces = nil
+	for _, proto := range protos {
+		merged.Interfaces = append(merged.Interfaces, proto.Interfaces...)
+	}
+	return merged
+}
+
 type Context struct {
 	T            *template.Template
 	Protocol     protocol.Protocol
+	Protocols    []protocol.Protocol
 	Config       Config
 	IsClient     bool
 	Locals       set.Set[string]
+	Symbols      Symbols
 	ExtraImports []string
 }
 
 func main() {
-	xmlfile := flag.String("xml", "", "protocol XML file")
-	out := flag.String("out", "", "output file (default <xml file>.go)")
-	config := flag.String("config", "", "config file (default <xnl file>.conf)")
-	client := flag.Bool("client", false, "generate code for client usage instead of server")
+	var xmlfiles, builtin listFlag
+	flag.Var(&xmlfiles, "xml", "protocol XML file, comma-separated list of files, or glob; may be repeated")
+	flag.Var(&builtin, "builtin", "name of a builtin protocol to generate code for, in addition to any -xml files; may be repeated")
+	listBuiltins := flag.Bool("builtins", false, "list the builtin protocols and exit")
+	out := flag.String("out", "", "output file (default <protocol name>.go next to the XML file, or in the current directory for builtins); with -role both, the file is written to client and server directories next to it instead")
+	config := flag.String("config", "", "config file (default <first xml file>.conf)")
+	role := flag.String("role", "server", "end of the protocol to generate code for: client, server, or both")
+	client := flag.Bool("client", false, "shorthand for -role client")
+	templates := flag.String("templates", "", "directory of .tmpl files that override the built-in templates by name")
+	strict := flag.Bool("strict", false, "reject XML elements and attributes that are not supported")
 	flag.Parse()
 
-	if *out == "" {
-		*out = *xmlfile + ".go"
+	if *client {
+		*role = "client"
 	}
-	if *config == "" {
-		*config = *xmlfile + ".conf"
+	var roles []string
+	switch *role {
+	case "client", "server":
+		roles = []string{*role}
+	case "both":
+		roles = []string{"client", "server"}
+	default:
+		log.Fatalf("unknown role: %q", *role)
+	}
+
+	if *listBuiltins {
+		names, err := builtins()
+		if err != nil {
+			log.Fatalf("list builtins: %v", err)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return
 	}
 
-	proto, err := loadXML(*xmlfile)
+	inputs, err := expandInputs(xmlfiles, builtin)
 	if err != nil {
-		log.Fatalf("load XML: %v", err)
+		log.Fatalf("find XML files: %v", err)
+	}
+	if len(inputs) == 0 {
+		log.Fatalf("no protocol XML files given")
+	}
+	first := inputs[0]
+
+	builtinConfig := first.Builtin && (*config == "")
+	if *config == "" {
+		*config = first.Path + ".conf"
 	}
 
-	conf, err := loadConfig(*config, *client)
+	protos := make([]protocol.Protocol, 0, len(inputs))
+	for _, in := range inputs {
+		proto, err := loadXML(in.Path, in.Builtin, *strict)
+		if err != nil {
+			log.Fatalf("load XML: %v", err)
+		}
+		protos = append(protos, proto)
+	}
+
+	if *out == "" {
+		*out = protos[0].Name + ".go"
+		if !first.Builtin {
+			*out = filepath.Join(filepath.Dir(first.Path), *out)
+		}
+	}
+
+	err = generateRoles(protos, roles, *config, builtinConfig, *templates, *out)
 	if err != nil {
-		log.Fatalf("load config: %v", err)
+		log.Fatal(err)
+	}
+}
+
+// generateRoles generates the code for each of the given roles. If
+// there is more than one, each role's file is written to a directory
+// named after the role next to out, which is created if it doesn't
+// already exist, instead of to out itself.
+func generateRoles(protos []protocol.Protocol, roles []string, config string, builtinConfig bool, templates, out string) error {
+	for _, role := range roles {
+		isClient := role == "client"
+
+		conf, err := loadConfig(config, builtinConfig, isClient)
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		path := out
+		if len(roles) > 1 {
+			path = filepath.Join(filepath.Dir(path), role, filepath.Base(path))
+			err = os.MkdirAll(filepath.Dir(path), 0777)
+			if err != nil {
+				return fmt.Errorf("create %v directory: %w", role, err)
+			}
+		}
+
+		err = generate(protos, conf, isClient, templates, path)
+		if err != nil {
+			return fmt.Errorf("generate %v code: %w", role, err)
+		}
 	}
 
+	return nil
+}
+
+// generate generates the code for one end of the given protocols and
+// writes it to the file at out.
+func generate(protos []protocol.Protocol, conf Config, isClient bool, templates, out string) error {
+	proto := mergeProtocols(protos)
+
 	ctx := Context{
-		Protocol: proto,
-		Config:   conf,
-		IsClient: *client,
-		Locals:   set.New("wl_display"),
+		Protocol:  proto,
+		Protocols: protos,
+		Config:    conf,
+		IsClient:  isClient,
+		Locals:    set.New("wl_display"),
 	}
+	syms, err := ctx.resolveSymbols(proto)
+	if err != nil {
+		return fmt.Errorf("resolve symbols: %w", err)
+	}
+	ctx.Symbols = syms
 
 	extraImports := make(set.Set[string])
 	for _, i := range proto.Interfaces {
@@ -200,11 +408,17 @@ func main() {
 	ctx.ExtraImports = maps.Keys(extraImports)
 
 	ctx.T = parseTemplates(ctx)
+	if templates != "" {
+		ctx.T, err = overrideTemplates(ctx.T, templates)
+		if err != nil {
+			return fmt.Errorf("load template overrides: %w", err)
+		}
+	}
 
 	var buf bytes.Buffer
 	err = ctx.T.ExecuteTemplate(&buf, baseTmpl, ctx)
 	if err != nil {
-		log.Fatalf("execute template: %v", err)
+		return fmt.Errorf("execute template: %w", err)
 	}
 
 	unfmt := buf.Bytes()
@@ -214,14 +428,5 @@ func main() {
 		data = unfmt
 	}
 
-	file, err := os.Create(*out)
-	if err != nil {
-		log.Fatalf("create output file: %v", err)
-	}
-	defer file.Close()
-
-	_, err = file.Write(data)
-	if err != nil {
-		log.Fatalf("write output: %v", err)
-	}
+	return os.WriteFile(out, data, 0666)
 }
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"deedles.dev/wl/protocol"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// TestGolden generates the code for both roles of each protocol in
+// testdata and compares it to the golden files next to it. Each
+// protocol, name.xml, is loaded in strict mode with the config in
+// name.xml.conf and, if there is a name.templates directory, with
+// the template overrides in it. The generated code is compared to
+// name.client.golden and name.server.golden.
+func TestGolden(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		base := strings.TrimSuffix(file, ".xml")
+		t.Run(filepath.Base(base), func(t *testing.T) {
+			proto, err := loadXML(file, false, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var templates string
+			if info, err := os.Stat(base + ".templates"); (err == nil) && info.IsDir() {
+				templates = base + ".templates"
+			}
+
+			for _, role := range []string{"client", "server"} {
+				isClient := role == "client"
+
+				conf, err := loadConfig(file+".conf", false, isClient)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				out := filepath.Join(t.TempDir(), "protocol.go")
+				err = generate([]protocol.Protocol{proto}, conf, isClient, templates, out)
+				if err != nil {
+					t.Fatalf("generate %v code: %v", role, err)
+				}
+				got, err := os.ReadFile(out)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				golden := base + "." + role + ".golden"
+				if *update {
+					if err := os.WriteFile(golden, got, 0666); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%v code differs from %v at line %v; run go test -update if the change is intended", role, golden, diffLine(got, want))
+				}
+			}
+		})
+	}
+}
+
+// TestValidateGolden validates each protocol in testdata/invalid both
+// with and without strict mode and compares the errors to the golden
+// file name.golden next to it.
+func TestValidateGolden(t *testing.T) {
+	files, err := filepath.Glob("testdata/invalid/*.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		base := strings.TrimSuffix(file, ".xml")
+		t.Run(filepath.Base(base), func(t *testing.T) {
+			var got bytes.Buffer
+			for _, strict := range []bool{false, true} {
+				f, err := os.Open(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = validateXML(f, file, strict)
+				f.Close()
+
+				fmt.Fprintf(&got, "strict=%v:\n%v\n", strict, err)
+			}
+
+			golden := base + ".golden"
+			if *update {
+				if err := os.WriteFile(golden, got.Bytes(), 0666); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("errors differ from %v at line %v; run go test -update if the change is intended", golden, diffLine(got.Bytes(), want))
+			}
+		})
+	}
+}
+
+// TestBuiltinGolden generates the builtin core protocol the same way
+// that the client and server packages do and compares the output to
+// their checked-in code, which serves as its golden files. Those are
+// updated by go generate rather than by -update.
+func TestBuiltinGolden(t *testing.T) {
+	names, err := builtins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(names, "wayland") {
+		t.Fatalf("builtins are %v, want wayland among them", names)
+	}
+
+	proto, err := loadXML("wayland.xml", true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, role := range []string{"client", "server"} {
+		conf, err := loadConfig("wayland.xml.conf", true, role == "client")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := filepath.Join(t.TempDir(), "protocol.go")
+		err = generate([]protocol.Protocol{proto}, conf, role == "client", "", out)
+		if err != nil {
+			t.Fatalf("generate %v code: %v", role, err)
+		}
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		golden := filepath.Join("..", "..", role, "protocol.go")
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%v code differs from %v at line %v; run go generate ./...", role, golden, diffLine(got, want))
+		}
+	}
+}
+
+// diffLine returns the number of the first line that differs between
+// got and want.
+func diffLine(got, want []byte) int {
+	gotLines := bytes.Split(got, []byte("\n"))
+	wantLines := bytes.Split(want, []byte("\n"))
+	for i := range min(len(gotLines), len(wantLines)) {
+		if !bytes.Equal(gotLines[i], wantLines[i]) {
+			return i + 1
+		}
+	}
+	return min(len(gotLines), len(wantLines)) + 1
+}
+
+func TestGenerateBothRoles(t *testing.T) {
+	proto, err := loadXML("wayland.xml", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither the client nor the server directory exists yet.
+	dir := t.TempDir()
+	out := filepath.Join(dir, "protocol.go")
+	err = generateRoles([]protocol.Protocol{proto}, []string{"client", "server"}, "wayland.xml.conf", true, "", out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, role := range []string{"client", "server"} {
+		if _, err := os.Stat(filepath.Join(dir, role, "protocol.go")); err != nil {
+			t.Errorf("%v code: %v", role, err)
+		}
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("code for both roles was written to %v", out)
+	}
+}
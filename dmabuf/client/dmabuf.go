@@ -0,0 +1,261 @@
+// Package dmabuf contains client-side bindings for the linux-dmabuf
+// protocol, along with helpers for decoding the feedback sent by the
+// compositor and for creating wl_buffers from dmabufs.
+package dmabuf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	wl "deedles.dev/wl/client"
+	"deedles.dev/wl/shm"
+	"golang.org/x/sys/unix"
+)
+
+// These are the DRM format modifiers with special meanings.
+const (
+	// ModifierLinear indicates that the buffer's layout is linear,
+	// without any tiling or compression.
+	ModifierLinear uint64 = 0
+
+	// ModifierInvalid indicates that no explicit modifier is
+	// specified, so the layout is determined by the driver.
+	ModifierInvalid uint64 = 0x00FFFFFFFFFFFFFF
+)
+
+// formatTableEntrySize is the size, in bytes, of each entry in a
+// format table.
+const formatTableEntrySize = 16
+
+// ErrBufferFailed is returned when the compositor sends
+// zwp_linux_buffer_params_v1.failed because it could not import the
+// dmabufs for a buffer.
+var ErrBufferFailed = errors.New("dmabuf buffer creation failed")
+
+// FormatModifier is a DRM format code paired with a layout modifier.
+type FormatModifier struct {
+	Format   uint32
+	Modifier uint64
+}
+
+// ReadFormatTable decodes the format table sent by the compositor in a
+// zwp_linux_dmabuf_feedback_v1.format_table event. The file is mapped
+// read-only in private mode, as the protocol requires, and is unmapped
+// again before returning. It is not closed.
+func ReadFormatTable(file *os.File, size uint32) ([]FormatModifier, error) {
+	if size%formatTableEntrySize != 0 {
+		return nil, fmt.Errorf("format table size %v is not a multiple of %v", size, formatTableEntrySize)
+	}
+	if size == 0 {
+		return nil, nil
+	}
+
+	mmap, err := shm.MapPrivate(file, int(size), unix.PROT_READ)
+	if err != nil {
+		return nil, fmt.Errorf("mmap format table: %w", err)
+	}
+	defer mmap.Unmap()
+
+	table := make([]FormatModifier, 0, size/formatTableEntrySize)
+	for data := []byte(mmap); len(data) > 0; data = data[formatTableEntrySize:] {
+		table = append(table, FormatModifier{
+			Format:   binary.NativeEndian.Uint32(data),
+			Modifier: binary.NativeEndian.Uint64(data[8:]),
+		})
+	}
+	return table, nil
+}
+
+// Tranche is a set of format and modifier pairs that the compositor
+// prefers equally for buffers used with a particular device.
+type Tranche struct {
+	// TargetDevice is the dev_t of the device that buffers created
+	// with one of the formats in this tranche will be used with.
+	TargetDevice uint64
+
+	Flags   LinuxDmabufFeedbackV1TrancheFlags
+	Formats []FormatModifier
+}
+
+// Feedback holds all of the parameters sent by the compositor to a
+// zwp_linux_dmabuf_feedback_v1 up to a done event.
+type Feedback struct {
+	// MainDevice is the dev_t of the device that the compositor
+	// prefers buffers to be allocated on.
+	MainDevice uint64
+
+	// Tranches holds the preference tranches, from the most preferred
+	// to the least preferred.
+	Tranches []Tranche
+}
+
+// Collect sets fb's Listener to an implementation that gathers the
+// parameters sent by the compositor and calls f with them whenever a
+// done event arrives, which happens once after fb has been created and
+// then again whenever the parameters change. If the parameters could
+// not be decoded, such as because the format table could not be
+// mapped, f is called with an error instead.
+func (fb *LinuxDmabufFeedbackV1) Collect(f func(Feedback, error)) {
+	fb.Listener = &feedbackListener{f: f}
+}
+
+type feedbackListener struct {
+	f       func(Feedback, error)
+	table   []FormatModifier
+	current Feedback
+	tranche Tranche
+	errs    []error
+}
+
+func (lis *feedbackListener) Done() {
+	fb, err := lis.current, errors.Join(lis.errs...)
+	lis.current, lis.errs = Feedback{}, nil
+	lis.f(fb, err)
+}
+
+func (lis *feedbackListener) FormatTable(fd *os.File, size uint32) {
+	defer fd.Close()
+
+	table, err := ReadFormatTable(fd, size)
+	if err != nil {
+		lis.errs = append(lis.errs, err)
+		return
+	}
+	lis.table = table
+}
+
+func (lis *feedbackListener) MainDevice(device []byte) {
+	dev, err := decodeDevice(device)
+	if err != nil {
+		lis.errs = append(lis.errs, fmt.Errorf("main device: %w", err))
+		return
+	}
+	lis.current.MainDevice = dev
+}
+
+func (lis *feedbackListener) TrancheDone() {
+	lis.current.Tranches = append(lis.current.Tranches, lis.tranche)
+	lis.tranche = Tranche{}
+}
+
+func (lis *feedbackListener) TrancheTargetDevice(device []byte) {
+	dev, err := decodeDevice(device)
+	if err != nil {
+		lis.errs = append(lis.errs, fmt.Errorf("tranche target device: %w", err))
+		return
+	}
+	lis.tranche.TargetDevice = dev
+}
+
+func (lis *feedbackListener) TrancheFormats(indices []byte) {
+	if len(indices)%2 != 0 {
+		lis.errs = append(lis.errs, fmt.Errorf("tranche formats: odd array length %v", len(indices)))
+		return
+	}
+
+	for ; len(indices) > 0; indices = indices[2:] {
+		i := binary.NativeEndian.Uint16(indices)
+		if int(i) >= len(lis.table) {
+			lis.errs = append(lis.errs, fmt.Errorf("tranche formats: index %v out of range of %v entry table", i, len(lis.table)))
+			continue
+		}
+		lis.tranche.Formats = append(lis.tranche.Formats, lis.table[i])
+	}
+}
+
+func (lis *feedbackListener) TrancheFlags(flags LinuxDmabufFeedbackV1TrancheFlags) {
+	lis.tranche.Flags = flags
+}
+
+// decodeDevice decodes a dev_t sent as an array argument.
+func decodeDevice(device []byte) (uint64, error) {
+	if len(device) != 8 {
+		return 0, fmt.Errorf("unexpected dev_t size %v", len(device))
+	}
+	return binary.NativeEndian.Uint64(device), nil
+}
+
+// Plane describes one plane of a buffer.
+type Plane struct {
+	// File is the dmabuf that holds the plane's data. Several planes
+	// can be in the same dmabuf.
+	File *os.File
+
+	// Offset is the position of the plane's data in File, in bytes.
+	Offset uint32
+
+	// Stride is the number of bytes between the start of each row of
+	// the plane.
+	Stride uint32
+}
+
+// BufferParams describes a buffer made up of one or more dmabufs.
+type BufferParams struct {
+	Width, Height int32
+
+	// Format is the buffer's DRM format code.
+	Format uint32
+
+	// Modifier is the layout modifier of every plane.
+	Modifier uint64
+
+	Flags LinuxBufferParamsV1Flags
+
+	// Planes holds the buffer's planes in order.
+	Planes []Plane
+}
+
+// params creates a zwp_linux_buffer_params_v1 with all of p's planes
+// added to it.
+func (p BufferParams) params(dmabuf *LinuxDmabufV1) *LinuxBufferParamsV1 {
+	params := dmabuf.CreateParams()
+	for i, plane := range p.Planes {
+		params.Add(
+			plane.File,
+			uint32(i),
+			plane.Offset,
+			plane.Stride,
+			uint32(p.Modifier>>32),
+			uint32(p.Modifier),
+		)
+	}
+	return params
+}
+
+// CreateBuffer asks the compositor to create a wl_buffer from the
+// dmabufs described by p using zwp_linux_buffer_params_v1.create.
+// Once the compositor has replied, f is called with either the new
+// buffer or ErrBufferFailed. The file descriptors of the planes are
+// duplicated when the request is made, so the caller remains
+// responsible for closing them.
+func CreateBuffer(dmabuf *LinuxDmabufV1, p BufferParams, f func(*wl.Buffer, error)) {
+	params := p.params(dmabuf)
+	params.OnCreated = func(ev LinuxBufferParamsV1CreatedEvent) {
+		params.Destroy()
+		f(ev.Buffer, nil)
+	}
+	params.OnFailed = func(LinuxBufferParamsV1FailedEvent) {
+		params.Destroy()
+		f(nil, ErrBufferFailed)
+	}
+	params.Create(p.Width, p.Height, p.Format, p.Flags)
+}
+
+// CreateBufferImmed creates a wl_buffer from the dmabufs described by
+// p using zwp_linux_buffer_params_v1.create_immed, which requires
+// version 2 of zwp_linux_dmabuf_v1. The buffer can be used
+// immediately. If the compositor fails to import the dmabufs, it
+// either disconnects the client with a protocol error or makes the
+// buffer unusable without telling the client, so CreateBuffer should
+// be used instead if the client needs to be able to fall back to
+// another way of providing its contents. As with CreateBuffer, the
+// caller remains responsible for closing the file descriptors of the
+// planes.
+func CreateBufferImmed(dmabuf *LinuxDmabufV1, p BufferParams) *wl.Buffer {
+	params := p.params(dmabuf)
+	buf := params.CreateImmed(p.Width, p.Height, p.Format, p.Flags)
+	params.Destroy()
+	return buf
+}
@@ -0,0 +1,7 @@
+// Package dmabuf provides bindings for the linux-dmabuf protocol,
+// which allows clients to share GPU buffers with the compositor.
+// The client and server subpackages contain the bindings for each end
+// of the protocol.
+package dmabuf
+
+//go:generate go run deedles.dev/wl/cmd/wlgen -role both -xml linux-dmabuf-v1.xml -out protocol.go
@@ -0,0 +1,1266 @@
+// Code generated by wlgen from the linux_dmabuf_v1 protocol. DO NOT EDIT.
+
+// Copyright © 2014, 2015 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package dmabuf
+
+import (
+	wl "deedles.dev/wl/server"
+	"deedles.dev/wl/wire"
+	"fmt"
+	"os"
+)
+
+// ProtocolName is the name of the protocol that this file was
+// generated from.
+const ProtocolName = "linux_dmabuf_v1"
+
+// Interfaces lists the interfaces defined by the linux_dmabuf_v1
+// protocol along with the highest version of each that is supported.
+var Interfaces = []wire.InterfaceInfo{
+	{Name: LinuxDmabufV1Interface, Version: LinuxDmabufV1Version},
+	{Name: LinuxBufferParamsV1Interface, Version: LinuxBufferParamsV1Version},
+	{Name: LinuxDmabufFeedbackV1Interface, Version: LinuxDmabufFeedbackV1Version},
+}
+
+const (
+	LinuxDmabufV1Interface = "zwp_linux_dmabuf_v1"
+	LinuxDmabufV1Version   = 4
+)
+
+// LinuxDmabufV1Listener is a type that can respond to incoming
+// messages for a LinuxDmabufV1 object.
+type LinuxDmabufV1Listener interface {
+	// Objects created through this interface, especially wl_buffers, will
+	// remain valid.
+	Destroy()
+
+	// This temporary object is used to collect multiple dmabuf handles into
+	// a single batch to create a wl_buffer. It can only be used once and
+	// should be destroyed after a 'created' or 'failed' event has been
+	// received.
+	CreateParams(paramsId *LinuxBufferParamsV1)
+
+	// This request creates a new wp_linux_dmabuf_feedback object not bound
+	// to a particular surface. This object will deliver feedback about dmabuf
+	// parameters to use if the client doesn't support per-surface feedback
+	// (see get_surface_feedback).
+	GetDefaultFeedback(id *LinuxDmabufFeedbackV1)
+
+	// This request creates a new wp_linux_dmabuf_feedback object for the
+	// specified wl_surface. This object will deliver feedback about dmabuf
+	// parameters to use for buffers attached to this surface.
+	//
+	// If the surface is destroyed before the wp_linux_dmabuf_feedback object,
+	// the feedback object becomes inert.
+	GetSurfaceFeedback(id *LinuxDmabufFeedbackV1, surface *wl.Surface)
+}
+
+// LinuxDmabufV1DestroyRequest holds the arguments of a zwp_linux_dmabuf_v1.destroy
+// request.
+type LinuxDmabufV1DestroyRequest struct {
+}
+
+// LinuxDmabufV1CreateParamsRequest holds the arguments of a zwp_linux_dmabuf_v1.create_params
+// request.
+type LinuxDmabufV1CreateParamsRequest struct {
+	ParamsId *LinuxBufferParamsV1
+}
+
+// LinuxDmabufV1GetDefaultFeedbackRequest holds the arguments of a zwp_linux_dmabuf_v1.get_default_feedback
+// request.
+type LinuxDmabufV1GetDefaultFeedbackRequest struct {
+	Id *LinuxDmabufFeedbackV1
+}
+
+// LinuxDmabufV1GetSurfaceFeedbackRequest holds the arguments of a zwp_linux_dmabuf_v1.get_surface_feedback
+// request.
+type LinuxDmabufV1GetSurfaceFeedbackRequest struct {
+	Id      *LinuxDmabufFeedbackV1
+	Surface *wl.Surface
+}
+
+// Following the interfaces from:
+// https://www.khronos.org/registry/egl/extensions/EXT/EGL_EXT_image_dma_buf_import.txt
+// https://www.khronos.org/registry/EGL/extensions/EXT/EGL_EXT_image_dma_buf_import_modifiers.txt
+// and the Linux DRM sub-system's AddFb2 ioctl.
+//
+// This interface offers ways to create generic dmabuf-based wl_buffers.
+//
+// Clients can use the get_surface_feedback request to get dmabuf feedback
+// for a particular surface. If the client wants to retrieve feedback not
+// tied to a surface, they can use the get_default_feedback request.
+//
+// The following are required from clients:
+//
+// - Clients must ensure that either all data in the dma-buf is
+// coherent for all subsequent read access or that coherency is
+// correctly handled by the underlying kernel-side dma-buf
+// implementation.
+//
+// - Don't make any more attachments after sending the buffer to the
+// compositor. Making more attachments later increases the risk of
+// the compositor not being able to use (re-import) an existing
+// dmabuf-based wl_buffer.
+//
+// The underlying graphics stack must ensure the following:
+//
+// - The dmabuf file descriptors relayed to the server will stay valid
+// for the whole lifetime of the wl_buffer. This means the server may
+// at any time use those fds to import the dmabuf into any kernel
+// sub-system that might accept it.
+//
+// However, when the underlying graphics stack fails to deliver the
+// promise, because of e.g. a device hot-unplug which raises internal
+// errors, after the wl_buffer has been successfully created the
+// compositor must not raise protocol errors to the client when dmabuf
+// import later fails.
+//
+// To create a wl_buffer from one or more dmabufs, a client creates a
+// zwp_linux_dmabuf_params_v1 object with a zwp_linux_dmabuf_v1.create_params
+// request. All planes required by the intended format are added with
+// the 'add' request. Finally, a 'create' or 'create_immed' request is
+// issued, which has the following outcome depending on the import success.
+//
+// The 'create' request,
+// - on success, triggers a 'created' event which provides the final
+// wl_buffer to the client.
+// - on failure, triggers a 'failed' event to convey that the server
+// cannot use the dmabufs received from the client.
+//
+// For the 'create_immed' request,
+// - on success, the server immediately imports the added dmabufs to
+// create a wl_buffer. No event is sent from the server in this case.
+// - on failure, the server can choose to either:
+// - terminate the client by raising a fatal error.
+// - mark the wl_buffer as failed, and send a 'failed' event to the
+// client. If the client uses a failed wl_buffer as an argument to any
+// request, the behaviour is compositor implementation-defined.
+//
+// For all DRM formats and unless specified in another protocol extension,
+// pre-multiplied alpha is used for pixel values.
+//
+// Unless specified otherwise in another protocol extension, implicit
+// synchronization is used. In other words, compositors and clients must
+// wait and signal fences implicitly passed via the DMA-BUF's reservation
+// mechanism.
+type LinuxDmabufV1 struct {
+	wire.Proxy
+
+	// Listener's methods are called by incoming messages from the
+	// remote end via Dispatch. If it is nil, messages are silently
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
+	Listener LinuxDmabufV1Listener
+
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(LinuxDmabufV1DestroyRequest)
+
+	// OnCreateParams, if not nil, is called with the arguments of
+	// each incoming create_params request before Listener is.
+	OnCreateParams func(LinuxDmabufV1CreateParamsRequest)
+
+	// OnGetDefaultFeedback, if not nil, is called with the arguments of
+	// each incoming get_default_feedback request before Listener is.
+	OnGetDefaultFeedback func(LinuxDmabufV1GetDefaultFeedbackRequest)
+
+	// OnGetSurfaceFeedback, if not nil, is called with the arguments of
+	// each incoming get_surface_feedback request before Listener is.
+	OnGetSurfaceFeedback func(LinuxDmabufV1GetSurfaceFeedbackRequest)
+}
+
+var (
+	_ wire.Object      = (*LinuxDmabufV1)(nil)
+	_ wire.DebugObject = (*LinuxDmabufV1)(nil)
+)
+
+// NewLinuxDmabufV1 returns a newly instantiated LinuxDmabufV1. It is
+// primarily intended for use by generated code.
+func NewLinuxDmabufV1(state wire.State) *LinuxDmabufV1 {
+	return &LinuxDmabufV1{Proxy: wire.NewProxy(state)}
+}
+
+// BindLinuxDmabufV1 creates a new LinuxDmabufV1 for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindLinuxDmabufV1(state wire.State, id wire.NewID) (*LinuxDmabufV1, error) {
+	if err := id.Check(LinuxDmabufV1Interface, LinuxDmabufV1Version); err != nil {
+		return nil, err
+	}
+
+	obj := NewLinuxDmabufV1(state)
+	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
+	state.Add(obj)
+	return obj, nil
+}
+
+func (obj *LinuxDmabufV1) Dispatch(msg *wire.MessageBuffer) error {
+	switch msg.Op() {
+	case 0:
+		if err := msg.Err(); err != nil {
+			return err
+		}
+
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(LinuxDmabufV1DestroyRequest{})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.Destroy()
+		return nil
+
+	case 1:
+
+		paramsId := NewLinuxBufferParamsV1(obj.State())
+		paramsId.SetID(msg.ReadUint())
+		paramsId.SetVersion(obj.Version())
+
+		if err := msg.Err(); err != nil {
+			return err
+		}
+		obj.State().Add(paramsId)
+
+		if obj.OnCreateParams != nil {
+			obj.OnCreateParams(LinuxDmabufV1CreateParamsRequest{
+				ParamsId: paramsId,
+			})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.CreateParams(
+			paramsId,
+		)
+		return nil
+
+	case 2:
+
+		id := NewLinuxDmabufFeedbackV1(obj.State())
+		id.SetID(msg.ReadUint())
+		id.SetVersion(obj.Version())
+
+		if err := msg.Err(); err != nil {
+			return err
+		}
+		obj.State().Add(id)
+
+		if obj.OnGetDefaultFeedback != nil {
+			obj.OnGetDefaultFeedback(LinuxDmabufV1GetDefaultFeedbackRequest{
+				Id: id,
+			})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.GetDefaultFeedback(
+			id,
+		)
+		return nil
+
+	case 3:
+
+		id := NewLinuxDmabufFeedbackV1(obj.State())
+		id.SetID(msg.ReadUint())
+		id.SetVersion(obj.Version())
+
+		surface, _ := obj.State().Get(msg.ReadUint()).(*wl.Surface)
+
+		if err := msg.Err(); err != nil {
+			return err
+		}
+		obj.State().Add(id)
+
+		if obj.OnGetSurfaceFeedback != nil {
+			obj.OnGetSurfaceFeedback(LinuxDmabufV1GetSurfaceFeedbackRequest{
+				Id:      id,
+				Surface: surface,
+			})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.GetSurfaceFeedback(
+			id,
+			surface,
+		)
+		return nil
+	}
+
+	return wire.UnknownOpError{
+		Interface: "zwp_linux_dmabuf_v1",
+		Type:      "request",
+		Op:        msg.Op(),
+	}
+}
+
+func (obj *LinuxDmabufV1) String() string {
+	return fmt.Sprintf("%v@%v", "zwp_linux_dmabuf_v1", obj.ID())
+}
+
+func (obj *LinuxDmabufV1) MethodName(op uint16) string {
+	switch op {
+	case 0:
+		return "destroy"
+
+	case 1:
+		return "create_params"
+
+	case 2:
+		return "get_default_feedback"
+
+	case 3:
+		return "get_surface_feedback"
+	}
+
+	return "unknown method"
+}
+
+func (obj *LinuxDmabufV1) Interface() string {
+	return LinuxDmabufV1Interface
+}
+
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// LinuxDmabufV1Version, the same as MaxVersion.
+func (obj *LinuxDmabufV1) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return LinuxDmabufV1Version
+}
+
+// MaxVersion returns LinuxDmabufV1Version, the highest version of
+// zwp_linux_dmabuf_v1 that is supported.
+func (obj *LinuxDmabufV1) MaxVersion() uint32 {
+	return LinuxDmabufV1Version
+}
+
+// This event advertises one buffer format that the server supports.
+// All the supported formats are advertised once when the client
+// binds to this interface. A roundtrip after binding guarantees
+// that the client has received all supported formats.
+//
+// For the definition of the format codes, see the
+// zwp_linux_buffer_params_v1::create request.
+//
+// Starting version 4, the format event is deprecated and must not be
+// sent by compositors. Instead, use get_default_feedback or
+// get_surface_feedback.
+func (obj *LinuxDmabufV1) Format(format uint32) {
+	builder := wire.NewMessage(obj, 0)
+
+	builder.WriteUint(format)
+
+	builder.Method = "format"
+	builder.Args = []any{format}
+	obj.State().Enqueue(builder)
+	return
+}
+
+// This event advertises the formats that the server supports, along with
+// the modifiers supported for each format. All the supported modifiers
+// for all the supported formats are advertised once when the client
+// binds to this interface. A roundtrip after binding guarantees that
+// the client has received all supported format-modifier pairs.
+//
+// For legacy support, DRM_FORMAT_MOD_INVALID (that is, modifier_hi ==
+// 0x00ffffff and modifier_lo == 0xffffffff) is allowed in this event.
+// It indicates that the server can support the format with an implicit
+// modifier. When a plane has DRM_FORMAT_MOD_INVALID as its modifier, it
+// is as if no explicit modifier is specified. The effective modifier
+// will be derived from the dmabuf.
+//
+// A compositor that sends valid modifiers and DRM_FORMAT_MOD_INVALID for
+// a given format supports both explicit modifiers and implicit modifiers.
+//
+// For the definition of the format and modifier codes, see the
+// zwp_linux_buffer_params_v1::create and zwp_linux_buffer_params_v1::add
+// requests.
+//
+// Starting version 4, the modifier event is deprecated and must not be
+// sent by compositors. Instead, use get_default_feedback or
+// get_surface_feedback.
+func (obj *LinuxDmabufV1) Modifier(format uint32, modifierHi uint32, modifierLo uint32) {
+	builder := wire.NewMessage(obj, 1)
+
+	builder.WriteUint(format)
+	builder.WriteUint(modifierHi)
+	builder.WriteUint(modifierLo)
+
+	builder.Method = "modifier"
+	builder.Args = []any{format, modifierHi, modifierLo}
+	obj.State().Enqueue(builder)
+	return
+}
+
+const (
+	LinuxBufferParamsV1Interface = "zwp_linux_buffer_params_v1"
+	LinuxBufferParamsV1Version   = 4
+)
+
+// LinuxBufferParamsV1Listener is a type that can respond to incoming
+// messages for a LinuxBufferParamsV1 object.
+type LinuxBufferParamsV1Listener interface {
+	// Cleans up the temporary data sent to the server for dmabuf-based
+	// wl_buffer creation.
+	Destroy()
+
+	// This request adds one dmabuf to the set in this
+	// zwp_linux_buffer_params_v1.
+	//
+	// The 64-bit unsigned value combined from modifier_hi and modifier_lo
+	// is the dmabuf layout modifier. DRM AddFB2 ioctl calls this the
+	// fb modifier, which is defined in drm_mode.h of Linux UAPI.
+	// This is an opaque token. Drivers use this token to express tiling,
+	// compression, etc. driver-specific modifications to the base format
+	// defined by the DRM fourcc code.
+	//
+	// Starting from version 4, the invalid_format protocol error is sent if
+	// the format + modifier pair was not advertised as supported.
+	//
+	// Starting from version 5, the invalid_format protocol error is sent if
+	// all planes don't use the same modifier.
+	//
+	// This request raises the PLANE_IDX error if plane_idx is too large.
+	// The error PLANE_SET is raised if attempting to set a plane that
+	// was already set.
+	Add(fd *os.File, planeIdx uint32, offset uint32, stride uint32, modifierHi uint32, modifierLo uint32)
+
+	// This asks for creation of a wl_buffer from the added dmabuf
+	// buffers. The wl_buffer is not created immediately but returned via
+	// the 'created' event if the dmabuf sharing succeeds. The sharing
+	// may fail at runtime for reasons a client cannot predict, in
+	// which case the 'failed' event is triggered.
+	//
+	// The 'format' argument is a DRM_FORMAT code, as defined by the
+	// libdrm's drm_fourcc.h. The Linux kernel's DRM sub-system is the
+	// authoritative source on how the format codes should work.
+	//
+	// The 'flags' is a bitfield of the flags defined in enum "flags".
+	// 'y_invert' means the that the image needs to be y-flipped.
+	//
+	// Flag 'interlaced' means that the frame in the buffer is not
+	// progressive as usual, but interlaced. An interlaced buffer as
+	// supported here must always contain both top and bottom fields.
+	// The top field always begins on the first pixel row. The temporal
+	// ordering between the two fields is top field first, unless
+	// 'bottom_first' is specified. It is undefined whether 'bottom_first'
+	// is ignored if 'interlaced' is not set.
+	//
+	// This protocol does not convey any information about field rate,
+	// duration, or timing, other than the relative ordering between the
+	// two fields in one buffer. A compositor may have to estimate the
+	// intended field rate from the incoming buffer rate. It is undefined
+	// whether the time of receiving wl_surface.commit with a new buffer
+	// attached, applying the wl_surface state, wl_surface.frame callback
+	// trigger, presentation, or any other point in the compositor cycle
+	// is used to measure the frame or field times. There is no support
+	// for detecting missed or late frames/fields/buffers either, and
+	// there is no support whatsoever for cooperating with interlaced
+	// compositor output.
+	//
+	// The composited image quality resulting from the use of interlaced
+	// buffers is explicitly undefined. A compositor may use elaborate
+	// hardware features or software to deinterlace and create progressive
+	// output frames from a sequence of interlaced input buffers, or it
+	// may produce substandard image quality. However, compositors that
+	// cannot guarantee reasonable image quality in all cases are recommended
+	// to just reject all interlaced buffers.
+	//
+	// Any argument errors, including non-positive width or height,
+	// mismatch between the number of planes and the format, bad
+	// format, bad offset or stride, may be indicated by fatal protocol
+	// errors: INCOMPLETE, INVALID_FORMAT, INVALID_DIMENSIONS,
+	// OUT_OF_BOUNDS.
+	//
+	// Dmabuf import errors in the server that are not obvious client
+	// bugs are returned via the 'failed' event as non-fatal. This
+	// allows attempting dmabuf sharing and falling back in the client
+	// if it fails.
+	//
+	// This request can be sent only once in the object's lifetime, after
+	// which the only legal request is destroy. This object should be
+	// destroyed after issuing a 'create' request. Attempting to use this
+	// object after issuing 'create' raises ALREADY_USED protocol error.
+	//
+	// It is not mandatory to issue 'create'. If a client wants to
+	// cancel the buffer creation, it can just destroy this object.
+	Create(width int32, height int32, format uint32, flags LinuxBufferParamsV1Flags)
+
+	// This asks for immediate creation of a wl_buffer by importing the
+	// added dmabufs.
+	//
+	// In case of import success, no event is sent from the server, and the
+	// wl_buffer is ready to be used by the client.
+	//
+	// Upon import failure, either of the following may happen, as seen fit
+	// by the implementation:
+	// - the client is terminated with one of the following fatal protocol
+	// errors:
+	// - INCOMPLETE, INVALID_FORMAT, INVALID_DIMENSIONS, OUT_OF_BOUNDS,
+	// in case of argument errors such as mismatch between the number
+	// of planes and the format, bad format, non-positive width or
+	// height, or bad offset or stride.
+	// - INVALID_WL_BUFFER, in case the cause for failure is unknown or
+	// platform specific.
+	// - the server creates an invalid wl_buffer, marks it as failed and
+	// sends a 'failed' event to the client. The result of using this
+	// invalid wl_buffer as an argument in any request by the client is
+	// defined by the compositor implementation.
+	//
+	// This takes the same arguments as a 'create' request, and obeys the
+	// same restrictions.
+	CreateImmed(bufferId *wl.Buffer, width int32, height int32, format uint32, flags LinuxBufferParamsV1Flags)
+}
+
+// LinuxBufferParamsV1DestroyRequest holds the arguments of a zwp_linux_buffer_params_v1.destroy
+// request.
+type LinuxBufferParamsV1DestroyRequest struct {
+}
+
+// LinuxBufferParamsV1AddRequest holds the arguments of a zwp_linux_buffer_params_v1.add
+// request.
+type LinuxBufferParamsV1AddRequest struct {
+	Fd         *os.File
+	PlaneIdx   uint32
+	Offset     uint32
+	Stride     uint32
+	ModifierHi uint32
+	ModifierLo uint32
+}
+
+// LinuxBufferParamsV1CreateRequest holds the arguments of a zwp_linux_buffer_params_v1.create
+// request.
+type LinuxBufferParamsV1CreateRequest struct {
+	Width  int32
+	Height int32
+	Format uint32
+	Flags  LinuxBufferParamsV1Flags
+}
+
+// LinuxBufferParamsV1CreateImmedRequest holds the arguments of a zwp_linux_buffer_params_v1.create_immed
+// request.
+type LinuxBufferParamsV1CreateImmedRequest struct {
+	BufferId *wl.Buffer
+	Width    int32
+	Height   int32
+	Format   uint32
+	Flags    LinuxBufferParamsV1Flags
+}
+
+// This temporary object is a collection of dmabufs and other
+// parameters that together form a single logical buffer. The temporary
+// object may eventually create one wl_buffer unless cancelled by
+// destroying it before requesting 'create'.
+//
+// Single-planar formats only require one dmabuf, however
+// multi-planar formats may require more than one dmabuf. For all
+// formats, an 'add' request must be called once per plane (even if the
+// underlying dmabuf fd is identical).
+//
+// You must use consecutive plane indices ('plane_idx' argument for 'add')
+// from zero to the number of planes used by the drm_fourcc format code.
+// All planes required by the format must be given exactly once, but can
+// be given in any order. Each plane index can be set only once.
+type LinuxBufferParamsV1 struct {
+	wire.Proxy
+
+	// Listener's methods are called by incoming messages from the
+	// remote end via Dispatch. If it is nil, messages are silently
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
+	Listener LinuxBufferParamsV1Listener
+
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(LinuxBufferParamsV1DestroyRequest)
+
+	// OnAdd, if not nil, is called with the arguments of
+	// each incoming add request before Listener is.
+	OnAdd func(LinuxBufferParamsV1AddRequest)
+
+	// OnCreate, if not nil, is called with the arguments of
+	// each incoming create request before Listener is.
+	OnCreate func(LinuxBufferParamsV1CreateRequest)
+
+	// OnCreateImmed, if not nil, is called with the arguments of
+	// each incoming create_immed request before Listener is.
+	OnCreateImmed func(LinuxBufferParamsV1CreateImmedRequest)
+}
+
+var (
+	_ wire.Object      = (*LinuxBufferParamsV1)(nil)
+	_ wire.DebugObject = (*LinuxBufferParamsV1)(nil)
+)
+
+// NewLinuxBufferParamsV1 returns a newly instantiated LinuxBufferParamsV1. It is
+// primarily intended for use by generated code.
+func NewLinuxBufferParamsV1(state wire.State) *LinuxBufferParamsV1 {
+	return &LinuxBufferParamsV1{Proxy: wire.NewProxy(state)}
+}
+
+func (obj *LinuxBufferParamsV1) Dispatch(msg *wire.MessageBuffer) error {
+	switch msg.Op() {
+	case 0:
+		if err := msg.Err(); err != nil {
+			return err
+		}
+
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(LinuxBufferParamsV1DestroyRequest{})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.Destroy()
+		return nil
+
+	case 1:
+		if have := msg.RemainingFDs(); have < 1 {
+			return wire.MissingFDsError{Interface: "zwp_linux_buffer_params_v1", Method: "add", Want: 1, Have: have}
+		}
+
+		fd := msg.ReadFile()
+
+		planeIdx := msg.ReadUint()
+
+		offset := msg.ReadUint()
+
+		stride := msg.ReadUint()
+
+		modifierHi := msg.ReadUint()
+
+		modifierLo := msg.ReadUint()
+
+		if err := msg.Err(); err != nil {
+			return err
+		}
+
+		if (obj.OnAdd == nil) && (obj.Listener == nil) {
+			obj.AddCleanup(fd.Close)
+			return nil
+		}
+
+		if obj.OnAdd != nil {
+			obj.OnAdd(LinuxBufferParamsV1AddRequest{
+				Fd:         fd,
+				PlaneIdx:   planeIdx,
+				Offset:     offset,
+				Stride:     stride,
+				ModifierHi: modifierHi,
+				ModifierLo: modifierLo,
+			})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.Add(
+			fd,
+			planeIdx,
+			offset,
+			stride,
+			modifierHi,
+			modifierLo,
+		)
+		return nil
+
+	case 2:
+
+		width := msg.ReadInt()
+
+		height := msg.ReadInt()
+
+		format := msg.ReadUint()
+
+		flags := LinuxBufferParamsV1Flags(msg.ReadUint())
+
+		if err := msg.Err(); err != nil {
+			return err
+		}
+
+		if obj.OnCreate != nil {
+			obj.OnCreate(LinuxBufferParamsV1CreateRequest{
+				Width:  width,
+				Height: height,
+				Format: format,
+				Flags:  flags,
+			})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.Create(
+			width,
+			height,
+			format,
+			flags,
+		)
+		return nil
+
+	case 3:
+
+		bufferId := wl.NewBuffer(obj.State())
+		bufferId.SetID(msg.ReadUint())
+		bufferId.SetVersion(obj.Version())
+
+		width := msg.ReadInt()
+
+		height := msg.ReadInt()
+
+		format := msg.ReadUint()
+
+		flags := LinuxBufferParamsV1Flags(msg.ReadUint())
+
+		if err := msg.Err(); err != nil {
+			return err
+		}
+		obj.State().Add(bufferId)
+
+		if obj.OnCreateImmed != nil {
+			obj.OnCreateImmed(LinuxBufferParamsV1CreateImmedRequest{
+				BufferId: bufferId,
+				Width:    width,
+				Height:   height,
+				Format:   format,
+				Flags:    flags,
+			})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.CreateImmed(
+			bufferId,
+			width,
+			height,
+			format,
+			flags,
+		)
+		return nil
+	}
+
+	return wire.UnknownOpError{
+		Interface: "zwp_linux_buffer_params_v1",
+		Type:      "request",
+		Op:        msg.Op(),
+	}
+}
+
+func (obj *LinuxBufferParamsV1) String() string {
+	return fmt.Sprintf("%v@%v", "zwp_linux_buffer_params_v1", obj.ID())
+}
+
+func (obj *LinuxBufferParamsV1) MethodName(op uint16) string {
+	switch op {
+	case 0:
+		return "destroy"
+
+	case 1:
+		return "add"
+
+	case 2:
+		return "create"
+
+	case 3:
+		return "create_immed"
+	}
+
+	return "unknown method"
+}
+
+func (obj *LinuxBufferParamsV1) Interface() string {
+	return LinuxBufferParamsV1Interface
+}
+
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// LinuxBufferParamsV1Version, the same as MaxVersion.
+func (obj *LinuxBufferParamsV1) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return LinuxBufferParamsV1Version
+}
+
+// MaxVersion returns LinuxBufferParamsV1Version, the highest version of
+// zwp_linux_buffer_params_v1 that is supported.
+func (obj *LinuxBufferParamsV1) MaxVersion() uint32 {
+	return LinuxBufferParamsV1Version
+}
+
+// This event indicates that the attempted buffer creation was
+// successful. It provides the new wl_buffer referencing the dmabuf(s).
+//
+// Upon receiving this event, the client should destroy the
+// zwp_linux_buffer_params_v1 object.
+func (obj *LinuxBufferParamsV1) Created() (buffer *wl.Buffer) {
+	builder := wire.NewMessage(obj, 0)
+
+	buffer = wl.NewBuffer(obj.State())
+	buffer.SetVersion(obj.Version())
+	obj.State().Add(buffer)
+	builder.WriteObject(buffer)
+
+	builder.Method = "created"
+	builder.Args = []any{buffer}
+	obj.State().Enqueue(builder)
+	return buffer
+}
+
+// This event indicates that the attempted buffer creation has
+// failed. It usually means that one of the dmabuf constraints
+// has not been fulfilled.
+//
+// Upon receiving this event, the client should destroy the
+// zwp_linux_buffer_params_v1 object.
+func (obj *LinuxBufferParamsV1) Failed() {
+	builder := wire.NewMessage(obj, 1)
+
+	builder.Method = "failed"
+	builder.Args = []any{}
+	obj.State().Enqueue(builder)
+	return
+}
+
+type LinuxBufferParamsV1Error int64
+
+const (
+	// the dmabuf_batch object has already been used to create a wl_buffer
+	LinuxBufferParamsV1ErrorAlreadyUsed LinuxBufferParamsV1Error = 0
+
+	// plane index out of bounds
+	LinuxBufferParamsV1ErrorPlaneIdx LinuxBufferParamsV1Error = 1
+
+	// the plane index was already set
+	LinuxBufferParamsV1ErrorPlaneSet LinuxBufferParamsV1Error = 2
+
+	// missing or too many planes to create a buffer
+	LinuxBufferParamsV1ErrorIncomplete LinuxBufferParamsV1Error = 3
+
+	// format not supported
+	LinuxBufferParamsV1ErrorInvalidFormat LinuxBufferParamsV1Error = 4
+
+	// invalid width or height
+	LinuxBufferParamsV1ErrorInvalidDimensions LinuxBufferParamsV1Error = 5
+
+	// offset + stride * height goes out of dmabuf bounds
+	LinuxBufferParamsV1ErrorOutOfBounds LinuxBufferParamsV1Error = 6
+
+	// invalid wl_buffer resulted from importing dmabufs via
+	// the create_immed request on given buffer_params
+	LinuxBufferParamsV1ErrorInvalidWlBuffer LinuxBufferParamsV1Error = 7
+)
+
+// LinuxBufferParamsV1ErrorNames maps the values of LinuxBufferParamsV1Error to their names.
+var LinuxBufferParamsV1ErrorNames = map[LinuxBufferParamsV1Error]string{
+	LinuxBufferParamsV1ErrorAlreadyUsed:       "LinuxBufferParamsV1ErrorAlreadyUsed",
+	LinuxBufferParamsV1ErrorPlaneIdx:          "LinuxBufferParamsV1ErrorPlaneIdx",
+	LinuxBufferParamsV1ErrorPlaneSet:          "LinuxBufferParamsV1ErrorPlaneSet",
+	LinuxBufferParamsV1ErrorIncomplete:        "LinuxBufferParamsV1ErrorIncomplete",
+	LinuxBufferParamsV1ErrorInvalidFormat:     "LinuxBufferParamsV1ErrorInvalidFormat",
+	LinuxBufferParamsV1ErrorInvalidDimensions: "LinuxBufferParamsV1ErrorInvalidDimensions",
+	LinuxBufferParamsV1ErrorOutOfBounds:       "LinuxBufferParamsV1ErrorOutOfBounds",
+	LinuxBufferParamsV1ErrorInvalidWlBuffer:   "LinuxBufferParamsV1ErrorInvalidWlBuffer",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum LinuxBufferParamsV1Error) String() string {
+	return wire.EnumString(enum, LinuxBufferParamsV1ErrorNames)
+}
+
+// Since returns the version of zwp_linux_buffer_params_v1 that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum LinuxBufferParamsV1Error) Since() uint32 {
+	return 1
+}
+
+type LinuxBufferParamsV1Flags int64
+
+const (
+	// contents are y-inverted
+	LinuxBufferParamsV1FlagsYInvert LinuxBufferParamsV1Flags = 1
+
+	// content is interlaced
+	LinuxBufferParamsV1FlagsInterlaced LinuxBufferParamsV1Flags = 2
+
+	// bottom field first
+	LinuxBufferParamsV1FlagsBottomFirst LinuxBufferParamsV1Flags = 4
+)
+
+// LinuxBufferParamsV1FlagsNames maps the values of LinuxBufferParamsV1Flags to their names.
+var LinuxBufferParamsV1FlagsNames = map[LinuxBufferParamsV1Flags]string{
+	LinuxBufferParamsV1FlagsYInvert:     "LinuxBufferParamsV1FlagsYInvert",
+	LinuxBufferParamsV1FlagsInterlaced:  "LinuxBufferParamsV1FlagsInterlaced",
+	LinuxBufferParamsV1FlagsBottomFirst: "LinuxBufferParamsV1FlagsBottomFirst",
+}
+
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum LinuxBufferParamsV1Flags) String() string {
+	return wire.FlagString(enum, LinuxBufferParamsV1FlagsNames)
+}
+
+// Since returns the version of zwp_linux_buffer_params_v1 that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum LinuxBufferParamsV1Flags) Since() uint32 {
+	return 1
+}
+
+const (
+	LinuxDmabufFeedbackV1Interface = "zwp_linux_dmabuf_feedback_v1"
+	LinuxDmabufFeedbackV1Version   = 4
+)
+
+// LinuxDmabufFeedbackV1Listener is a type that can respond to incoming
+// messages for a LinuxDmabufFeedbackV1 object.
+type LinuxDmabufFeedbackV1Listener interface {
+	// Using this request a client can tell the server that it is not going to
+	// use the wp_linux_dmabuf_feedback object anymore.
+	Destroy()
+}
+
+// LinuxDmabufFeedbackV1DestroyRequest holds the arguments of a zwp_linux_dmabuf_feedback_v1.destroy
+// request.
+type LinuxDmabufFeedbackV1DestroyRequest struct {
+}
+
+// This object advertises dmabuf parameters feedback. This includes the
+// preferred devices and the supported formats/modifiers.
+//
+// The parameters are sent once when this object is created and whenever they
+// change. The done event is always sent once after all parameters have been
+// sent. When a single parameter changes, all parameters are re-sent by the
+// compositor.
+//
+// Compositors can re-send the parameters when the current client buffer
+// allocations are sub-optimal. Compositors should not re-send the
+// parameters if re-allocating the buffers would not result in a more optimal
+// configuration. In particular, compositors should avoid sending the exact
+// same parameters multiple times in a row.
+//
+// The tranche_target_device and tranche_formats events are grouped by
+// tranches of preference. For each tranche, a tranche_target_device, one
+// tranche_flags and one or more tranche_formats events are sent, followed
+// by a tranche_done event finishing the list. The tranches are sent in
+// descending order of preference. All formats and modifiers in the same
+// tranche have the same preference.
+//
+// To send parameters, the compositor sends one main_device event, tranches
+// (each consisting of one tranche_target_device event, one tranche_flags
+// event, tranche_formats events and then a tranche_done event), then one
+// done event.
+type LinuxDmabufFeedbackV1 struct {
+	wire.Proxy
+
+	// Listener's methods are called by incoming messages from the
+	// remote end via Dispatch. If it is nil, messages are silently
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
+	Listener LinuxDmabufFeedbackV1Listener
+
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(LinuxDmabufFeedbackV1DestroyRequest)
+}
+
+var (
+	_ wire.Object      = (*LinuxDmabufFeedbackV1)(nil)
+	_ wire.DebugObject = (*LinuxDmabufFeedbackV1)(nil)
+)
+
+// NewLinuxDmabufFeedbackV1 returns a newly instantiated LinuxDmabufFeedbackV1. It is
+// primarily intended for use by generated code.
+func NewLinuxDmabufFeedbackV1(state wire.State) *LinuxDmabufFeedbackV1 {
+	return &LinuxDmabufFeedbackV1{Proxy: wire.NewProxy(state)}
+}
+
+func (obj *LinuxDmabufFeedbackV1) Dispatch(msg *wire.MessageBuffer) error {
+	switch msg.Op() {
+	case 0:
+		if err := msg.Err(); err != nil {
+			return err
+		}
+
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(LinuxDmabufFeedbackV1DestroyRequest{})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.Destroy()
+		return nil
+	}
+
+	return wire.UnknownOpError{
+		Interface: "zwp_linux_dmabuf_feedback_v1",
+		Type:      "request",
+		Op:        msg.Op(),
+	}
+}
+
+func (obj *LinuxDmabufFeedbackV1) String() string {
+	return fmt.Sprintf("%v@%v", "zwp_linux_dmabuf_feedback_v1", obj.ID())
+}
+
+func (obj *LinuxDmabufFeedbackV1) MethodName(op uint16) string {
+	switch op {
+	case 0:
+		return "destroy"
+	}
+
+	return "unknown method"
+}
+
+func (obj *LinuxDmabufFeedbackV1) Interface() string {
+	return LinuxDmabufFeedbackV1Interface
+}
+
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// LinuxDmabufFeedbackV1Version, the same as MaxVersion.
+func (obj *LinuxDmabufFeedbackV1) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return LinuxDmabufFeedbackV1Version
+}
+
+// MaxVersion returns LinuxDmabufFeedbackV1Version, the highest version of
+// zwp_linux_dmabuf_feedback_v1 that is supported.
+func (obj *LinuxDmabufFeedbackV1) MaxVersion() uint32 {
+	return LinuxDmabufFeedbackV1Version
+}
+
+// This event is sent after all parameters of a wp_linux_dmabuf_feedback
+// object have been sent.
+//
+// This allows changes to the wp_linux_dmabuf_feedback parameters to be
+// seen as atomic, even if they happen via multiple events.
+func (obj *LinuxDmabufFeedbackV1) Done() {
+	builder := wire.NewMessage(obj, 0)
+
+	builder.Method = "done"
+	builder.Args = []any{}
+	obj.State().Enqueue(builder)
+	return
+}
+
+// This event provides a file descriptor which can be memory-mapped to
+// access the format and modifier table.
+//
+// The table contains a tightly packed array of consecutive format +
+// modifier pairs. Each pair is 16 bytes wide. It contains a format as a
+// 32-bit unsigned integer, followed by 4 bytes of unused padding, and a
+// modifier as a 64-bit unsigned integer. The native endianness is used.
+//
+// The client must map the file descriptor in read-only private mode.
+//
+// Compositors are not allowed to mutate the table file contents once this
+// event has been sent. Instead, compositors must create a new, separate
+// table file and re-send feedback parameters. Compositors are allowed to
+// store duplicate format + modifier pairs in the table.
+func (obj *LinuxDmabufFeedbackV1) FormatTable(fd *os.File, size uint32) {
+	builder := wire.NewMessage(obj, 1)
+
+	builder.WriteFile(fd)
+	builder.WriteUint(size)
+
+	builder.Method = "format_table"
+	builder.Args = []any{fd, size}
+	obj.State().Enqueue(builder)
+	return
+}
+
+// This event advertises the main device that the server prefers to use
+// when direct scan-out to the target device isn't possible. The
+// advertised main device may be different for each
+// wp_linux_dmabuf_feedback object, and may change over time.
+//
+// There is exactly one main device. The compositor must send at least
+// one preference tranche with tranche_target_device equal to main_device.
+//
+// Clients need to create buffers that the main device can import and
+// read from, otherwise creating the dmabuf wl_buffer will fail (see the
+// wp_linux_buffer_params.create and create_immed requests for details).
+// The main device will also likely be kept active by the compositor,
+// so clients can use it instead of waking up another device for power
+// savings.
+//
+// In general the device is a DRM node. The DRM node type (primary vs.
+// render) is unspecified. Clients must not rely on the compositor sending
+// a particular node type. Clients cannot check two devices for equality
+// by comparing the dev_t value.
+//
+// If explicit modifiers are not supported and the client performs buffer
+// allocations on a different device than the main device, then the client
+// must force the buffer to have a linear layout.
+func (obj *LinuxDmabufFeedbackV1) MainDevice(device []byte) {
+	builder := wire.NewMessage(obj, 2)
+
+	builder.WriteArray(device)
+
+	builder.Fail(wire.CheckMessageSize(LinuxDmabufFeedbackV1Interface, "main_device", builder))
+
+	builder.Method = "main_device"
+	builder.Args = []any{device}
+	obj.State().Enqueue(builder)
+	return
+}
+
+// This event splits tranche_target_device and tranche_formats events in
+// preference tranches. It is sent after a set of tranche_target_device
+// and tranche_formats events; it represents the end of a tranche. The
+// next tranche will have a lower preference.
+func (obj *LinuxDmabufFeedbackV1) TrancheDone() {
+	builder := wire.NewMessage(obj, 3)
+
+	builder.Method = "tranche_done"
+	builder.Args = []any{}
+	obj.State().Enqueue(builder)
+	return
+}
+
+// This event advertises the target device that the server prefers to use
+// for a buffer created given this tranche. The advertised target device
+// may be different for each preference tranche, and may change over time.
+//
+// There is exactly one target device per tranche.
+//
+// The target device may be a scan-out device, for example if the
+// compositor prefers to directly scan-out a buffer created given this
+// tranche. The target device may be a rendering device, for example if
+// the compositor prefers to texture from said buffer.
+//
+// The client can use this hint to allocate the buffer in a way that makes
+// it accessible from the target device, ideally directly. The buffer must
+// still be accessible from the main device, either through direct import
+// or through a potentially more expensive fallback path. If the buffer
+// can't be directly imported from the main device then clients must be
+// prepared for the compositor changing the tranche priority or making
+// wl_buffer creation fail (see the wp_linux_buffer_params.create and
+// create_immed requests for details).
+//
+// If the device is a DRM node, the DRM node type (primary vs. render) is
+// unspecified. Clients must not rely on the compositor sending a
+// particular node type. Clients cannot check two devices for equality by
+// comparing the dev_t value.
+//
+// This event is tied to a preference tranche, see the tranche_done event.
+func (obj *LinuxDmabufFeedbackV1) TrancheTargetDevice(device []byte) {
+	builder := wire.NewMessage(obj, 4)
+
+	builder.WriteArray(device)
+
+	builder.Fail(wire.CheckMessageSize(LinuxDmabufFeedbackV1Interface, "tranche_target_device", builder))
+
+	builder.Method = "tranche_target_device"
+	builder.Args = []any{device}
+	obj.State().Enqueue(builder)
+	return
+}
+
+// This event advertises the format + modifier combinations that the
+// compositor supports.
+//
+// It carries an array of indices, each referring to a format + modifier
+// pair in the last received format table (see the format_table event).
+// Each index is a 16-bit unsigned integer in native endianness.
+//
+// For legacy support, DRM_FORMAT_MOD_INVALID is an allowed modifier.
+// It indicates that the server can support the format with an implicit
+// modifier. When a buffer has DRM_FORMAT_MOD_INVALID as its modifier, it
+// is as if no explicit modifier is specified. The effective modifier
+// will be derived from the dmabuf.
+//
+// A compositor that sends valid modifiers and DRM_FORMAT_MOD_INVALID for
+// a given format supports both explicit modifiers and implicit modifiers.
+//
+// Compositors must not send duplicate format + modifier pairs within the
+// same tranche or across two different tranches with the same target
+// device and flags.
+//
+// This event is tied to a preference tranche, see the tranche_done event.
+//
+// For the definition of the format and modifier codes, see the
+// wp_linux_buffer_params.create request.
+func (obj *LinuxDmabufFeedbackV1) TrancheFormats(indices []byte) {
+	builder := wire.NewMessage(obj, 5)
+
+	builder.WriteArray(indices)
+
+	builder.Fail(wire.CheckMessageSize(LinuxDmabufFeedbackV1Interface, "tranche_formats", builder))
+
+	builder.Method = "tranche_formats"
+	builder.Args = []any{indices}
+	obj.State().Enqueue(builder)
+	return
+}
+
+// This event sets tranche-specific flags.
+//
+// The scanout flag is a hint that direct scan-out may be attempted by the
+// compositor on the target device if the client appropriately allocates a
+// buffer. How to allocate a buffer that can be scanned out on the target
+// device is implementation-defined.
+//
+// This event is tied to a preference tranche, see the tranche_done event.
+func (obj *LinuxDmabufFeedbackV1) TrancheFlags(flags LinuxDmabufFeedbackV1TrancheFlags) {
+	builder := wire.NewMessage(obj, 6)
+
+	builder.WriteUint(uint32(flags))
+
+	builder.Method = "tranche_flags"
+	builder.Args = []any{flags}
+	obj.State().Enqueue(builder)
+	return
+}
+
+type LinuxDmabufFeedbackV1TrancheFlags int64
+
+const (
+	// direct scan-out tranche
+	LinuxDmabufFeedbackV1TrancheFlagsScanout LinuxDmabufFeedbackV1TrancheFlags = 1
+)
+
+// LinuxDmabufFeedbackV1TrancheFlagsNames maps the values of LinuxDmabufFeedbackV1TrancheFlags to their names.
+var LinuxDmabufFeedbackV1TrancheFlagsNames = map[LinuxDmabufFeedbackV1TrancheFlags]string{
+	LinuxDmabufFeedbackV1TrancheFlagsScanout: "LinuxDmabufFeedbackV1TrancheFlagsScanout",
+}
+
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum LinuxDmabufFeedbackV1TrancheFlags) String() string {
+	return wire.FlagString(enum, LinuxDmabufFeedbackV1TrancheFlagsNames)
+}
+
+// Since returns the version of zwp_linux_dmabuf_feedback_v1 that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum LinuxDmabufFeedbackV1TrancheFlags) Since() uint32 {
+	return 1
+}
@@ -7,9 +7,9 @@ import (
 	"os/signal"
 	"time"
 
-	xdg "deedles.dev/wl/examples/internal/xdg/server"
 	wl "deedles.dev/wl/server"
 	"deedles.dev/wl/wire"
+	xdg "deedles.dev/wl/xdg/server"
 	"deedles.dev/xsync"
 )
 
@@ -123,13 +123,26 @@ type registryListener clientState
 func (cs *registryListener) Bind(name uint32, id wire.NewID) {
 	switch name {
 	case 0:
-		c := wl.BindCompositor(cs.client, id)
+		c, err := wl.BindCompositor(cs.client, id)
+		if err != nil {
+			log.Printf("bind compositor: %v", err)
+			return
+		}
 		c.Listener = (*compositorListener)(cs)
 	case 1:
-		shm := wl.BindShm(cs.client, id)
+		shm, err := wl.BindShm(cs.client, id)
+		if err != nil {
+			log.Printf("bind shm: %v", err)
+			return
+		}
 		shm.Listener = (*shmListener)(cs)
 	case 2:
-		cs.wmBase = xdg.BindWmBase(cs.client, id)
+		wmBase, err := xdg.BindWmBase(cs.client, id)
+		if err != nil {
+			log.Printf("bind wm_base: %v", err)
+			return
+		}
+		cs.wmBase = wmBase
 		cs.wmBase.Listener = (*wmBaseListener)(cs)
 	}
 }
@@ -28,7 +28,11 @@ func (lis *registryListener) Global(name uint32, inter string, version uint32) {
 
 	switch inter {
 	case wl.OutputInterface:
-		output := wl.BindOutput(lis.state, lis.registry, name, version)
+		output, err := wl.BindOutput(lis.state, lis.registry, name, version)
+		if err != nil {
+			log.Printf("bind output: %v", err)
+			return
+		}
 		output.Listener = (*outputListener)(lis)
 	}
 }
@@ -14,10 +14,10 @@ import (
 	"os/signal"
 
 	wl "deedles.dev/wl/client"
-	xdg "deedles.dev/wl/examples/internal/xdg/client"
 	"deedles.dev/wl/pointer"
 	"deedles.dev/wl/shm"
 	"deedles.dev/wl/wire"
+	xdg "deedles.dev/wl/xdg/client"
 	"deedles.dev/ximage/xcursor"
 	"deedles.dev/xsync"
 	_ "golang.org/x/image/bmp"
@@ -43,10 +43,9 @@ type state struct {
 	keyboard   *wl.Keyboard
 	pointer    *wl.Pointer
 
-	surface  *wl.Surface
-	xsurface *xdg.Surface
-	toplevel *xdg.Toplevel
-	buffer   *wl.ImageBuffer
+	surface *wl.Surface
+	window  *xdg.Window
+	buffer  *wl.ImageBuffer
 
 	cursorSurface *wl.Surface
 	cursorHot     image.Point
@@ -100,12 +99,10 @@ func (s *state) init() error {
 func (s *state) initWindow() {
 	s.surface = s.compositor.CreateSurface()
 
-	s.xsurface = s.wmBase.GetXdgSurface(s.surface)
-	s.xsurface.Listener = (*xdgSurfaceListener)(s)
-
-	s.toplevel = s.xsurface.GetToplevel()
-	s.toplevel.SetTitle("Example")
-	s.toplevel.Listener = (*xdgToplevelListener)(s)
+	s.window = xdg.NewWindow(s.wmBase, s.surface)
+	s.window.Toplevel.SetTitle("Example")
+	s.window.OnConfigure = func(xdg.ToplevelConfig) { s.draw(0, 0) }
+	s.window.OnClose = s.stop.Stop
 
 	s.keyboard = s.seat.GetKeyboard()
 
@@ -242,27 +239,28 @@ func (s *displayListener) DeleteId(id uint32) {
 type registryListener state
 
 func (s *registryListener) Global(name uint32, inter string, version uint32) {
+	var err error
 	switch inter {
 	case wl.CompositorInterface:
-		s.compositor = wl.BindCompositor(s.client, s.registry, name, version)
+		s.compositor, err = wl.BindCompositor(s.client, s.registry, name, version)
 	case wl.ShmInterface:
-		s.shm = wl.BindShm(s.client, s.registry, name, version)
+		s.shm, err = wl.BindShm(s.client, s.registry, name, version)
 	case xdg.WmBaseInterface:
-		s.wmBase = xdg.BindWmBase(s.client, s.registry, name, version)
-		s.wmBase.Listener = (*wmBaseListener)(s)
+		s.wmBase, err = xdg.BindWmBase(s.client, s.registry, name, version)
+		if err != nil {
+			break
+		}
+		s.wmBase.AutoPong()
 	case wl.SeatInterface:
-		s.seat = wl.BindSeat(s.client, s.registry, name, version)
+		s.seat, err = wl.BindSeat(s.client, s.registry, name, version)
+	}
+	if err != nil {
+		log.Fatalf("bind %v: %v", inter, err)
 	}
 }
 
 func (s *registryListener) GlobalRemove(name uint32) {}
 
-type wmBaseListener state
-
-func (s *wmBaseListener) Ping(serial uint32) {
-	s.wmBase.Pong(serial)
-}
-
 type pointerListener state
 
 func (s *pointerListener) Enter(serial uint32, surface *wl.Surface, surfaceX wire.Fixed, surfaceY wire.Fixed) {
@@ -294,12 +292,12 @@ func (s *pointerListener) Button(serial, time uint32, button uint32, bstate wl.P
 		case s.pointerLoc.In(s.closeBounds):
 			s.stop.Stop()
 		//case s.pointerLoc.In(s.maxBounds):
-		//	s.toplevel.SetMaximized(!s.max)
+		//	s.window.Toplevel.SetMaximized(!s.max)
 		//	s.max = !s.max
 		case s.pointerLoc.In(s.minBounds):
-			s.toplevel.SetMinimized()
+			s.window.Toplevel.SetMinimized()
 		case s.pointerLoc.In(s.barBounds):
-			s.toplevel.Move(s.seat, serial)
+			s.window.Toplevel.Move(s.seat, serial)
 		}
 	}
 }
@@ -314,26 +312,6 @@ func (s *pointerListener) AxisStop(time uint32, axis wl.PointerAxis) {}
 
 func (s *pointerListener) AxisDiscrete(axis wl.PointerAxis, discrete int32) {}
 
-type xdgSurfaceListener state
-
-func (s *xdgSurfaceListener) Configure(serial uint32) {
-	s.xsurface.AckConfigure(serial)
-
-	(*state)(s).draw(0, 0)
-}
-
-type xdgToplevelListener state
-
-func (s *xdgToplevelListener) Configure(w, h int32, states []byte) {}
-
-func (s *xdgToplevelListener) Close() {
-	s.stop.Stop()
-}
-
-func (s *xdgToplevelListener) ConfigureBounds(w, h int32) {}
-
-func (s *xdgToplevelListener) WmCapabilities(capabilities []byte) {}
-
 func fillRect(img draw.Image, r image.Rectangle, c color.Color) {
 	r = r.Canon()
 	for y := r.Min.Y; y < r.Max.Y; y++ {
@@ -1,23 +1,37 @@
 package debug
 
 import (
-	"log"
+	"fmt"
+	"io"
 	"os"
-	"strconv"
+	"strings"
+	"time"
 )
 
-var debug = func(string, ...any) {}
+// Client and Server report whether the WAYLAND_DEBUG environment
+// variable turns on tracing for the client and server ends of
+// connections, respectively.
+var Client, Server bool
 
 func init() {
-	debugLevel, err := strconv.ParseInt(os.Getenv("WAYLAND_DEBUG"), 10, 0)
-	if err != nil {
-		return
-	}
-	if debugLevel > 0 {
-		debug = func(str string, args ...any) { log.Printf(str, args...) }
+	// libwayland enables tracing for whichever end of the connection
+	// is named, or for both if the value is 1.
+	v := os.Getenv("WAYLAND_DEBUG")
+	Client, Server = parse(v)
+}
+
+func parse(v string) (client, server bool) {
+	if v == "1" {
+		return true, true
 	}
+	return strings.Contains(v, "client"), strings.Contains(v, "server")
 }
 
-func Printf(str string, args ...any) {
-	debug(str, args...)
+// Fprintf writes a line of debug output to w. Each line is prefixed
+// with a timestamp in milliseconds formatted the same way as
+// libwayland's WAYLAND_DEBUG output, so that traces from both can be
+// compared directly.
+func Fprintf(w io.Writer, str string, args ...any) {
+	us := time.Now().UnixMicro()
+	fmt.Fprintf(w, "[%7d.%03d] %v\n", uint32(us/1000), us%1000, fmt.Sprintf(str, args...))
 }
@@ -0,0 +1,26 @@
+package debug
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		v              string
+		client, server bool
+	}{
+		{"", false, false},
+		{"0", false, false},
+		{"1", true, true},
+		{"client", true, false},
+		{"server", false, true},
+		{"client,server", true, true},
+		{"11", false, false},
+		{"client1", true, false},
+	}
+
+	for _, test := range tests {
+		client, server := parse(test.v)
+		if (client != test.client) || (server != test.server) {
+			t.Errorf("WAYLAND_DEBUG=%q: got client %v, server %v, want %v, %v", test.v, client, server, test.client, test.server)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Package limit provides a way to bound the number of items that
+// have been produced but not yet consumed.
+package limit
+
+import "sync/atomic"
+
+// Limiter counts pending items and makes producers wait while there
+// are too many of them. A zero Limiter has no limit.
+type Limiter struct {
+	limit   atomic.Int64
+	pending atomic.Int64
+	signal  atomic.Pointer[chan struct{}]
+}
+
+func (l *Limiter) signalChan() chan struct{} {
+	if c := l.signal.Load(); c != nil {
+		return *c
+	}
+
+	c := make(chan struct{}, 1)
+	if l.signal.CompareAndSwap(nil, &c) {
+		return c
+	}
+	return *l.signal.Load()
+}
+
+func (l *Limiter) wake() {
+	select {
+	case l.signalChan() <- struct{}{}:
+	default:
+	}
+}
+
+// SetLimit sets the maximum number of pending items. A limit of zero
+// or less removes the limit.
+func (l *Limiter) SetLimit(n int) {
+	l.limit.Store(int64(n))
+	l.wake()
+}
+
+// Wait blocks until there are fewer pending items than the limit or
+// until stop is closed. It returns false in the latter case.
+func (l *Limiter) Wait(stop <-chan struct{}) bool {
+	for {
+		limit := l.limit.Load()
+		if (limit <= 0) || (l.pending.Load() < limit) {
+			return true
+		}
+
+		select {
+		case <-stop:
+			return false
+		case <-l.signalChan():
+		}
+	}
+}
+
+// Add adds a pending item.
+func (l *Limiter) Add() {
+	l.pending.Add(1)
+}
+
+// Done removes a pending item, waking up a waiting producer.
+func (l *Limiter) Done() {
+	l.pending.Add(-1)
+	l.wake()
+}
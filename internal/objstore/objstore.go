@@ -1,50 +1,118 @@
 package objstore
 
 import (
-	"deedles.dev/wl/internal/debug"
+	"errors"
+	"fmt"
+	"slices"
+
 	"deedles.dev/wl/wire"
 )
 
+// Store tracks objects by ID and allocates IDs for new objects from
+// the range [start, end]. IDs in that range are reused once the
+// objects that they belonged to have been deleted, with the most
+// recently freed ID being reused first, the same as libwayland does.
 type Store struct {
-	objects map[uint32]wire.Object
-	nextID  uint32
+	objects    map[uint32]wire.Object
+	start, end uint32
+	nextID     uint32
+	free       []uint32
 }
 
-func New(start uint32) *Store {
+func New(start, end uint32) *Store {
 	return &Store{
 		objects: make(map[uint32]wire.Object),
+		start:   start,
+		end:     end,
 		nextID:  start,
 	}
 }
 
+// Add adds obj to the store. If obj has no ID yet, a new one is
+// allocated for it. If it already has one that is in the range that s
+// allocates from, that ID is reserved so that it is not handed out to
+// another object while obj is using it.
 func (s *Store) Add(obj wire.Object) {
 	id := obj.ID()
 	if id == 0 {
-		id = s.nextID
+		id = s.allocID()
 		obj.SetID(id)
-		s.nextID++
+	} else if s.owns(id) {
+		s.reserveID(id)
 	}
 
 	s.objects[id] = obj
 }
 
+// reserveID removes id from the IDs that are available to be
+// allocated.
+func (s *Store) reserveID(id uint32) {
+	if i := slices.Index(s.free, id); i >= 0 {
+		s.free = slices.Delete(s.free, i, i+1)
+	}
+	if id >= s.nextID {
+		s.nextID = id + 1
+	}
+}
+
+func (s *Store) allocID() uint32 {
+	if len(s.free) > 0 {
+		id := s.free[len(s.free)-1]
+		s.free = s.free[:len(s.free)-1]
+		return id
+	}
+
+	if (s.nextID < s.start) || (s.nextID > s.end) {
+		panic(fmt.Errorf("all object IDs from %#x to %#x are in use", s.start, s.end))
+	}
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+func (s *Store) owns(id uint32) bool {
+	return (id >= s.start) && (id <= s.end)
+}
+
 func (s *Store) Get(id uint32) wire.Object {
 	return s.objects[id]
 }
 
+// Delete removes the object with the given ID and calls its Delete
+// method. If the ID was allocated by s, it becomes available to be
+// reused, so this must not be called for such an object until the
+// remote end has confirmed that it is no longer using the ID, such as
+// via wl_display.delete_id.
 func (s *Store) Delete(id uint32) {
-	obj := s.objects[id]
+	obj, ok := s.objects[id]
+	if !ok {
+		return
+	}
+
 	delete(s.objects, id)
-	if obj != nil {
-		obj.Delete()
+	if s.owns(id) {
+		s.free = append(s.free, id)
 	}
+	obj.Delete()
 }
 
-func (s *Store) Clear() {
+// Reset deletes every object in the store, calling their Delete
+// methods, and resets ID allocation so that the store can be used as
+// if it was new. Objects that have a Cleanup method, such as the ones
+// that embed a wire.Proxy, have it called first so that any errors
+// from releasing the resources that they hold can be returned.
+func (s *Store) Reset() error {
+	var errs []error
 	for id, obj := range s.objects {
+		if c, ok := obj.(interface{ Cleanup() error }); ok {
+			errs = append(errs, c.Cleanup())
+		}
 		obj.Delete()
 		delete(s.objects, id)
 	}
+	s.nextID = s.start
+	s.free = s.free[:0]
+	return errors.Join(errs...)
 }
 
 func (s *Store) Dispatch(msg *wire.MessageBuffer) error {
@@ -54,6 +122,22 @@ func (s *Store) Dispatch(msg *wire.MessageBuffer) error {
 	}
 
 	err := obj.Dispatch(msg)
-	debug.Printf("%v", msg.Debug(obj))
+
+	conn := msg.Conn()
+	if (conn != nil) && conn.Tracing() {
+		conn.Tracef("%v", msg.Debug(obj))
+		if err == nil {
+			err := msg.Verify()
+			if err != nil {
+				conn.Tracef("%v: %v", obj, err)
+			}
+		}
+
+		var malformed wire.MalformedMessageError
+		if errors.As(err, &malformed) {
+			conn.Tracef("%v:\n%v", malformed, malformed.Dump())
+		}
+	}
+
 	return err
 }
@@ -0,0 +1,139 @@
+package objstore
+
+import (
+	"testing"
+
+	"deedles.dev/wl/wire"
+)
+
+type testObject struct {
+	id      uint32
+	deleted bool
+}
+
+func (obj *testObject) ID() uint32                             { return obj.id }
+func (obj *testObject) SetID(id uint32)                        { obj.id = id }
+func (obj *testObject) Dispatch(msg *wire.MessageBuffer) error { return nil }
+func (obj *testObject) Delete()                                { obj.deleted = true }
+
+func add(s *Store) *testObject {
+	var obj testObject
+	s.Add(&obj)
+	return &obj
+}
+
+func TestSequentialIDs(t *testing.T) {
+	s := New(wire.ClientIDMin, wire.ClientIDMax)
+	for i := range uint32(5) {
+		obj := add(s)
+		if obj.id != wire.ClientIDMin+i {
+			t.Fatalf("object %v got ID %v", i, obj.id)
+		}
+		if s.Get(obj.id) != obj {
+			t.Fatalf("object %v not found by ID", i)
+		}
+	}
+}
+
+func TestRecycleIDs(t *testing.T) {
+	s := New(1, 100)
+	objs := []*testObject{add(s), add(s), add(s), add(s)}
+
+	s.Delete(objs[1].id)
+	s.Delete(objs[3].id)
+	if !objs[1].deleted || !objs[3].deleted {
+		t.Fatal("Delete did not call the objects' Delete methods")
+	}
+	if s.Get(objs[1].id) != nil {
+		t.Fatal("deleted object is still in the store")
+	}
+
+	// The most recently freed ID is reused first.
+	for _, want := range []uint32{4, 2, 5} {
+		if obj := add(s); obj.id != want {
+			t.Fatalf("got ID %v, want %v", obj.id, want)
+		}
+	}
+}
+
+func TestDeleteForeignID(t *testing.T) {
+	s := New(wire.ServerIDMin, wire.ServerIDMax)
+	obj := &testObject{id: 3}
+	s.Add(obj)
+	s.Delete(3)
+
+	// IDs outside of the store's range are never handed out.
+	if next := add(s); next.id != wire.ServerIDMin {
+		t.Fatalf("got ID %#x, want %#x", next.id, wire.ServerIDMin)
+	}
+}
+
+func TestExhaustion(t *testing.T) {
+	s := New(1, 3)
+	objs := []*testObject{add(s), add(s), add(s)}
+
+	assertPanics(t, func() { add(s) })
+
+	s.Delete(objs[1].id)
+	if obj := add(s); obj.id != 2 {
+		t.Fatalf("got ID %v after freeing one, want 2", obj.id)
+	}
+	assertPanics(t, func() { add(s) })
+}
+
+func TestExhaustionAtRangeEnd(t *testing.T) {
+	s := New(wire.ServerIDMax-1, wire.ServerIDMax)
+	add(s)
+	add(s)
+	assertPanics(t, func() { add(s) })
+}
+
+func TestAddExplicitID(t *testing.T) {
+	s := New(1, 100)
+	s.Add(&testObject{id: 3})
+
+	// IDs after an explicitly added one continue from it.
+	if obj := add(s); obj.id != 4 {
+		t.Fatalf("got ID %v, want 4", obj.id)
+	}
+
+	// An explicitly added ID is taken out of the free list.
+	free := add(s)
+	s.Delete(free.id)
+	s.Add(&testObject{id: free.id})
+	if obj := add(s); obj.id == free.id {
+		t.Fatalf("ID %v was allocated while it was still in use", obj.id)
+	}
+}
+
+func TestReset(t *testing.T) {
+	s := New(1, 100)
+	objs := []*testObject{add(s), add(s)}
+	s.Delete(objs[0].id)
+
+	if err := s.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if !objs[1].deleted {
+		t.Fatal("Reset did not delete the remaining object")
+	}
+	if s.Get(objs[1].id) != nil {
+		t.Fatal("object is still in the store after Reset")
+	}
+	if obj := add(s); obj.id != 1 {
+		t.Fatalf("got ID %v after Reset, want 1", obj.id)
+	}
+	if obj := add(s); obj.id != 2 {
+		t.Fatalf("got ID %v after Reset, want 2", obj.id)
+	}
+}
+
+func assertPanics(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	f()
+}
@@ -0,0 +1,260 @@
+// Code generated by wlgen from the test protocol. DO NOT EDIT.
+
+// Copyright 2026 the wl authors.
+//
+// This protocol is used only for testing generated code.
+
+package testproto
+
+import (
+	"deedles.dev/wl/wire"
+	"fmt"
+)
+
+// ProtocolName is the name of the protocol that this file was
+// generated from.
+const ProtocolName = "test"
+
+// Interfaces lists the interfaces defined by the test
+// protocol along with the highest version of each that is supported.
+var Interfaces = []wire.InterfaceInfo{
+	{Name: WidgetInterface, Version: WidgetVersion},
+}
+
+const (
+	WidgetInterface = "test_widget"
+	WidgetVersion   = 2
+)
+
+// WidgetListener is a type that can respond to incoming
+// messages for a Widget object.
+type WidgetListener interface {
+	Mode(mode WidgetMode, flags WidgetFlags)
+}
+
+// WidgetModeEvent holds the arguments of a test_widget.mode
+// event.
+type WidgetModeEvent struct {
+	Mode  WidgetMode
+	Flags WidgetFlags
+}
+
+// A global with enums that have entries added in version 2 and a
+// request with a string argument.
+type Widget struct {
+	wire.Proxy
+
+	// Listener's methods are called by incoming messages from the
+	// remote end via Dispatch. If it is nil, messages are silently
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
+	Listener WidgetListener
+
+	// OnMode, if not nil, is called with the arguments of
+	// each incoming mode event before Listener is.
+	OnMode func(WidgetModeEvent)
+}
+
+var (
+	_ wire.Object      = (*Widget)(nil)
+	_ wire.DebugObject = (*Widget)(nil)
+)
+
+// NewWidget returns a newly instantiated Widget. It is
+// primarily intended for use by generated code.
+func NewWidget(state wire.State) *Widget {
+	return &Widget{Proxy: wire.NewProxy(state)}
+}
+
+// BindWidget binds the global identified by name to a new
+// Widget. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and WidgetVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindWidget(state wire.State, registry wire.Binder, name, version uint32) (*Widget, error) {
+	v := wire.NegotiateVersion(WidgetVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: WidgetInterface, Local: WidgetVersion, Remote: version}
+	}
+
+	obj := NewWidget(state)
+	obj.SetVersion(v)
+	state.Add(obj)
+	registry.Bind(name, wire.NewID{Interface: WidgetInterface, Version: v, ID: obj.ID()})
+	return obj, nil
+}
+
+func (obj *Widget) Dispatch(msg *wire.MessageBuffer) error {
+	switch msg.Op() {
+	case 0:
+
+		mode := WidgetMode(msg.ReadUint())
+
+		flags := WidgetFlags(msg.ReadUint())
+
+		if err := msg.Err(); err != nil {
+			return err
+		}
+		if err := wire.CheckEnumVersion(WidgetInterface, "mode", obj.Version(), mode); err != nil {
+			return err
+		}
+		if err := wire.CheckEnumVersion(WidgetInterface, "mode", obj.Version(), flags); err != nil {
+			return err
+		}
+
+		if obj.OnMode != nil {
+			obj.OnMode(WidgetModeEvent{
+				Mode:  mode,
+				Flags: flags,
+			})
+		}
+		if obj.Listener == nil {
+			return nil
+		}
+		obj.Listener.Mode(
+			mode,
+			flags,
+		)
+		return nil
+	}
+
+	return wire.UnknownOpError{
+		Interface: "test_widget",
+		Type:      "event",
+		Op:        msg.Op(),
+	}
+}
+
+func (obj *Widget) String() string {
+	return fmt.Sprintf("%v@%v", "test_widget", obj.ID())
+}
+
+func (obj *Widget) MethodName(op uint16) string {
+	switch op {
+	case 0:
+		return "mode"
+	}
+
+	return "unknown method"
+}
+
+func (obj *Widget) Interface() string {
+	return WidgetInterface
+}
+
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// WidgetVersion, the same as MaxVersion.
+func (obj *Widget) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return WidgetVersion
+}
+
+// MaxVersion returns WidgetVersion, the highest version of
+// test_widget that is supported.
+func (obj *Widget) MaxVersion() uint32 {
+	return WidgetVersion
+}
+
+func (obj *Widget) SetMode(mode WidgetMode, flags WidgetFlags) {
+	builder := wire.NewMessage(obj, 0)
+
+	builder.WriteUint(uint32(mode))
+	builder.WriteUint(uint32(flags))
+
+	builder.Fail(wire.CheckEnumVersion(WidgetInterface, "set_mode", obj.Version(), mode))
+	builder.Fail(wire.CheckEnumVersion(WidgetInterface, "set_mode", obj.Version(), flags))
+
+	builder.Method = "set_mode"
+	builder.Args = []any{mode, flags}
+	obj.State().Enqueue(builder)
+	return
+}
+func (obj *Widget) SetTitle(title string) {
+	builder := wire.NewMessage(obj, 1)
+
+	builder.WriteString(title)
+
+	builder.Fail(wire.CheckMessageSize(WidgetInterface, "set_title", builder))
+
+	builder.Method = "set_title"
+	builder.Args = []any{title}
+	obj.State().Enqueue(builder)
+	return
+}
+
+type WidgetMode int64
+
+const (
+	// available in every version
+	WidgetModePlain WidgetMode = 0
+
+	// added in version 2
+	//
+	// Since version 2.
+	WidgetModeFancy WidgetMode = 1
+)
+
+// WidgetModeNames maps the values of WidgetMode to their names.
+var WidgetModeNames = map[WidgetMode]string{
+	WidgetModePlain: "WidgetModePlain",
+	WidgetModeFancy: "WidgetModeFancy",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum WidgetMode) String() string {
+	return wire.EnumString(enum, WidgetModeNames)
+}
+
+// Since returns the version of test_widget that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum WidgetMode) Since() uint32 {
+	switch enum {
+	case 1:
+		return 2
+	}
+	return 1
+}
+
+type WidgetFlags int64
+
+const (
+	WidgetFlagsNone WidgetFlags = 0
+
+	// available in every version
+	WidgetFlagsBold WidgetFlags = 1
+
+	// added in version 2
+	//
+	// Since version 2.
+	WidgetFlagsShiny WidgetFlags = 2
+)
+
+// WidgetFlagsNames maps the values of WidgetFlags to their names.
+var WidgetFlagsNames = map[WidgetFlags]string{
+	WidgetFlagsNone:  "WidgetFlagsNone",
+	WidgetFlagsBold:  "WidgetFlagsBold",
+	WidgetFlagsShiny: "WidgetFlagsShiny",
+}
+
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum WidgetFlags) String() string {
+	return wire.FlagString(enum, WidgetFlagsNames)
+}
+
+// Since returns the version of test_widget that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum WidgetFlags) Since() uint32 {
+	since := uint32(1)
+	if (enum & 2) != 0 {
+		since = max(since, 2)
+	}
+	return since
+}
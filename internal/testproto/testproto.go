@@ -0,0 +1,8 @@
+// Package testproto holds bindings generated from a small protocol
+// that exists only to test the code generated by wlgen, such as
+// against features that the protocols that are used for real don't
+// make use of in a way that is convenient to test. The client and
+// server subpackages contain the bindings for each end.
+package testproto
+
+//go:generate go run deedles.dev/wl/cmd/wlgen -role both -xml test.xml -out protocol.go
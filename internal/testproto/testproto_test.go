@@ -0,0 +1,186 @@
+package testproto_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	testc "deedles.dev/wl/internal/testproto/client"
+	tests "deedles.dev/wl/internal/testproto/server"
+	"deedles.dev/wl/wire"
+)
+
+// state sends every message that is enqueued immediately and records
+// the error from doing so.
+type state struct {
+	conn *wire.Conn
+	err  error
+}
+
+func (s *state) Add(wire.Object)        {}
+func (s *state) Get(uint32) wire.Object { return nil }
+
+func (s *state) Enqueue(msg *wire.MessageBuilder) {
+	s.err = msg.Build(s.conn)
+}
+
+func newPair(t *testing.T) (client, server *wire.Conn) {
+	t.Helper()
+
+	c, s, err := wire.SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, server = wire.NewConn(c), wire.NewConn(s)
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+func assertEnumVersionError(t *testing.T, err error, value string) {
+	t.Helper()
+
+	var verr wire.EnumVersionError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected EnumVersionError, got %v", err)
+	}
+	if (verr.Value != value) || (verr.Since != 2) || (verr.Version != 1) {
+		t.Fatalf("unexpected error: %#v", verr)
+	}
+}
+
+func TestEnumVersionEncode(t *testing.T) {
+	client, server := newPair(t)
+
+	s := state{conn: client}
+	widget := testc.NewWidget(&s)
+	widget.SetID(3)
+	widget.SetVersion(1)
+
+	widget.SetMode(testc.WidgetModeFancy, testc.WidgetFlagsNone)
+	assertEnumVersionError(t, s.err, "WidgetModeFancy")
+
+	widget.SetMode(testc.WidgetModePlain, testc.WidgetFlagsBold|testc.WidgetFlagsShiny)
+	assertEnumVersionError(t, s.err, "WidgetFlagsBold|WidgetFlagsShiny")
+
+	// Neither of the rejected messages was sent, so the first one to
+	// arrive is the valid one.
+	widget.SetMode(testc.WidgetModePlain, testc.WidgetFlagsBold)
+	if s.err != nil {
+		t.Fatal(s.err)
+	}
+
+	msg, err := wire.ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode, flags := msg.ReadUint(), msg.ReadUint(); (mode != 0) || (flags != 1) {
+		t.Fatalf("got mode %v and flags %v, want 0 and 1", mode, flags)
+	}
+}
+
+func TestEnumVersionDecode(t *testing.T) {
+	client, server := newPair(t)
+
+	// The client bound at version 2, but the server's object only
+	// knows version 1.
+	s := state{conn: client}
+	cwidget := testc.NewWidget(&s)
+	cwidget.SetID(3)
+	cwidget.SetVersion(2)
+
+	swidget := tests.NewWidget(nil)
+	swidget.SetID(3)
+	swidget.SetVersion(1)
+
+	var calls int
+	swidget.OnSetMode = func(tests.WidgetSetModeRequest) { calls++ }
+
+	dispatch := func(mode testc.WidgetMode, flags testc.WidgetFlags) error {
+		t.Helper()
+
+		cwidget.SetMode(mode, flags)
+		if s.err != nil {
+			t.Fatal(s.err)
+		}
+
+		msg, err := wire.ReadMessage(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return swidget.Dispatch(msg)
+	}
+
+	assertEnumVersionError(t, dispatch(testc.WidgetModeFancy, 0), "WidgetModeFancy")
+	assertEnumVersionError(t, dispatch(testc.WidgetModePlain, testc.WidgetFlagsShiny), "WidgetFlagsShiny")
+	if calls != 0 {
+		t.Fatalf("handler called %v times for rejected requests", calls)
+	}
+
+	if err := dispatch(testc.WidgetModePlain, testc.WidgetFlagsBold); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("handler called %v times, want 1", calls)
+	}
+
+	swidget.SetVersion(2)
+	if err := dispatch(testc.WidgetModeFancy, testc.WidgetFlagsShiny); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("handler called %v times, want 2", calls)
+	}
+}
+
+func TestEnumVersionUnbound(t *testing.T) {
+	client, _ := newPair(t)
+
+	// Objects that have not been given a version are not checked.
+	s := state{conn: client}
+	widget := testc.NewWidget(&s)
+	widget.SetID(3)
+
+	widget.SetMode(testc.WidgetModeFancy, testc.WidgetFlagsShiny)
+	if s.err != nil {
+		t.Fatal(s.err)
+	}
+}
+
+func TestMessageSize(t *testing.T) {
+	client, server := newPair(t)
+
+	s := state{conn: client}
+	widget := testc.NewWidget(&s)
+	widget.SetID(3)
+
+	widget.SetTitle(strings.Repeat("x", wire.MaxMessageSize))
+	var serr wire.MessageSizeError
+	if !errors.As(s.err, &serr) {
+		t.Fatalf("expected MessageSizeError, got %v", s.err)
+	}
+	if (serr.Interface != testc.WidgetInterface) || (serr.Method != "set_title") || (serr.Size <= wire.MaxMessageSize) {
+		t.Fatalf("unexpected error: %#v", serr)
+	}
+	if !errors.Is(s.err, wire.ErrMessageTooLarge) {
+		t.Fatalf("%v does not wrap ErrMessageTooLarge", s.err)
+	}
+
+	// The oversized message was not sent, so the first one to arrive is
+	// the one after it.
+	widget.SetTitle("title")
+	if s.err != nil {
+		t.Fatal(s.err)
+	}
+
+	msg, err := wire.ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title := msg.ReadString(); title != "title" {
+		t.Fatalf("got title of length %v, want %q", len(title), "title")
+	}
+}
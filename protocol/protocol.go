@@ -2,7 +2,10 @@
 // protocol-specification XML file.
 package protocol
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+)
 
 type Protocol struct {
 	Name      string `xml:"name,attr"`
@@ -27,15 +30,25 @@ type Description struct {
 }
 
 type Op struct {
-	Name        string      `xml:"name,attr"`
-	Description Description `xml:"description"`
+	Name            string      `xml:"name,attr"`
+	Type            string      `xml:"type,attr"`
+	Since           int         `xml:"since,attr"`
+	DeprecatedSince int         `xml:"deprecated-since,attr"`
+	Description     Description `xml:"description"`
 
 	Args []Arg `xml:"arg"`
 }
 
+// IsDestructor reports whether the op is marked as a destructor,
+// meaning that the object it is sent to is destroyed by it.
+func (op Op) IsDestructor() bool {
+	return op.Type == "destructor"
+}
+
 type Arg struct {
-	Name    string `xml:"name,attr"`
-	Summary string `xml:"summary,attr"`
+	Name        string      `xml:"name,attr"`
+	Summary     string      `xml:"summary,attr"`
+	Description Description `xml:"description"`
 
 	Type      string `xml:"type,attr"`
 	AllowNull bool   `xml:"allow-null,attr"`
@@ -44,17 +57,33 @@ type Arg struct {
 	Enum      string `xml:"enum,attr"`
 }
 
+// EnumRef splits the arg's enum attribute into the interface that
+// the enum belongs to and the enum's name. The interface is empty if
+// the enum is one of the interface that the arg's op belongs to.
+func (a Arg) EnumRef() (iface, name string) {
+	iface, name, ok := strings.Cut(a.Enum, ".")
+	if !ok {
+		return "", iface
+	}
+	return iface, name
+}
+
 type Enum struct {
 	Name        string      `xml:"name,attr"`
+	Bitfield    bool        `xml:"bitfield,attr"`
+	Since       int         `xml:"since,attr"`
 	Description Description `xml:"description"`
 
 	Entries []Entry `xml:"entry"`
 }
 
 type Entry struct {
-	Name    string `xml:"name,attr"`
-	Summary string `xml:"summary,attr"`
-	Value   string `xml:"value,attr"`
+	Name            string      `xml:"name,attr"`
+	Summary         string      `xml:"summary,attr"`
+	Value           string      `xml:"value,attr"`
+	Since           int         `xml:"since,attr"`
+	DeprecatedSince int         `xml:"deprecated-since,attr"`
+	Description     Description `xml:"description"`
 }
 
 func (e Entry) Int() (int, error) {
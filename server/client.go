@@ -5,8 +5,10 @@ import (
 	"errors"
 	"io"
 	"net"
+	"os"
 
 	"deedles.dev/wl/internal/debug"
+	"deedles.dev/wl/internal/limit"
 	"deedles.dev/wl/internal/objstore"
 	"deedles.dev/wl/wire"
 	"deedles.dev/xsync"
@@ -18,18 +20,24 @@ type Client struct {
 	conn   *wire.Conn
 	stop   xsync.Stopper
 	queue  xsync.Queue[func() error]
+	limit  limit.Limiter
 	store  *objstore.Store
 }
 
 func newClient(ctx context.Context, server *Server, conn *wire.Conn) *Client {
+	if debug.Server && (conn.Logger() == nil) {
+		conn.SetLogger(os.Stderr)
+	}
+
 	client := Client{
 		server: server,
 		conn:   conn,
-		store:  objstore.New(1 << 24),
+		store:  objstore.New(wire.ServerIDMin, wire.ServerIDMax),
 	}
 
 	display := NewDisplay(&client)
 	display.SetID(1)
+	display.SetVersion(DisplayVersion)
 	client.store.Add(display)
 
 	go client.listen(ctx)
@@ -54,6 +62,10 @@ func (client *Client) listen(ctx context.Context) {
 	}()
 
 	for {
+		if !client.limit.Wait(client.stop.Done()) {
+			return
+		}
+
 		msg, err := wire.ReadMessage(client.conn)
 		if err != nil {
 			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
@@ -70,13 +82,13 @@ func (client *Client) listen(ctx context.Context) {
 			}
 		}
 
+		client.limit.Add()
 		select {
 		case <-ctx.Done():
 			return
 		case <-client.stop.Done():
 			return
-		case client.queue.Push() <- func() error { return client.dispatch(msg) }:
-			// TODO: Limit number of queued incoming messages?
+		case client.queue.Push() <- client.dispatchFunc(msg):
 		}
 	}
 }
@@ -85,6 +97,27 @@ func (client *Client) dispatch(msg *wire.MessageBuffer) error {
 	return client.store.Dispatch(msg)
 }
 
+// dispatchFunc returns a function that dispatches msg and then
+// releases its slot in the queue limit.
+func (client *Client) dispatchFunc(msg *wire.MessageBuffer) func() error {
+	return func() error {
+		defer client.limit.Done()
+		return client.dispatch(msg)
+	}
+}
+
+// SetQueueLimit sets the maximum number of incoming messages that can
+// be waiting in the event queue to be dispatched. While the limit is
+// reached, no more messages are read from the connection until some
+// of the pending ones have been handled. This keeps slow handling of
+// events from causing an unbounded amount of memory to be used, with
+// the remote end eventually being blocked from sending by the
+// socket's buffer filling up instead. A limit of zero or less, the
+// default, disables the check.
+func (client *Client) SetQueueLimit(n int) {
+	client.limit.SetLimit(n)
+}
+
 // Add adds obj to client's knowledge. Do not call this method unless
 // you know what you are doing.
 func (client *Client) Add(obj wire.Object) {
@@ -104,11 +137,31 @@ func (client *Client) Delete(id uint32) {
 }
 
 // DeleteAll removes all objects from the client, running their delete
-// handlers where applicable. This is not done automatically, so if
-// you want the handlers to be run when, for example, the client
-// disconnects you must call this yourself.
+// handlers where applicable, and resets object ID allocation. This is
+// not done automatically, so if you want the handlers to be run when,
+// for example, the client disconnects you must call this yourself.
+// It is the same as Reset, but ignores errors.
 func (client *Client) DeleteAll() {
-	client.store.Clear()
+	client.Reset()
+}
+
+// Reset removes all objects from the client and resets object ID
+// allocation, the same as DeleteAll. Before each object is deleted,
+// the cleanup functions registered for it with AddCleanup are run,
+// which closes any file descriptors that it holds, such as ones
+// received in events that it had no handler for. Any errors from them
+// are returned.
+//
+// The Display is deleted along with everything else, but is then
+// added back with the same ID, as it exists for as long as the
+// connection does.
+func (client *Client) Reset() error {
+	display, _ := client.Get(1).(*Display)
+	err := client.store.Reset()
+	if display != nil {
+		client.store.Add(display)
+	}
+	return err
 }
 
 // Enqueue adds msg to the event queue.
@@ -116,7 +169,7 @@ func (client *Client) Enqueue(msg *wire.MessageBuilder) {
 	select {
 	case <-client.stop.Done():
 	case client.queue.Push() <- func() error {
-		debug.Printf(" -> %v", msg)
+		client.conn.Tracef(" -> %v", msg)
 		return msg.Build(client.conn)
 	}:
 	}
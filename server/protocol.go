@@ -1,4 +1,29 @@
-// Code generated by wlgen. DO NOT EDIT.
+// Code generated by wlgen from the wayland protocol. DO NOT EDIT.
+
+// Copyright © 2008-2011 Kristian Høgsberg
+// Copyright © 2010-2011 Intel Corporation
+// Copyright © 2012-2013 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the
+// next paragraph) shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT.  IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
 
 package wl
 
@@ -8,6 +33,37 @@ import (
 	"os"
 )
 
+// ProtocolName is the name of the protocol that this file was
+// generated from.
+const ProtocolName = "wayland"
+
+// Interfaces lists the interfaces defined by the wayland
+// protocol along with the highest version of each that is supported.
+var Interfaces = []wire.InterfaceInfo{
+	{Name: DisplayInterface, Version: DisplayVersion},
+	{Name: RegistryInterface, Version: RegistryVersion},
+	{Name: CallbackInterface, Version: CallbackVersion},
+	{Name: CompositorInterface, Version: CompositorVersion},
+	{Name: ShmPoolInterface, Version: ShmPoolVersion},
+	{Name: ShmInterface, Version: ShmVersion},
+	{Name: BufferInterface, Version: BufferVersion},
+	{Name: DataOfferInterface, Version: DataOfferVersion},
+	{Name: DataSourceInterface, Version: DataSourceVersion},
+	{Name: DataDeviceInterface, Version: DataDeviceVersion},
+	{Name: DataDeviceManagerInterface, Version: DataDeviceManagerVersion},
+	{Name: ShellInterface, Version: ShellVersion},
+	{Name: ShellSurfaceInterface, Version: ShellSurfaceVersion},
+	{Name: SurfaceInterface, Version: SurfaceVersion},
+	{Name: SeatInterface, Version: SeatVersion},
+	{Name: PointerInterface, Version: PointerVersion},
+	{Name: KeyboardInterface, Version: KeyboardVersion},
+	{Name: TouchInterface, Version: TouchVersion},
+	{Name: OutputInterface, Version: OutputVersion},
+	{Name: RegionInterface, Version: RegionVersion},
+	{Name: SubcompositorInterface, Version: SubcompositorVersion},
+	{Name: SubsurfaceInterface, Version: SubsurfaceVersion},
+}
+
 const (
 	DisplayInterface = "wl_display"
 	DisplayVersion   = 1
@@ -41,45 +97,67 @@ type DisplayListener interface {
 	GetRegistry(registry *Registry)
 }
 
+// DisplaySyncRequest holds the arguments of a wl_display.sync
+// request.
+type DisplaySyncRequest struct {
+	Callback *Callback
+}
+
+// DisplayGetRegistryRequest holds the arguments of a wl_display.get_registry
+// request.
+type DisplayGetRegistryRequest struct {
+	Registry *Registry
+}
+
 // The core global object.  This is a special singleton object.  It
 // is used for internal Wayland protocol features.
 type Display struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DisplayListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnSync, if not nil, is called with the arguments of
+	// each incoming sync request before Listener is.
+	OnSync func(DisplaySyncRequest)
 
-	state wire.State
-	id    uint32
+	// OnGetRegistry, if not nil, is called with the arguments of
+	// each incoming get_registry request before Listener is.
+	OnGetRegistry func(DisplayGetRegistryRequest)
 }
 
+var (
+	_ wire.Object      = (*Display)(nil)
+	_ wire.DebugObject = (*Display)(nil)
+)
+
 // NewDisplay returns a newly instantiated Display. It is
 // primarily intended for use by generated code.
 func NewDisplay(state wire.State) *Display {
-	return &Display{state: state}
-}
-
-func (obj *Display) State() wire.State {
-	return obj.state
+	return &Display{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Display) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		callback := NewCallback(obj.state)
+		callback := NewCallback(obj.State())
 		callback.SetID(msg.ReadUint())
-
-		obj.state.Add(callback)
+		callback.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(callback)
 
+		if obj.OnSync != nil {
+			obj.OnSync(DisplaySyncRequest{
+				Callback: callback,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -90,15 +168,20 @@ func (obj *Display) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		registry := NewRegistry(obj.state)
+		registry := NewRegistry(obj.State())
 		registry.SetID(msg.ReadUint())
-
-		obj.state.Add(registry)
+		registry.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(registry)
 
+		if obj.OnGetRegistry != nil {
+			obj.OnGetRegistry(DisplayGetRegistryRequest{
+				Registry: registry,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -115,22 +198,8 @@ func (obj *Display) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Display) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Display) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Display) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Display) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_display", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_display", obj.ID())
 }
 
 func (obj *Display) MethodName(op uint16) string {
@@ -149,7 +218,19 @@ func (obj *Display) Interface() string {
 	return DisplayInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DisplayVersion, the same as MaxVersion.
 func (obj *Display) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DisplayVersion
+}
+
+// MaxVersion returns DisplayVersion, the highest version of
+// wl_display that is supported.
+func (obj *Display) MaxVersion() uint32 {
 	return DisplayVersion
 }
 
@@ -167,9 +248,11 @@ func (obj *Display) Error(objectId uint32, code uint32, message string) {
 	builder.WriteUint(code)
 	builder.WriteString(message)
 
+	builder.Fail(wire.CheckMessageSize(DisplayInterface, "error", builder))
+
 	builder.Method = "error"
 	builder.Args = []any{objectId, code, message}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -185,7 +268,7 @@ func (obj *Display) DeleteId(id uint32) {
 
 	builder.Method = "delete_id"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -207,22 +290,25 @@ const (
 	DisplayErrorImplementation DisplayError = 3
 )
 
-func (enum DisplayError) String() string {
-	switch enum {
-	case 0:
-		return "DisplayErrorInvalidObject"
-
-	case 1:
-		return "DisplayErrorInvalidMethod"
-
-	case 2:
-		return "DisplayErrorNoMemory"
+// DisplayErrorNames maps the values of DisplayError to their names.
+var DisplayErrorNames = map[DisplayError]string{
+	DisplayErrorInvalidObject:  "DisplayErrorInvalidObject",
+	DisplayErrorInvalidMethod:  "DisplayErrorInvalidMethod",
+	DisplayErrorNoMemory:       "DisplayErrorNoMemory",
+	DisplayErrorImplementation: "DisplayErrorImplementation",
+}
 
-	case 3:
-		return "DisplayErrorImplementation"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum DisplayError) String() string {
+	return wire.EnumString(enum, DisplayErrorNames)
+}
 
-	return "<invalid DisplayError>"
+// Since returns the version of wl_display that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DisplayError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -238,6 +324,13 @@ type RegistryListener interface {
 	Bind(name uint32, id wire.NewID)
 }
 
+// RegistryBindRequest holds the arguments of a wl_registry.bind
+// request.
+type RegistryBindRequest struct {
+	Name uint32
+	Id   wire.NewID
+}
+
 // The singleton global registry object.  The server has a number of
 // global objects that are available to all clients.  These objects
 // typically represent an actual object in the server (for example,
@@ -259,27 +352,28 @@ type RegistryListener interface {
 // emit events to the client and lets the client invoke requests on
 // the object.
 type Registry struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener RegistryListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnBind, if not nil, is called with the arguments of
+	// each incoming bind request before Listener is.
+	OnBind func(RegistryBindRequest)
 }
 
+var (
+	_ wire.Object      = (*Registry)(nil)
+	_ wire.DebugObject = (*Registry)(nil)
+)
+
 // NewRegistry returns a newly instantiated Registry. It is
 // primarily intended for use by generated code.
 func NewRegistry(state wire.State) *Registry {
-	return &Registry{state: state}
-}
-
-func (obj *Registry) State() wire.State {
-	return obj.state
+	return &Registry{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Registry) Dispatch(msg *wire.MessageBuffer) error {
@@ -294,6 +388,12 @@ func (obj *Registry) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnBind != nil {
+			obj.OnBind(RegistryBindRequest{
+				Name: name,
+				Id:   id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -311,22 +411,8 @@ func (obj *Registry) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Registry) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Registry) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Registry) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Registry) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_registry", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_registry", obj.ID())
 }
 
 func (obj *Registry) MethodName(op uint16) string {
@@ -342,7 +428,19 @@ func (obj *Registry) Interface() string {
 	return RegistryInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// RegistryVersion, the same as MaxVersion.
 func (obj *Registry) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return RegistryVersion
+}
+
+// MaxVersion returns RegistryVersion, the highest version of
+// wl_registry that is supported.
+func (obj *Registry) MaxVersion() uint32 {
 	return RegistryVersion
 }
 
@@ -358,9 +456,11 @@ func (obj *Registry) Global(name uint32, _interface string, version uint32) {
 	builder.WriteString(_interface)
 	builder.WriteUint(version)
 
+	builder.Fail(wire.CheckMessageSize(RegistryInterface, "global", builder))
+
 	builder.Method = "global"
 	builder.Args = []any{name, _interface, version}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -381,7 +481,7 @@ func (obj *Registry) GlobalRemove(name uint32) {
 
 	builder.Method = "global_remove"
 	builder.Args = []any{name}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -393,23 +493,18 @@ const (
 // Clients can handle the 'done' event to get notified when
 // the related request is done.
 type Callback struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*Callback)(nil)
+	_ wire.DebugObject = (*Callback)(nil)
+)
+
 // NewCallback returns a newly instantiated Callback. It is
 // primarily intended for use by generated code.
 func NewCallback(state wire.State) *Callback {
-	return &Callback{state: state}
-}
-
-func (obj *Callback) State() wire.State {
-	return obj.state
+	return &Callback{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Callback) Dispatch(msg *wire.MessageBuffer) error {
@@ -421,22 +516,8 @@ func (obj *Callback) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Callback) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Callback) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Callback) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Callback) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_callback", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_callback", obj.ID())
 }
 
 func (obj *Callback) MethodName(op uint16) string {
@@ -450,7 +531,19 @@ func (obj *Callback) Interface() string {
 	return CallbackInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// CallbackVersion, the same as MaxVersion.
 func (obj *Callback) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return CallbackVersion
+}
+
+// MaxVersion returns CallbackVersion, the highest version of
+// wl_callback that is supported.
+func (obj *Callback) MaxVersion() uint32 {
 	return CallbackVersion
 }
 
@@ -462,7 +555,7 @@ func (obj *Callback) Done(callbackData uint32) {
 
 	builder.Method = "done"
 	builder.Args = []any{callbackData}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -481,53 +574,84 @@ type CompositorListener interface {
 	CreateRegion(id *Region)
 }
 
+// CompositorCreateSurfaceRequest holds the arguments of a wl_compositor.create_surface
+// request.
+type CompositorCreateSurfaceRequest struct {
+	Id *Surface
+}
+
+// CompositorCreateRegionRequest holds the arguments of a wl_compositor.create_region
+// request.
+type CompositorCreateRegionRequest struct {
+	Id *Region
+}
+
 // A compositor.  This object is a singleton global.  The
 // compositor is in charge of combining the contents of multiple
 // surfaces into one displayable output.
 type Compositor struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener CompositorListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnCreateSurface, if not nil, is called with the arguments of
+	// each incoming create_surface request before Listener is.
+	OnCreateSurface func(CompositorCreateSurfaceRequest)
 
-	state wire.State
-	id    uint32
+	// OnCreateRegion, if not nil, is called with the arguments of
+	// each incoming create_region request before Listener is.
+	OnCreateRegion func(CompositorCreateRegionRequest)
 }
 
+var (
+	_ wire.Object      = (*Compositor)(nil)
+	_ wire.DebugObject = (*Compositor)(nil)
+)
+
 // NewCompositor returns a newly instantiated Compositor. It is
 // primarily intended for use by generated code.
 func NewCompositor(state wire.State) *Compositor {
-	return &Compositor{state: state}
+	return &Compositor{Proxy: wire.NewProxy(state)}
 }
 
-func BindCompositor(state wire.State, id wire.NewID) *Compositor {
+// BindCompositor creates a new Compositor for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindCompositor(state wire.State, id wire.NewID) (*Compositor, error) {
+	if err := id.Check(CompositorInterface, CompositorVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewCompositor(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *Compositor) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *Compositor) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		id := NewSurface(obj.state)
+		id := NewSurface(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnCreateSurface != nil {
+			obj.OnCreateSurface(CompositorCreateSurfaceRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -538,15 +662,20 @@ func (obj *Compositor) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		id := NewRegion(obj.state)
+		id := NewRegion(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnCreateRegion != nil {
+			obj.OnCreateRegion(CompositorCreateRegionRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -563,22 +692,8 @@ func (obj *Compositor) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Compositor) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Compositor) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Compositor) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Compositor) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_compositor", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_compositor", obj.ID())
 }
 
 func (obj *Compositor) MethodName(op uint16) string {
@@ -597,7 +712,19 @@ func (obj *Compositor) Interface() string {
 	return CompositorInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// CompositorVersion, the same as MaxVersion.
 func (obj *Compositor) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return CompositorVersion
+}
+
+// MaxVersion returns CompositorVersion, the highest version of
+// wl_compositor that is supported.
+func (obj *Compositor) MaxVersion() uint32 {
 	return CompositorVersion
 }
 
@@ -636,6 +763,28 @@ type ShmPoolListener interface {
 	Resize(size int32)
 }
 
+// ShmPoolCreateBufferRequest holds the arguments of a wl_shm_pool.create_buffer
+// request.
+type ShmPoolCreateBufferRequest struct {
+	Id     *Buffer
+	Offset int32
+	Width  int32
+	Height int32
+	Stride int32
+	Format ShmFormat
+}
+
+// ShmPoolDestroyRequest holds the arguments of a wl_shm_pool.destroy
+// request.
+type ShmPoolDestroyRequest struct {
+}
+
+// ShmPoolResizeRequest holds the arguments of a wl_shm_pool.resize
+// request.
+type ShmPoolResizeRequest struct {
+	Size int32
+}
+
 // The wl_shm_pool object encapsulates a piece of memory shared
 // between the compositor and client.  Through the wl_shm_pool
 // object, the client can allocate shared memory wl_buffer objects.
@@ -644,37 +793,45 @@ type ShmPoolListener interface {
 // setup/teardown overhead and is useful when interactively resizing
 // a surface or for many small buffers.
 type ShmPool struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ShmPoolListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnCreateBuffer, if not nil, is called with the arguments of
+	// each incoming create_buffer request before Listener is.
+	OnCreateBuffer func(ShmPoolCreateBufferRequest)
+
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(ShmPoolDestroyRequest)
 
-	state wire.State
-	id    uint32
+	// OnResize, if not nil, is called with the arguments of
+	// each incoming resize request before Listener is.
+	OnResize func(ShmPoolResizeRequest)
 }
 
+var (
+	_ wire.Object      = (*ShmPool)(nil)
+	_ wire.DebugObject = (*ShmPool)(nil)
+)
+
 // NewShmPool returns a newly instantiated ShmPool. It is
 // primarily intended for use by generated code.
 func NewShmPool(state wire.State) *ShmPool {
-	return &ShmPool{state: state}
-}
-
-func (obj *ShmPool) State() wire.State {
-	return obj.state
+	return &ShmPool{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *ShmPool) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		id := NewBuffer(obj.state)
+		id := NewBuffer(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		offset := msg.ReadInt()
 
@@ -689,7 +846,18 @@ func (obj *ShmPool) Dispatch(msg *wire.MessageBuffer) error {
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnCreateBuffer != nil {
+			obj.OnCreateBuffer(ShmPoolCreateBufferRequest{
+				Id:     id,
+				Offset: offset,
+				Width:  width,
+				Height: height,
+				Stride: stride,
+				Format: format,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -708,6 +876,9 @@ func (obj *ShmPool) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(ShmPoolDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -722,6 +893,11 @@ func (obj *ShmPool) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnResize != nil {
+			obj.OnResize(ShmPoolResizeRequest{
+				Size: size,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -738,22 +914,8 @@ func (obj *ShmPool) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *ShmPool) ID() uint32 {
-	return obj.id
-}
-
-func (obj *ShmPool) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *ShmPool) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *ShmPool) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shm_pool", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shm_pool", obj.ID())
 }
 
 func (obj *ShmPool) MethodName(op uint16) string {
@@ -775,7 +937,19 @@ func (obj *ShmPool) Interface() string {
 	return ShmPoolInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShmPoolVersion, the same as MaxVersion.
 func (obj *ShmPool) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShmPoolVersion
+}
+
+// MaxVersion returns ShmPoolVersion, the highest version of
+// wl_shm_pool that is supported.
+func (obj *ShmPool) MaxVersion() uint32 {
 	return ShmPoolVersion
 }
 
@@ -795,6 +969,14 @@ type ShmListener interface {
 	CreatePool(id *ShmPool, fd *os.File, size int32)
 }
 
+// ShmCreatePoolRequest holds the arguments of a wl_shm.create_pool
+// request.
+type ShmCreatePoolRequest struct {
+	Id   *ShmPool
+	Fd   *os.File
+	Size int32
+}
+
 // A singleton global object that provides support for shared
 // memory.
 //
@@ -805,44 +987,56 @@ type ShmListener interface {
 // format events to inform clients about the valid pixel formats
 // that can be used for buffers.
 type Shm struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ShmListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnCreatePool, if not nil, is called with the arguments of
+	// each incoming create_pool request before Listener is.
+	OnCreatePool func(ShmCreatePoolRequest)
 }
 
+var (
+	_ wire.Object      = (*Shm)(nil)
+	_ wire.DebugObject = (*Shm)(nil)
+)
+
 // NewShm returns a newly instantiated Shm. It is
 // primarily intended for use by generated code.
 func NewShm(state wire.State) *Shm {
-	return &Shm{state: state}
+	return &Shm{Proxy: wire.NewProxy(state)}
 }
 
-func BindShm(state wire.State, id wire.NewID) *Shm {
+// BindShm creates a new Shm for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindShm(state wire.State, id wire.NewID) (*Shm, error) {
+	if err := id.Check(ShmInterface, ShmVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewShm(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *Shm) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *Shm) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
+		if have := msg.RemainingFDs(); have < 1 {
+			return wire.MissingFDsError{Interface: "wl_shm", Method: "create_pool", Want: 1, Have: have}
+		}
 
-		id := NewShmPool(obj.state)
+		id := NewShmPool(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		fd := msg.ReadFile()
 
@@ -851,7 +1045,20 @@ func (obj *Shm) Dispatch(msg *wire.MessageBuffer) error {
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if (obj.OnCreatePool == nil) && (obj.Listener == nil) {
+			obj.AddCleanup(fd.Close)
+			return nil
+		}
+
+		if obj.OnCreatePool != nil {
+			obj.OnCreatePool(ShmCreatePoolRequest{
+				Id:   id,
+				Fd:   fd,
+				Size: size,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -870,22 +1077,8 @@ func (obj *Shm) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Shm) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Shm) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Shm) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Shm) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shm", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shm", obj.ID())
 }
 
 func (obj *Shm) MethodName(op uint16) string {
@@ -901,7 +1094,19 @@ func (obj *Shm) Interface() string {
 	return ShmInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShmVersion, the same as MaxVersion.
 func (obj *Shm) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShmVersion
+}
+
+// MaxVersion returns ShmVersion, the highest version of
+// wl_shm that is supported.
+func (obj *Shm) MaxVersion() uint32 {
 	return ShmVersion
 }
 
@@ -915,7 +1120,7 @@ func (obj *Shm) Format(format ShmFormat) {
 
 	builder.Method = "format"
 	builder.Args = []any{format}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -933,19 +1138,24 @@ const (
 	ShmErrorInvalidFd ShmError = 2
 )
 
-func (enum ShmError) String() string {
-	switch enum {
-	case 0:
-		return "ShmErrorInvalidFormat"
-
-	case 1:
-		return "ShmErrorInvalidStride"
+// ShmErrorNames maps the values of ShmError to their names.
+var ShmErrorNames = map[ShmError]string{
+	ShmErrorInvalidFormat: "ShmErrorInvalidFormat",
+	ShmErrorInvalidStride: "ShmErrorInvalidStride",
+	ShmErrorInvalidFd:     "ShmErrorInvalidFd",
+}
 
-	case 2:
-		return "ShmErrorInvalidFd"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ShmError) String() string {
+	return wire.EnumString(enum, ShmErrorNames)
+}
 
-	return "<invalid ShmError>"
+// Since returns the version of wl_shm that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShmError) Since() uint32 {
+	return 1
 }
 
 // This describes the memory layout of an individual pixel.
@@ -1261,322 +1471,125 @@ const (
 	ShmFormatQ401 ShmFormat = 825242705
 )
 
+// ShmFormatNames maps the values of ShmFormat to their names.
+var ShmFormatNames = map[ShmFormat]string{
+	ShmFormatArgb8888:             "ShmFormatArgb8888",
+	ShmFormatXrgb8888:             "ShmFormatXrgb8888",
+	ShmFormatC8:                   "ShmFormatC8",
+	ShmFormatRgb332:               "ShmFormatRgb332",
+	ShmFormatBgr233:               "ShmFormatBgr233",
+	ShmFormatXrgb4444:             "ShmFormatXrgb4444",
+	ShmFormatXbgr4444:             "ShmFormatXbgr4444",
+	ShmFormatRgbx4444:             "ShmFormatRgbx4444",
+	ShmFormatBgrx4444:             "ShmFormatBgrx4444",
+	ShmFormatArgb4444:             "ShmFormatArgb4444",
+	ShmFormatAbgr4444:             "ShmFormatAbgr4444",
+	ShmFormatRgba4444:             "ShmFormatRgba4444",
+	ShmFormatBgra4444:             "ShmFormatBgra4444",
+	ShmFormatXrgb1555:             "ShmFormatXrgb1555",
+	ShmFormatXbgr1555:             "ShmFormatXbgr1555",
+	ShmFormatRgbx5551:             "ShmFormatRgbx5551",
+	ShmFormatBgrx5551:             "ShmFormatBgrx5551",
+	ShmFormatArgb1555:             "ShmFormatArgb1555",
+	ShmFormatAbgr1555:             "ShmFormatAbgr1555",
+	ShmFormatRgba5551:             "ShmFormatRgba5551",
+	ShmFormatBgra5551:             "ShmFormatBgra5551",
+	ShmFormatRgb565:               "ShmFormatRgb565",
+	ShmFormatBgr565:               "ShmFormatBgr565",
+	ShmFormatRgb888:               "ShmFormatRgb888",
+	ShmFormatBgr888:               "ShmFormatBgr888",
+	ShmFormatXbgr8888:             "ShmFormatXbgr8888",
+	ShmFormatRgbx8888:             "ShmFormatRgbx8888",
+	ShmFormatBgrx8888:             "ShmFormatBgrx8888",
+	ShmFormatAbgr8888:             "ShmFormatAbgr8888",
+	ShmFormatRgba8888:             "ShmFormatRgba8888",
+	ShmFormatBgra8888:             "ShmFormatBgra8888",
+	ShmFormatXrgb2101010:          "ShmFormatXrgb2101010",
+	ShmFormatXbgr2101010:          "ShmFormatXbgr2101010",
+	ShmFormatRgbx1010102:          "ShmFormatRgbx1010102",
+	ShmFormatBgrx1010102:          "ShmFormatBgrx1010102",
+	ShmFormatArgb2101010:          "ShmFormatArgb2101010",
+	ShmFormatAbgr2101010:          "ShmFormatAbgr2101010",
+	ShmFormatRgba1010102:          "ShmFormatRgba1010102",
+	ShmFormatBgra1010102:          "ShmFormatBgra1010102",
+	ShmFormatYuyv:                 "ShmFormatYuyv",
+	ShmFormatYvyu:                 "ShmFormatYvyu",
+	ShmFormatUyvy:                 "ShmFormatUyvy",
+	ShmFormatVyuy:                 "ShmFormatVyuy",
+	ShmFormatAyuv:                 "ShmFormatAyuv",
+	ShmFormatNv12:                 "ShmFormatNv12",
+	ShmFormatNv21:                 "ShmFormatNv21",
+	ShmFormatNv16:                 "ShmFormatNv16",
+	ShmFormatNv61:                 "ShmFormatNv61",
+	ShmFormatYuv410:               "ShmFormatYuv410",
+	ShmFormatYvu410:               "ShmFormatYvu410",
+	ShmFormatYuv411:               "ShmFormatYuv411",
+	ShmFormatYvu411:               "ShmFormatYvu411",
+	ShmFormatYuv420:               "ShmFormatYuv420",
+	ShmFormatYvu420:               "ShmFormatYvu420",
+	ShmFormatYuv422:               "ShmFormatYuv422",
+	ShmFormatYvu422:               "ShmFormatYvu422",
+	ShmFormatYuv444:               "ShmFormatYuv444",
+	ShmFormatYvu444:               "ShmFormatYvu444",
+	ShmFormatR8:                   "ShmFormatR8",
+	ShmFormatR16:                  "ShmFormatR16",
+	ShmFormatRg88:                 "ShmFormatRg88",
+	ShmFormatGr88:                 "ShmFormatGr88",
+	ShmFormatRg1616:               "ShmFormatRg1616",
+	ShmFormatGr1616:               "ShmFormatGr1616",
+	ShmFormatXrgb16161616f:        "ShmFormatXrgb16161616f",
+	ShmFormatXbgr16161616f:        "ShmFormatXbgr16161616f",
+	ShmFormatArgb16161616f:        "ShmFormatArgb16161616f",
+	ShmFormatAbgr16161616f:        "ShmFormatAbgr16161616f",
+	ShmFormatXyuv8888:             "ShmFormatXyuv8888",
+	ShmFormatVuy888:               "ShmFormatVuy888",
+	ShmFormatVuy101010:            "ShmFormatVuy101010",
+	ShmFormatY210:                 "ShmFormatY210",
+	ShmFormatY212:                 "ShmFormatY212",
+	ShmFormatY216:                 "ShmFormatY216",
+	ShmFormatY410:                 "ShmFormatY410",
+	ShmFormatY412:                 "ShmFormatY412",
+	ShmFormatY416:                 "ShmFormatY416",
+	ShmFormatXvyu2101010:          "ShmFormatXvyu2101010",
+	ShmFormatXvyu1216161616:       "ShmFormatXvyu1216161616",
+	ShmFormatXvyu16161616:         "ShmFormatXvyu16161616",
+	ShmFormatY0l0:                 "ShmFormatY0l0",
+	ShmFormatX0l0:                 "ShmFormatX0l0",
+	ShmFormatY0l2:                 "ShmFormatY0l2",
+	ShmFormatX0l2:                 "ShmFormatX0l2",
+	ShmFormatYuv4208bit:           "ShmFormatYuv4208bit",
+	ShmFormatYuv42010bit:          "ShmFormatYuv42010bit",
+	ShmFormatXrgb8888A8:           "ShmFormatXrgb8888A8",
+	ShmFormatXbgr8888A8:           "ShmFormatXbgr8888A8",
+	ShmFormatRgbx8888A8:           "ShmFormatRgbx8888A8",
+	ShmFormatBgrx8888A8:           "ShmFormatBgrx8888A8",
+	ShmFormatRgb888A8:             "ShmFormatRgb888A8",
+	ShmFormatBgr888A8:             "ShmFormatBgr888A8",
+	ShmFormatRgb565A8:             "ShmFormatRgb565A8",
+	ShmFormatBgr565A8:             "ShmFormatBgr565A8",
+	ShmFormatNv24:                 "ShmFormatNv24",
+	ShmFormatNv42:                 "ShmFormatNv42",
+	ShmFormatP210:                 "ShmFormatP210",
+	ShmFormatP010:                 "ShmFormatP010",
+	ShmFormatP012:                 "ShmFormatP012",
+	ShmFormatP016:                 "ShmFormatP016",
+	ShmFormatAxbxgxrx106106106106: "ShmFormatAxbxgxrx106106106106",
+	ShmFormatNv15:                 "ShmFormatNv15",
+	ShmFormatQ410:                 "ShmFormatQ410",
+	ShmFormatQ401:                 "ShmFormatQ401",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum ShmFormat) String() string {
-	switch enum {
-	case 0:
-		return "ShmFormatArgb8888"
-
-	case 1:
-		return "ShmFormatXrgb8888"
-
-	case 538982467:
-		return "ShmFormatC8"
-
-	case 943867730:
-		return "ShmFormatRgb332"
-
-	case 944916290:
-		return "ShmFormatBgr233"
-
-	case 842093144:
-		return "ShmFormatXrgb4444"
-
-	case 842089048:
-		return "ShmFormatXbgr4444"
-
-	case 842094674:
-		return "ShmFormatRgbx4444"
-
-	case 842094658:
-		return "ShmFormatBgrx4444"
-
-	case 842093121:
-		return "ShmFormatArgb4444"
-
-	case 842089025:
-		return "ShmFormatAbgr4444"
-
-	case 842088786:
-		return "ShmFormatRgba4444"
-
-	case 842088770:
-		return "ShmFormatBgra4444"
-
-	case 892424792:
-		return "ShmFormatXrgb1555"
-
-	case 892420696:
-		return "ShmFormatXbgr1555"
-
-	case 892426322:
-		return "ShmFormatRgbx5551"
-
-	case 892426306:
-		return "ShmFormatBgrx5551"
-
-	case 892424769:
-		return "ShmFormatArgb1555"
-
-	case 892420673:
-		return "ShmFormatAbgr1555"
-
-	case 892420434:
-		return "ShmFormatRgba5551"
-
-	case 892420418:
-		return "ShmFormatBgra5551"
-
-	case 909199186:
-		return "ShmFormatRgb565"
-
-	case 909199170:
-		return "ShmFormatBgr565"
-
-	case 875710290:
-		return "ShmFormatRgb888"
-
-	case 875710274:
-		return "ShmFormatBgr888"
-
-	case 875709016:
-		return "ShmFormatXbgr8888"
-
-	case 875714642:
-		return "ShmFormatRgbx8888"
-
-	case 875714626:
-		return "ShmFormatBgrx8888"
-
-	case 875708993:
-		return "ShmFormatAbgr8888"
-
-	case 875708754:
-		return "ShmFormatRgba8888"
-
-	case 875708738:
-		return "ShmFormatBgra8888"
-
-	case 808669784:
-		return "ShmFormatXrgb2101010"
-
-	case 808665688:
-		return "ShmFormatXbgr2101010"
-
-	case 808671314:
-		return "ShmFormatRgbx1010102"
-
-	case 808671298:
-		return "ShmFormatBgrx1010102"
-
-	case 808669761:
-		return "ShmFormatArgb2101010"
-
-	case 808665665:
-		return "ShmFormatAbgr2101010"
-
-	case 808665426:
-		return "ShmFormatRgba1010102"
-
-	case 808665410:
-		return "ShmFormatBgra1010102"
-
-	case 1448695129:
-		return "ShmFormatYuyv"
-
-	case 1431918169:
-		return "ShmFormatYvyu"
-
-	case 1498831189:
-		return "ShmFormatUyvy"
-
-	case 1498765654:
-		return "ShmFormatVyuy"
-
-	case 1448433985:
-		return "ShmFormatAyuv"
-
-	case 842094158:
-		return "ShmFormatNv12"
-
-	case 825382478:
-		return "ShmFormatNv21"
-
-	case 909203022:
-		return "ShmFormatNv16"
-
-	case 825644622:
-		return "ShmFormatNv61"
-
-	case 961959257:
-		return "ShmFormatYuv410"
-
-	case 961893977:
-		return "ShmFormatYvu410"
-
-	case 825316697:
-		return "ShmFormatYuv411"
-
-	case 825316953:
-		return "ShmFormatYvu411"
-
-	case 842093913:
-		return "ShmFormatYuv420"
-
-	case 842094169:
-		return "ShmFormatYvu420"
-
-	case 909202777:
-		return "ShmFormatYuv422"
-
-	case 909203033:
-		return "ShmFormatYvu422"
-
-	case 875713881:
-		return "ShmFormatYuv444"
-
-	case 875714137:
-		return "ShmFormatYvu444"
-
-	case 538982482:
-		return "ShmFormatR8"
-
-	case 540422482:
-		return "ShmFormatR16"
-
-	case 943212370:
-		return "ShmFormatRg88"
-
-	case 943215175:
-		return "ShmFormatGr88"
-
-	case 842221394:
-		return "ShmFormatRg1616"
-
-	case 842224199:
-		return "ShmFormatGr1616"
-
-	case 1211388504:
-		return "ShmFormatXrgb16161616f"
-
-	case 1211384408:
-		return "ShmFormatXbgr16161616f"
-
-	case 1211388481:
-		return "ShmFormatArgb16161616f"
-
-	case 1211384385:
-		return "ShmFormatAbgr16161616f"
-
-	case 1448434008:
-		return "ShmFormatXyuv8888"
-
-	case 875713878:
-		return "ShmFormatVuy888"
-
-	case 808670550:
-		return "ShmFormatVuy101010"
-
-	case 808530521:
-		return "ShmFormatY210"
-
-	case 842084953:
-		return "ShmFormatY212"
-
-	case 909193817:
-		return "ShmFormatY216"
-
-	case 808531033:
-		return "ShmFormatY410"
-
-	case 842085465:
-		return "ShmFormatY412"
-
-	case 909194329:
-		return "ShmFormatY416"
-
-	case 808670808:
-		return "ShmFormatXvyu2101010"
-
-	case 909334104:
-		return "ShmFormatXvyu1216161616"
-
-	case 942954072:
-		return "ShmFormatXvyu16161616"
-
-	case 810299481:
-		return "ShmFormatY0l0"
-
-	case 810299480:
-		return "ShmFormatX0l0"
-
-	case 843853913:
-		return "ShmFormatY0l2"
-
-	case 843853912:
-		return "ShmFormatX0l2"
-
-	case 942691673:
-		return "ShmFormatYuv4208bit"
-
-	case 808539481:
-		return "ShmFormatYuv42010bit"
-
-	case 943805016:
-		return "ShmFormatXrgb8888A8"
-
-	case 943800920:
-		return "ShmFormatXbgr8888A8"
-
-	case 943806546:
-		return "ShmFormatRgbx8888A8"
-
-	case 943806530:
-		return "ShmFormatBgrx8888A8"
-
-	case 943798354:
-		return "ShmFormatRgb888A8"
-
-	case 943798338:
-		return "ShmFormatBgr888A8"
-
-	case 943797586:
-		return "ShmFormatRgb565A8"
-
-	case 943797570:
-		return "ShmFormatBgr565A8"
-
-	case 875714126:
-		return "ShmFormatNv24"
-
-	case 842290766:
-		return "ShmFormatNv42"
-
-	case 808530512:
-		return "ShmFormatP210"
-
-	case 808530000:
-		return "ShmFormatP010"
-
-	case 842084432:
-		return "ShmFormatP012"
-
-	case 909193296:
-		return "ShmFormatP016"
-
-	case 808534593:
-		return "ShmFormatAxbxgxrx106106106106"
-
-	case 892425806:
-		return "ShmFormatNv15"
-
-	case 808531025:
-		return "ShmFormatQ410"
-
-	case 825242705:
-		return "ShmFormatQ401"
-	}
+	return wire.EnumString(enum, ShmFormatNames)
+}
 
-	return "<invalid ShmFormat>"
+// Since returns the version of wl_shm that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShmFormat) Since() uint32 {
+	return 1
 }
 
 const (
@@ -1594,33 +1607,39 @@ type BufferListener interface {
 	Destroy()
 }
 
+// BufferDestroyRequest holds the arguments of a wl_buffer.destroy
+// request.
+type BufferDestroyRequest struct {
+}
+
 // A buffer provides the content for a wl_surface. Buffers are
 // created through factory interfaces such as wl_drm, wl_shm or
 // similar. It has a width and a height and can be attached to a
 // wl_surface, but the mechanism by which a client provides and
 // updates the contents is defined by the buffer factory interface.
 type Buffer struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener BufferListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(BufferDestroyRequest)
 }
 
+var (
+	_ wire.Object      = (*Buffer)(nil)
+	_ wire.DebugObject = (*Buffer)(nil)
+)
+
 // NewBuffer returns a newly instantiated Buffer. It is
 // primarily intended for use by generated code.
 func NewBuffer(state wire.State) *Buffer {
-	return &Buffer{state: state}
-}
-
-func (obj *Buffer) State() wire.State {
-	return obj.state
+	return &Buffer{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Buffer) Dispatch(msg *wire.MessageBuffer) error {
@@ -1630,6 +1649,9 @@ func (obj *Buffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(BufferDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1644,22 +1666,8 @@ func (obj *Buffer) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Buffer) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Buffer) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Buffer) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Buffer) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_buffer", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_buffer", obj.ID())
 }
 
 func (obj *Buffer) MethodName(op uint16) string {
@@ -1675,7 +1683,19 @@ func (obj *Buffer) Interface() string {
 	return BufferInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// BufferVersion, the same as MaxVersion.
 func (obj *Buffer) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return BufferVersion
+}
+
+// MaxVersion returns BufferVersion, the highest version of
+// wl_buffer that is supported.
+func (obj *Buffer) MaxVersion() uint32 {
 	return BufferVersion
 }
 
@@ -1696,7 +1716,7 @@ func (obj *Buffer) Release() {
 
 	builder.Method = "release"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1722,7 +1742,7 @@ type DataOfferListener interface {
 	// will be cancelled and the corresponding drag source will receive
 	// wl_data_source.cancelled. Clients may still use this event in
 	// conjunction with wl_data_source.action for feedback.
-	Accept(serial uint32, mimeType string)
+	Accept(serial uint32, mimeType *string)
 
 	// To transfer the offered data, the client issues this request
 	// and indicates the mime type it wants to receive.  The transfer
@@ -1794,6 +1814,37 @@ type DataOfferListener interface {
 	SetActions(dndActions DataDeviceManagerDndAction, preferredAction DataDeviceManagerDndAction)
 }
 
+// DataOfferAcceptRequest holds the arguments of a wl_data_offer.accept
+// request.
+type DataOfferAcceptRequest struct {
+	Serial   uint32
+	MimeType *string
+}
+
+// DataOfferReceiveRequest holds the arguments of a wl_data_offer.receive
+// request.
+type DataOfferReceiveRequest struct {
+	MimeType string
+	Fd       *os.File
+}
+
+// DataOfferDestroyRequest holds the arguments of a wl_data_offer.destroy
+// request.
+type DataOfferDestroyRequest struct {
+}
+
+// DataOfferFinishRequest holds the arguments of a wl_data_offer.finish
+// request.
+type DataOfferFinishRequest struct {
+}
+
+// DataOfferSetActionsRequest holds the arguments of a wl_data_offer.set_actions
+// request.
+type DataOfferSetActionsRequest struct {
+	DndActions      DataDeviceManagerDndAction
+	PreferredAction DataDeviceManagerDndAction
+}
+
 // A wl_data_offer represents a piece of data offered for transfer
 // by another client (the source client).  It is used by the
 // copy-and-paste and drag-and-drop mechanisms.  The offer
@@ -1801,27 +1852,44 @@ type DataOfferListener interface {
 // converted to and provides the mechanism for transferring the
 // data directly from the source client.
 type DataOffer struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DataOfferListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnAccept, if not nil, is called with the arguments of
+	// each incoming accept request before Listener is.
+	OnAccept func(DataOfferAcceptRequest)
+
+	// OnReceive, if not nil, is called with the arguments of
+	// each incoming receive request before Listener is.
+	OnReceive func(DataOfferReceiveRequest)
 
-	state wire.State
-	id    uint32
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(DataOfferDestroyRequest)
+
+	// OnFinish, if not nil, is called with the arguments of
+	// each incoming finish request before Listener is.
+	OnFinish func(DataOfferFinishRequest)
+
+	// OnSetActions, if not nil, is called with the arguments of
+	// each incoming set_actions request before Listener is.
+	OnSetActions func(DataOfferSetActionsRequest)
 }
 
+var (
+	_ wire.Object      = (*DataOffer)(nil)
+	_ wire.DebugObject = (*DataOffer)(nil)
+)
+
 // NewDataOffer returns a newly instantiated DataOffer. It is
 // primarily intended for use by generated code.
 func NewDataOffer(state wire.State) *DataOffer {
-	return &DataOffer{state: state}
-}
-
-func (obj *DataOffer) State() wire.State {
-	return obj.state
+	return &DataOffer{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
@@ -1830,12 +1898,18 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 
 		serial := msg.ReadUint()
 
-		mimeType := msg.ReadString()
+		mimeType := msg.ReadNullableString()
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnAccept != nil {
+			obj.OnAccept(DataOfferAcceptRequest{
+				Serial:   serial,
+				MimeType: mimeType,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1846,6 +1920,9 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 		return nil
 
 	case 1:
+		if have := msg.RemainingFDs(); have < 1 {
+			return wire.MissingFDsError{Interface: "wl_data_offer", Method: "receive", Want: 1, Have: have}
+		}
 
 		mimeType := msg.ReadString()
 
@@ -1855,6 +1932,17 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if (obj.OnReceive == nil) && (obj.Listener == nil) {
+			obj.AddCleanup(fd.Close)
+			return nil
+		}
+
+		if obj.OnReceive != nil {
+			obj.OnReceive(DataOfferReceiveRequest{
+				MimeType: mimeType,
+				Fd:       fd,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1869,6 +1957,9 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(DataOfferDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1880,6 +1971,9 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnFinish != nil {
+			obj.OnFinish(DataOfferFinishRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1896,6 +1990,12 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetActions != nil {
+			obj.OnSetActions(DataOfferSetActionsRequest{
+				DndActions:      dndActions,
+				PreferredAction: preferredAction,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1913,22 +2013,8 @@ func (obj *DataOffer) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataOffer) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataOffer) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataOffer) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataOffer) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_offer", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_offer", obj.ID())
 }
 
 func (obj *DataOffer) MethodName(op uint16) string {
@@ -1956,7 +2042,19 @@ func (obj *DataOffer) Interface() string {
 	return DataOfferInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataOfferVersion, the same as MaxVersion.
 func (obj *DataOffer) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataOfferVersion
+}
+
+// MaxVersion returns DataOfferVersion, the highest version of
+// wl_data_offer that is supported.
+func (obj *DataOffer) MaxVersion() uint32 {
 	return DataOfferVersion
 }
 
@@ -1967,9 +2065,11 @@ func (obj *DataOffer) Offer(mimeType string) {
 
 	builder.WriteString(mimeType)
 
+	builder.Fail(wire.CheckMessageSize(DataOfferInterface, "offer", builder))
+
 	builder.Method = "offer"
 	builder.Args = []any{mimeType}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1983,7 +2083,7 @@ func (obj *DataOffer) SourceActions(sourceActions DataDeviceManagerDndAction) {
 
 	builder.Method = "source_actions"
 	builder.Args = []any{sourceActions}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2029,7 +2129,7 @@ func (obj *DataOffer) Action(dndAction DataDeviceManagerDndAction) {
 
 	builder.Method = "action"
 	builder.Args = []any{dndAction}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2049,22 +2149,25 @@ const (
 	DataOfferErrorInvalidOffer DataOfferError = 3
 )
 
-func (enum DataOfferError) String() string {
-	switch enum {
-	case 0:
-		return "DataOfferErrorInvalidFinish"
-
-	case 1:
-		return "DataOfferErrorInvalidActionMask"
-
-	case 2:
-		return "DataOfferErrorInvalidAction"
+// DataOfferErrorNames maps the values of DataOfferError to their names.
+var DataOfferErrorNames = map[DataOfferError]string{
+	DataOfferErrorInvalidFinish:     "DataOfferErrorInvalidFinish",
+	DataOfferErrorInvalidActionMask: "DataOfferErrorInvalidActionMask",
+	DataOfferErrorInvalidAction:     "DataOfferErrorInvalidAction",
+	DataOfferErrorInvalidOffer:      "DataOfferErrorInvalidOffer",
+}
 
-	case 3:
-		return "DataOfferErrorInvalidOffer"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum DataOfferError) String() string {
+	return wire.EnumString(enum, DataOfferErrorNames)
+}
 
-	return "<invalid DataOfferError>"
+// Since returns the version of wl_data_offer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataOfferError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2099,32 +2202,58 @@ type DataSourceListener interface {
 	SetActions(dndActions DataDeviceManagerDndAction)
 }
 
+// DataSourceOfferRequest holds the arguments of a wl_data_source.offer
+// request.
+type DataSourceOfferRequest struct {
+	MimeType string
+}
+
+// DataSourceDestroyRequest holds the arguments of a wl_data_source.destroy
+// request.
+type DataSourceDestroyRequest struct {
+}
+
+// DataSourceSetActionsRequest holds the arguments of a wl_data_source.set_actions
+// request.
+type DataSourceSetActionsRequest struct {
+	DndActions DataDeviceManagerDndAction
+}
+
 // The wl_data_source object is the source side of a wl_data_offer.
 // It is created by the source client in a data transfer and
 // provides a way to describe the offered data and a way to respond
 // to requests to transfer the data.
 type DataSource struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DataSourceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnOffer, if not nil, is called with the arguments of
+	// each incoming offer request before Listener is.
+	OnOffer func(DataSourceOfferRequest)
 
-	state wire.State
-	id    uint32
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(DataSourceDestroyRequest)
+
+	// OnSetActions, if not nil, is called with the arguments of
+	// each incoming set_actions request before Listener is.
+	OnSetActions func(DataSourceSetActionsRequest)
 }
 
+var (
+	_ wire.Object      = (*DataSource)(nil)
+	_ wire.DebugObject = (*DataSource)(nil)
+)
+
 // NewDataSource returns a newly instantiated DataSource. It is
 // primarily intended for use by generated code.
 func NewDataSource(state wire.State) *DataSource {
-	return &DataSource{state: state}
-}
-
-func (obj *DataSource) State() wire.State {
-	return obj.state
+	return &DataSource{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
@@ -2137,6 +2266,11 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnOffer != nil {
+			obj.OnOffer(DataSourceOfferRequest{
+				MimeType: mimeType,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2150,6 +2284,9 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(DataSourceDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2164,6 +2301,11 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetActions != nil {
+			obj.OnSetActions(DataSourceSetActionsRequest{
+				DndActions: dndActions,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2180,22 +2322,8 @@ func (obj *DataSource) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataSource) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataSource) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataSource) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataSource) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_source", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_source", obj.ID())
 }
 
 func (obj *DataSource) MethodName(op uint16) string {
@@ -2217,7 +2345,19 @@ func (obj *DataSource) Interface() string {
 	return DataSourceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataSourceVersion, the same as MaxVersion.
 func (obj *DataSource) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataSourceVersion
+}
+
+// MaxVersion returns DataSourceVersion, the highest version of
+// wl_data_source that is supported.
+func (obj *DataSource) MaxVersion() uint32 {
 	return DataSourceVersion
 }
 
@@ -2225,14 +2365,16 @@ func (obj *DataSource) Version() uint32 {
 // a target does not accept any of the offered types, type is NULL.
 //
 // Used for feedback during drag-and-drop.
-func (obj *DataSource) Target(mimeType string) {
+func (obj *DataSource) Target(mimeType *string) {
 	builder := wire.NewMessage(obj, 0)
 
-	builder.WriteString(mimeType)
+	builder.WriteNullableString(mimeType)
+
+	builder.Fail(wire.CheckMessageSize(DataSourceInterface, "target", builder))
 
 	builder.Method = "target"
 	builder.Args = []any{mimeType}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2245,9 +2387,11 @@ func (obj *DataSource) Send(mimeType string, fd *os.File) {
 	builder.WriteString(mimeType)
 	builder.WriteFile(fd)
 
+	builder.Fail(wire.CheckMessageSize(DataSourceInterface, "send", builder))
+
 	builder.Method = "send"
 	builder.Args = []any{mimeType, fd}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2276,7 +2420,7 @@ func (obj *DataSource) Cancelled() {
 
 	builder.Method = "cancelled"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2294,7 +2438,7 @@ func (obj *DataSource) DndDropPerformed() {
 
 	builder.Method = "dnd_drop_performed"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2309,7 +2453,7 @@ func (obj *DataSource) DndFinished() {
 
 	builder.Method = "dnd_finished"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2345,7 +2489,7 @@ func (obj *DataSource) Action(dndAction DataDeviceManagerDndAction) {
 
 	builder.Method = "action"
 	builder.Args = []any{dndAction}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2359,16 +2503,23 @@ const (
 	DataSourceErrorInvalidSource DataSourceError = 1
 )
 
-func (enum DataSourceError) String() string {
-	switch enum {
-	case 0:
-		return "DataSourceErrorInvalidActionMask"
+// DataSourceErrorNames maps the values of DataSourceError to their names.
+var DataSourceErrorNames = map[DataSourceError]string{
+	DataSourceErrorInvalidActionMask: "DataSourceErrorInvalidActionMask",
+	DataSourceErrorInvalidSource:     "DataSourceErrorInvalidSource",
+}
 
-	case 1:
-		return "DataSourceErrorInvalidSource"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum DataSourceError) String() string {
+	return wire.EnumString(enum, DataSourceErrorNames)
+}
 
-	return "<invalid DataSourceError>"
+// Since returns the version of wl_data_source that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataSourceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2419,50 +2570,74 @@ type DataDeviceListener interface {
 	Release()
 }
 
+// DataDeviceStartDragRequest holds the arguments of a wl_data_device.start_drag
+// request.
+type DataDeviceStartDragRequest struct {
+	Source *DataSource
+	Origin *Surface
+	Icon   *Surface
+	Serial uint32
+}
+
+// DataDeviceSetSelectionRequest holds the arguments of a wl_data_device.set_selection
+// request.
+type DataDeviceSetSelectionRequest struct {
+	Source *DataSource
+	Serial uint32
+}
+
+// DataDeviceReleaseRequest holds the arguments of a wl_data_device.release
+// request.
+type DataDeviceReleaseRequest struct {
+}
+
 // There is one wl_data_device per seat which can be obtained
 // from the global wl_data_device_manager singleton.
 //
 // A wl_data_device provides access to inter-client data transfer
 // mechanisms such as copy-and-paste and drag-and-drop.
 type DataDevice struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DataDeviceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnStartDrag, if not nil, is called with the arguments of
+	// each incoming start_drag request before Listener is.
+	OnStartDrag func(DataDeviceStartDragRequest)
 
-	state wire.State
-	id    uint32
+	// OnSetSelection, if not nil, is called with the arguments of
+	// each incoming set_selection request before Listener is.
+	OnSetSelection func(DataDeviceSetSelectionRequest)
+
+	// OnRelease, if not nil, is called with the arguments of
+	// each incoming release request before Listener is.
+	OnRelease func(DataDeviceReleaseRequest)
 }
 
+var (
+	_ wire.Object      = (*DataDevice)(nil)
+	_ wire.DebugObject = (*DataDevice)(nil)
+)
+
 // NewDataDevice returns a newly instantiated DataDevice. It is
 // primarily intended for use by generated code.
 func NewDataDevice(state wire.State) *DataDevice {
-	return &DataDevice{state: state}
-}
-
-func (obj *DataDevice) State() wire.State {
-	return obj.state
+	return &DataDevice{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		source, _ := obj.state.Get(msg.ReadUint()).(*DataSource)
-
-		obj.state.Add(source)
+		source, _ := obj.State().Get(msg.ReadUint()).(*DataSource)
 
-		origin, _ := obj.state.Get(msg.ReadUint()).(*Surface)
+		origin, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
-		obj.state.Add(origin)
-
-		icon, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(icon)
+		icon, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		serial := msg.ReadUint()
 
@@ -2470,6 +2645,14 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnStartDrag != nil {
+			obj.OnStartDrag(DataDeviceStartDragRequest{
+				Source: source,
+				Origin: origin,
+				Icon:   icon,
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2483,9 +2666,7 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		source, _ := obj.state.Get(msg.ReadUint()).(*DataSource)
-
-		obj.state.Add(source)
+		source, _ := obj.State().Get(msg.ReadUint()).(*DataSource)
 
 		serial := msg.ReadUint()
 
@@ -2493,6 +2674,12 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetSelection != nil {
+			obj.OnSetSelection(DataDeviceSetSelectionRequest{
+				Source: source,
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2507,6 +2694,9 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRelease != nil {
+			obj.OnRelease(DataDeviceReleaseRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2521,22 +2711,8 @@ func (obj *DataDevice) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataDevice) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataDevice) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataDevice) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataDevice) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_device", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_device", obj.ID())
 }
 
 func (obj *DataDevice) MethodName(op uint16) string {
@@ -2558,7 +2734,19 @@ func (obj *DataDevice) Interface() string {
 	return DataDeviceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataDeviceVersion, the same as MaxVersion.
 func (obj *DataDevice) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataDeviceVersion
+}
+
+// MaxVersion returns DataDeviceVersion, the highest version of
+// wl_data_device that is supported.
+func (obj *DataDevice) MaxVersion() uint32 {
 	return DataDeviceVersion
 }
 
@@ -2572,13 +2760,14 @@ func (obj *DataDevice) Version() uint32 {
 func (obj *DataDevice) DataOffer() (id *DataOffer) {
 	builder := wire.NewMessage(obj, 0)
 
-	id = NewDataOffer(obj.state)
-	obj.state.Add(id)
+	id = NewDataOffer(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "data_offer"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -2597,7 +2786,7 @@ func (obj *DataDevice) Enter(serial uint32, surface *Surface, x wire.Fixed, y wi
 
 	builder.Method = "enter"
 	builder.Args = []any{serial, surface, x, y, id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2609,7 +2798,7 @@ func (obj *DataDevice) Leave() {
 
 	builder.Method = "leave"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2626,7 +2815,7 @@ func (obj *DataDevice) Motion(time uint32, x wire.Fixed, y wire.Fixed) {
 
 	builder.Method = "motion"
 	builder.Args = []any{time, x, y}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2648,7 +2837,7 @@ func (obj *DataDevice) Drop() {
 
 	builder.Method = "drop"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2670,7 +2859,7 @@ func (obj *DataDevice) Selection(id *DataOffer) {
 
 	builder.Method = "selection"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2681,13 +2870,22 @@ const (
 	DataDeviceErrorRole DataDeviceError = 0
 )
 
+// DataDeviceErrorNames maps the values of DataDeviceError to their names.
+var DataDeviceErrorNames = map[DataDeviceError]string{
+	DataDeviceErrorRole: "DataDeviceErrorRole",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum DataDeviceError) String() string {
-	switch enum {
-	case 0:
-		return "DataDeviceErrorRole"
-	}
+	return wire.EnumString(enum, DataDeviceErrorNames)
+}
 
-	return "<invalid DataDeviceError>"
+// Since returns the version of wl_data_device that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataDeviceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2705,6 +2903,19 @@ type DataDeviceManagerListener interface {
 	GetDataDevice(id *DataDevice, seat *Seat)
 }
 
+// DataDeviceManagerCreateDataSourceRequest holds the arguments of a wl_data_device_manager.create_data_source
+// request.
+type DataDeviceManagerCreateDataSourceRequest struct {
+	Id *DataSource
+}
+
+// DataDeviceManagerGetDataDeviceRequest holds the arguments of a wl_data_device_manager.get_data_device
+// request.
+type DataDeviceManagerGetDataDeviceRequest struct {
+	Id   *DataDevice
+	Seat *Seat
+}
+
 // The wl_data_device_manager is a singleton global object that
 // provides access to inter-client data transfer mechanisms such as
 // copy-and-paste and drag-and-drop.  These mechanisms are tied to
@@ -2716,49 +2927,68 @@ type DataDeviceManagerListener interface {
 // functioning properly. See wl_data_source.set_actions,
 // wl_data_offer.accept and wl_data_offer.finish for details.
 type DataDeviceManager struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener DataDeviceManagerListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnCreateDataSource, if not nil, is called with the arguments of
+	// each incoming create_data_source request before Listener is.
+	OnCreateDataSource func(DataDeviceManagerCreateDataSourceRequest)
 
-	state wire.State
-	id    uint32
+	// OnGetDataDevice, if not nil, is called with the arguments of
+	// each incoming get_data_device request before Listener is.
+	OnGetDataDevice func(DataDeviceManagerGetDataDeviceRequest)
 }
 
+var (
+	_ wire.Object      = (*DataDeviceManager)(nil)
+	_ wire.DebugObject = (*DataDeviceManager)(nil)
+)
+
 // NewDataDeviceManager returns a newly instantiated DataDeviceManager. It is
 // primarily intended for use by generated code.
 func NewDataDeviceManager(state wire.State) *DataDeviceManager {
-	return &DataDeviceManager{state: state}
+	return &DataDeviceManager{Proxy: wire.NewProxy(state)}
 }
 
-func BindDataDeviceManager(state wire.State, id wire.NewID) *DataDeviceManager {
+// BindDataDeviceManager creates a new DataDeviceManager for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindDataDeviceManager(state wire.State, id wire.NewID) (*DataDeviceManager, error) {
+	if err := id.Check(DataDeviceManagerInterface, DataDeviceManagerVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewDataDeviceManager(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *DataDeviceManager) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *DataDeviceManager) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		id := NewDataSource(obj.state)
+		id := NewDataSource(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnCreateDataSource != nil {
+			obj.OnCreateDataSource(DataDeviceManagerCreateDataSourceRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2769,19 +2999,23 @@ func (obj *DataDeviceManager) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		id := NewDataDevice(obj.state)
+		id := NewDataDevice(obj.State())
 		id.SetID(msg.ReadUint())
+		id.SetVersion(obj.Version())
 
-		obj.state.Add(id)
-
-		seat, _ := obj.state.Get(msg.ReadUint()).(*Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*Seat)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetDataDevice != nil {
+			obj.OnGetDataDevice(DataDeviceManagerGetDataDeviceRequest{
+				Id:   id,
+				Seat: seat,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2799,22 +3033,8 @@ func (obj *DataDeviceManager) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *DataDeviceManager) ID() uint32 {
-	return obj.id
-}
-
-func (obj *DataDeviceManager) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *DataDeviceManager) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *DataDeviceManager) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_data_device_manager", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_data_device_manager", obj.ID())
 }
 
 func (obj *DataDeviceManager) MethodName(op uint16) string {
@@ -2833,7 +3053,19 @@ func (obj *DataDeviceManager) Interface() string {
 	return DataDeviceManagerInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// DataDeviceManagerVersion, the same as MaxVersion.
 func (obj *DataDeviceManager) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return DataDeviceManagerVersion
+}
+
+// MaxVersion returns DataDeviceManagerVersion, the highest version of
+// wl_data_device_manager that is supported.
+func (obj *DataDeviceManager) MaxVersion() uint32 {
 	return DataDeviceManagerVersion
 }
 
@@ -2876,22 +3108,25 @@ const (
 	DataDeviceManagerDndActionAsk DataDeviceManagerDndAction = 4
 )
 
-func (enum DataDeviceManagerDndAction) String() string {
-	switch enum {
-	case 0:
-		return "DataDeviceManagerDndActionNone"
-
-	case 1:
-		return "DataDeviceManagerDndActionCopy"
-
-	case 2:
-		return "DataDeviceManagerDndActionMove"
+// DataDeviceManagerDndActionNames maps the values of DataDeviceManagerDndAction to their names.
+var DataDeviceManagerDndActionNames = map[DataDeviceManagerDndAction]string{
+	DataDeviceManagerDndActionNone: "DataDeviceManagerDndActionNone",
+	DataDeviceManagerDndActionCopy: "DataDeviceManagerDndActionCopy",
+	DataDeviceManagerDndActionMove: "DataDeviceManagerDndActionMove",
+	DataDeviceManagerDndActionAsk:  "DataDeviceManagerDndActionAsk",
+}
 
-	case 4:
-		return "DataDeviceManagerDndActionAsk"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum DataDeviceManagerDndAction) String() string {
+	return wire.FlagString(enum, DataDeviceManagerDndActionNames)
+}
 
-	return "<invalid DataDeviceManagerDndAction>"
+// Since returns the version of wl_data_device_manager that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum DataDeviceManagerDndAction) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2910,6 +3145,13 @@ type ShellListener interface {
 	GetShellSurface(id *ShellSurface, surface *Surface)
 }
 
+// ShellGetShellSurfaceRequest holds the arguments of a wl_shell.get_shell_surface
+// request.
+type ShellGetShellSurfaceRequest struct {
+	Id      *ShellSurface
+	Surface *Surface
+}
+
 // This interface is implemented by servers that provide
 // desktop-style user interfaces.
 //
@@ -2919,53 +3161,67 @@ type ShellListener interface {
 // Note! This protocol is deprecated and not intended for production use.
 // For desktop-style user interfaces, use xdg_shell.
 type Shell struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ShellListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnGetShellSurface, if not nil, is called with the arguments of
+	// each incoming get_shell_surface request before Listener is.
+	OnGetShellSurface func(ShellGetShellSurfaceRequest)
 }
 
+var (
+	_ wire.Object      = (*Shell)(nil)
+	_ wire.DebugObject = (*Shell)(nil)
+)
+
 // NewShell returns a newly instantiated Shell. It is
 // primarily intended for use by generated code.
 func NewShell(state wire.State) *Shell {
-	return &Shell{state: state}
+	return &Shell{Proxy: wire.NewProxy(state)}
 }
 
-func BindShell(state wire.State, id wire.NewID) *Shell {
+// BindShell creates a new Shell for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindShell(state wire.State, id wire.NewID) (*Shell, error) {
+	if err := id.Check(ShellInterface, ShellVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewShell(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *Shell) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *Shell) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		id := NewShellSurface(obj.state)
+		id := NewShellSurface(obj.State())
 		id.SetID(msg.ReadUint())
+		id.SetVersion(obj.Version())
 
-		obj.state.Add(id)
-
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetShellSurface != nil {
+			obj.OnGetShellSurface(ShellGetShellSurfaceRequest{
+				Id:      id,
+				Surface: surface,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2983,22 +3239,8 @@ func (obj *Shell) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Shell) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Shell) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Shell) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Shell) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shell", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shell", obj.ID())
 }
 
 func (obj *Shell) MethodName(op uint16) string {
@@ -3014,7 +3256,19 @@ func (obj *Shell) Interface() string {
 	return ShellInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShellVersion, the same as MaxVersion.
 func (obj *Shell) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShellVersion
+}
+
+// MaxVersion returns ShellVersion, the highest version of
+// wl_shell that is supported.
+func (obj *Shell) MaxVersion() uint32 {
 	return ShellVersion
 }
 
@@ -3025,13 +3279,22 @@ const (
 	ShellErrorRole ShellError = 0
 )
 
+// ShellErrorNames maps the values of ShellError to their names.
+var ShellErrorNames = map[ShellError]string{
+	ShellErrorRole: "ShellErrorRole",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum ShellError) String() string {
-	switch enum {
-	case 0:
-		return "ShellErrorRole"
-	}
+	return wire.EnumString(enum, ShellErrorNames)
+}
 
-	return "<invalid ShellError>"
+// Since returns the version of wl_shell that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -3168,6 +3431,78 @@ type ShellSurfaceListener interface {
 	SetClass(class string)
 }
 
+// ShellSurfacePongRequest holds the arguments of a wl_shell_surface.pong
+// request.
+type ShellSurfacePongRequest struct {
+	Serial uint32
+}
+
+// ShellSurfaceMoveRequest holds the arguments of a wl_shell_surface.move
+// request.
+type ShellSurfaceMoveRequest struct {
+	Seat   *Seat
+	Serial uint32
+}
+
+// ShellSurfaceResizeRequest holds the arguments of a wl_shell_surface.resize
+// request.
+type ShellSurfaceResizeRequest struct {
+	Seat   *Seat
+	Serial uint32
+	Edges  ShellSurfaceResize
+}
+
+// ShellSurfaceSetToplevelRequest holds the arguments of a wl_shell_surface.set_toplevel
+// request.
+type ShellSurfaceSetToplevelRequest struct {
+}
+
+// ShellSurfaceSetTransientRequest holds the arguments of a wl_shell_surface.set_transient
+// request.
+type ShellSurfaceSetTransientRequest struct {
+	Parent *Surface
+	X      int32
+	Y      int32
+	Flags  ShellSurfaceTransient
+}
+
+// ShellSurfaceSetFullscreenRequest holds the arguments of a wl_shell_surface.set_fullscreen
+// request.
+type ShellSurfaceSetFullscreenRequest struct {
+	Method    ShellSurfaceFullscreenMethod
+	Framerate uint32
+	Output    *Output
+}
+
+// ShellSurfaceSetPopupRequest holds the arguments of a wl_shell_surface.set_popup
+// request.
+type ShellSurfaceSetPopupRequest struct {
+	Seat   *Seat
+	Serial uint32
+	Parent *Surface
+	X      int32
+	Y      int32
+	Flags  ShellSurfaceTransient
+}
+
+// ShellSurfaceSetMaximizedRequest holds the arguments of a wl_shell_surface.set_maximized
+// request.
+type ShellSurfaceSetMaximizedRequest struct {
+	Output *Output
+}
+
+// ShellSurfaceSetTitleRequest holds the arguments of a wl_shell_surface.set_title
+// request.
+type ShellSurfaceSetTitleRequest struct {
+	Title string
+}
+
+// ShellSurfaceSetClassRequest holds the arguments of a wl_shell_surface.set_class
+// request.
+type ShellSurfaceSetClassRequest struct {
+	Class string
+}
+
 // An interface that may be implemented by a wl_surface, for
 // implementations that provide a desktop-style user interface.
 //
@@ -3180,27 +3515,64 @@ type ShellSurfaceListener interface {
 // wl_shell_surface_destroy() must be called before destroying
 // the wl_surface object.
 type ShellSurface struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ShellSurfaceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnPong, if not nil, is called with the arguments of
+	// each incoming pong request before Listener is.
+	OnPong func(ShellSurfacePongRequest)
+
+	// OnMove, if not nil, is called with the arguments of
+	// each incoming move request before Listener is.
+	OnMove func(ShellSurfaceMoveRequest)
+
+	// OnResize, if not nil, is called with the arguments of
+	// each incoming resize request before Listener is.
+	OnResize func(ShellSurfaceResizeRequest)
 
-	state wire.State
-	id    uint32
+	// OnSetToplevel, if not nil, is called with the arguments of
+	// each incoming set_toplevel request before Listener is.
+	OnSetToplevel func(ShellSurfaceSetToplevelRequest)
+
+	// OnSetTransient, if not nil, is called with the arguments of
+	// each incoming set_transient request before Listener is.
+	OnSetTransient func(ShellSurfaceSetTransientRequest)
+
+	// OnSetFullscreen, if not nil, is called with the arguments of
+	// each incoming set_fullscreen request before Listener is.
+	OnSetFullscreen func(ShellSurfaceSetFullscreenRequest)
+
+	// OnSetPopup, if not nil, is called with the arguments of
+	// each incoming set_popup request before Listener is.
+	OnSetPopup func(ShellSurfaceSetPopupRequest)
+
+	// OnSetMaximized, if not nil, is called with the arguments of
+	// each incoming set_maximized request before Listener is.
+	OnSetMaximized func(ShellSurfaceSetMaximizedRequest)
+
+	// OnSetTitle, if not nil, is called with the arguments of
+	// each incoming set_title request before Listener is.
+	OnSetTitle func(ShellSurfaceSetTitleRequest)
+
+	// OnSetClass, if not nil, is called with the arguments of
+	// each incoming set_class request before Listener is.
+	OnSetClass func(ShellSurfaceSetClassRequest)
 }
 
+var (
+	_ wire.Object      = (*ShellSurface)(nil)
+	_ wire.DebugObject = (*ShellSurface)(nil)
+)
+
 // NewShellSurface returns a newly instantiated ShellSurface. It is
 // primarily intended for use by generated code.
 func NewShellSurface(state wire.State) *ShellSurface {
-	return &ShellSurface{state: state}
-}
-
-func (obj *ShellSurface) State() wire.State {
-	return obj.state
+	return &ShellSurface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
@@ -3213,6 +3585,11 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnPong != nil {
+			obj.OnPong(ShellSurfacePongRequest{
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3223,9 +3600,7 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		seat, _ := obj.state.Get(msg.ReadUint()).(*Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*Seat)
 
 		serial := msg.ReadUint()
 
@@ -3233,6 +3608,12 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnMove != nil {
+			obj.OnMove(ShellSurfaceMoveRequest{
+				Seat:   seat,
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3244,9 +3625,7 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 2:
 
-		seat, _ := obj.state.Get(msg.ReadUint()).(*Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*Seat)
 
 		serial := msg.ReadUint()
 
@@ -3256,6 +3635,13 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnResize != nil {
+			obj.OnResize(ShellSurfaceResizeRequest{
+				Seat:   seat,
+				Serial: serial,
+				Edges:  edges,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3271,6 +3657,9 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetToplevel != nil {
+			obj.OnSetToplevel(ShellSurfaceSetToplevelRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3279,9 +3668,7 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 4:
 
-		parent, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(parent)
+		parent, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		x := msg.ReadInt()
 
@@ -3293,6 +3680,14 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetTransient != nil {
+			obj.OnSetTransient(ShellSurfaceSetTransientRequest{
+				Parent: parent,
+				X:      x,
+				Y:      y,
+				Flags:  flags,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3310,14 +3705,19 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 
 		framerate := msg.ReadUint()
 
-		output, _ := obj.state.Get(msg.ReadUint()).(*Output)
-
-		obj.state.Add(output)
+		output, _ := obj.State().Get(msg.ReadUint()).(*Output)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnSetFullscreen != nil {
+			obj.OnSetFullscreen(ShellSurfaceSetFullscreenRequest{
+				Method:    method,
+				Framerate: framerate,
+				Output:    output,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3330,15 +3730,11 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 6:
 
-		seat, _ := obj.state.Get(msg.ReadUint()).(*Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*Seat)
 
 		serial := msg.ReadUint()
 
-		parent, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(parent)
+		parent, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		x := msg.ReadInt()
 
@@ -3350,6 +3746,16 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetPopup != nil {
+			obj.OnSetPopup(ShellSurfaceSetPopupRequest{
+				Seat:   seat,
+				Serial: serial,
+				Parent: parent,
+				X:      x,
+				Y:      y,
+				Flags:  flags,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3365,14 +3771,17 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 7:
 
-		output, _ := obj.state.Get(msg.ReadUint()).(*Output)
-
-		obj.state.Add(output)
+		output, _ := obj.State().Get(msg.ReadUint()).(*Output)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnSetMaximized != nil {
+			obj.OnSetMaximized(ShellSurfaceSetMaximizedRequest{
+				Output: output,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3389,6 +3798,11 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetTitle != nil {
+			obj.OnSetTitle(ShellSurfaceSetTitleRequest{
+				Title: title,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3405,6 +3819,11 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetClass != nil {
+			obj.OnSetClass(ShellSurfaceSetClassRequest{
+				Class: class,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -3421,22 +3840,8 @@ func (obj *ShellSurface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *ShellSurface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *ShellSurface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *ShellSurface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *ShellSurface) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_shell_surface", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_shell_surface", obj.ID())
 }
 
 func (obj *ShellSurface) MethodName(op uint16) string {
@@ -3479,7 +3884,19 @@ func (obj *ShellSurface) Interface() string {
 	return ShellSurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ShellSurfaceVersion, the same as MaxVersion.
 func (obj *ShellSurface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ShellSurfaceVersion
+}
+
+// MaxVersion returns ShellSurfaceVersion, the highest version of
+// wl_shell_surface that is supported.
+func (obj *ShellSurface) MaxVersion() uint32 {
 	return ShellSurfaceVersion
 }
 
@@ -3492,7 +3909,7 @@ func (obj *ShellSurface) Ping(serial uint32) {
 
 	builder.Method = "ping"
 	builder.Args = []any{serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3522,7 +3939,7 @@ func (obj *ShellSurface) Configure(edges ShellSurfaceResize, width int32, height
 
 	builder.Method = "configure"
 	builder.Args = []any{edges, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3534,7 +3951,7 @@ func (obj *ShellSurface) PopupDone() {
 
 	builder.Method = "popup_done"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -3573,37 +3990,30 @@ const (
 	ShellSurfaceResizeBottomRight ShellSurfaceResize = 10
 )
 
+// ShellSurfaceResizeNames maps the values of ShellSurfaceResize to their names.
+var ShellSurfaceResizeNames = map[ShellSurfaceResize]string{
+	ShellSurfaceResizeNone:        "ShellSurfaceResizeNone",
+	ShellSurfaceResizeTop:         "ShellSurfaceResizeTop",
+	ShellSurfaceResizeBottom:      "ShellSurfaceResizeBottom",
+	ShellSurfaceResizeLeft:        "ShellSurfaceResizeLeft",
+	ShellSurfaceResizeTopLeft:     "ShellSurfaceResizeTopLeft",
+	ShellSurfaceResizeBottomLeft:  "ShellSurfaceResizeBottomLeft",
+	ShellSurfaceResizeRight:       "ShellSurfaceResizeRight",
+	ShellSurfaceResizeTopRight:    "ShellSurfaceResizeTopRight",
+	ShellSurfaceResizeBottomRight: "ShellSurfaceResizeBottomRight",
+}
+
+// String returns the names of the flags set in enum, joined
+// with "|".
 func (enum ShellSurfaceResize) String() string {
-	switch enum {
-	case 0:
-		return "ShellSurfaceResizeNone"
-
-	case 1:
-		return "ShellSurfaceResizeTop"
-
-	case 2:
-		return "ShellSurfaceResizeBottom"
-
-	case 4:
-		return "ShellSurfaceResizeLeft"
-
-	case 5:
-		return "ShellSurfaceResizeTopLeft"
-
-	case 6:
-		return "ShellSurfaceResizeBottomLeft"
-
-	case 8:
-		return "ShellSurfaceResizeRight"
-
-	case 9:
-		return "ShellSurfaceResizeTopRight"
-
-	case 10:
-		return "ShellSurfaceResizeBottomRight"
-	}
+	return wire.FlagString(enum, ShellSurfaceResizeNames)
+}
 
-	return "<invalid ShellSurfaceResize>"
+// Since returns the version of wl_shell_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellSurfaceResize) Since() uint32 {
+	return 1
 }
 
 // These flags specify details of the expected behaviour
@@ -3615,13 +4025,22 @@ const (
 	ShellSurfaceTransientInactive ShellSurfaceTransient = 1
 )
 
+// ShellSurfaceTransientNames maps the values of ShellSurfaceTransient to their names.
+var ShellSurfaceTransientNames = map[ShellSurfaceTransient]string{
+	ShellSurfaceTransientInactive: "ShellSurfaceTransientInactive",
+}
+
+// String returns the names of the flags set in enum, joined
+// with "|".
 func (enum ShellSurfaceTransient) String() string {
-	switch enum {
-	case 1:
-		return "ShellSurfaceTransientInactive"
-	}
+	return wire.FlagString(enum, ShellSurfaceTransientNames)
+}
 
-	return "<invalid ShellSurfaceTransient>"
+// Since returns the version of wl_shell_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellSurfaceTransient) Since() uint32 {
+	return 1
 }
 
 // Hints to indicate to the compositor how to deal with a conflict
@@ -3643,22 +4062,25 @@ const (
 	ShellSurfaceFullscreenMethodFill ShellSurfaceFullscreenMethod = 3
 )
 
-func (enum ShellSurfaceFullscreenMethod) String() string {
-	switch enum {
-	case 0:
-		return "ShellSurfaceFullscreenMethodDefault"
-
-	case 1:
-		return "ShellSurfaceFullscreenMethodScale"
-
-	case 2:
-		return "ShellSurfaceFullscreenMethodDriver"
+// ShellSurfaceFullscreenMethodNames maps the values of ShellSurfaceFullscreenMethod to their names.
+var ShellSurfaceFullscreenMethodNames = map[ShellSurfaceFullscreenMethod]string{
+	ShellSurfaceFullscreenMethodDefault: "ShellSurfaceFullscreenMethodDefault",
+	ShellSurfaceFullscreenMethodScale:   "ShellSurfaceFullscreenMethodScale",
+	ShellSurfaceFullscreenMethodDriver:  "ShellSurfaceFullscreenMethodDriver",
+	ShellSurfaceFullscreenMethodFill:    "ShellSurfaceFullscreenMethodFill",
+}
 
-	case 3:
-		return "ShellSurfaceFullscreenMethodFill"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ShellSurfaceFullscreenMethod) String() string {
+	return wire.EnumString(enum, ShellSurfaceFullscreenMethodNames)
+}
 
-	return "<invalid ShellSurfaceFullscreenMethod>"
+// Since returns the version of wl_shell_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ShellSurfaceFullscreenMethod) Since() uint32 {
+	return 1
 }
 
 const (
@@ -3937,6 +4359,72 @@ type SurfaceListener interface {
 	DamageBuffer(x int32, y int32, width int32, height int32)
 }
 
+// SurfaceDestroyRequest holds the arguments of a wl_surface.destroy
+// request.
+type SurfaceDestroyRequest struct {
+}
+
+// SurfaceAttachRequest holds the arguments of a wl_surface.attach
+// request.
+type SurfaceAttachRequest struct {
+	Buffer *Buffer
+	X      int32
+	Y      int32
+}
+
+// SurfaceDamageRequest holds the arguments of a wl_surface.damage
+// request.
+type SurfaceDamageRequest struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
+// SurfaceFrameRequest holds the arguments of a wl_surface.frame
+// request.
+type SurfaceFrameRequest struct {
+	Callback *Callback
+}
+
+// SurfaceSetOpaqueRegionRequest holds the arguments of a wl_surface.set_opaque_region
+// request.
+type SurfaceSetOpaqueRegionRequest struct {
+	Region *Region
+}
+
+// SurfaceSetInputRegionRequest holds the arguments of a wl_surface.set_input_region
+// request.
+type SurfaceSetInputRegionRequest struct {
+	Region *Region
+}
+
+// SurfaceCommitRequest holds the arguments of a wl_surface.commit
+// request.
+type SurfaceCommitRequest struct {
+}
+
+// SurfaceSetBufferTransformRequest holds the arguments of a wl_surface.set_buffer_transform
+// request.
+type SurfaceSetBufferTransformRequest struct {
+	Transform OutputTransform
+}
+
+// SurfaceSetBufferScaleRequest holds the arguments of a wl_surface.set_buffer_scale
+// request.
+type SurfaceSetBufferScaleRequest struct {
+	Scale int32
+}
+
+// SurfaceDamageBufferRequest holds the arguments of a wl_surface.damage_buffer
+// request.
+type SurfaceDamageBufferRequest struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
 // A surface is a rectangular area that may be displayed on zero
 // or more outputs, and shown any number of times at the compositor's
 // discretion. They can present wl_buffers, receive user input, and
@@ -3979,27 +4467,64 @@ type SurfaceListener interface {
 // a cursor (cursor is a different role than sub-surface, and role
 // switching is not allowed).
 type Surface struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SurfaceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(SurfaceDestroyRequest)
+
+	// OnAttach, if not nil, is called with the arguments of
+	// each incoming attach request before Listener is.
+	OnAttach func(SurfaceAttachRequest)
+
+	// OnDamage, if not nil, is called with the arguments of
+	// each incoming damage request before Listener is.
+	OnDamage func(SurfaceDamageRequest)
+
+	// OnFrame, if not nil, is called with the arguments of
+	// each incoming frame request before Listener is.
+	OnFrame func(SurfaceFrameRequest)
+
+	// OnSetOpaqueRegion, if not nil, is called with the arguments of
+	// each incoming set_opaque_region request before Listener is.
+	OnSetOpaqueRegion func(SurfaceSetOpaqueRegionRequest)
+
+	// OnSetInputRegion, if not nil, is called with the arguments of
+	// each incoming set_input_region request before Listener is.
+	OnSetInputRegion func(SurfaceSetInputRegionRequest)
+
+	// OnCommit, if not nil, is called with the arguments of
+	// each incoming commit request before Listener is.
+	OnCommit func(SurfaceCommitRequest)
 
-	state wire.State
-	id    uint32
+	// OnSetBufferTransform, if not nil, is called with the arguments of
+	// each incoming set_buffer_transform request before Listener is.
+	OnSetBufferTransform func(SurfaceSetBufferTransformRequest)
+
+	// OnSetBufferScale, if not nil, is called with the arguments of
+	// each incoming set_buffer_scale request before Listener is.
+	OnSetBufferScale func(SurfaceSetBufferScaleRequest)
+
+	// OnDamageBuffer, if not nil, is called with the arguments of
+	// each incoming damage_buffer request before Listener is.
+	OnDamageBuffer func(SurfaceDamageBufferRequest)
 }
 
+var (
+	_ wire.Object      = (*Surface)(nil)
+	_ wire.DebugObject = (*Surface)(nil)
+)
+
 // NewSurface returns a newly instantiated Surface. It is
 // primarily intended for use by generated code.
 func NewSurface(state wire.State) *Surface {
-	return &Surface{state: state}
-}
-
-func (obj *Surface) State() wire.State {
-	return obj.state
+	return &Surface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
@@ -4009,6 +4534,9 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(SurfaceDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4017,9 +4545,7 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		buffer, _ := obj.state.Get(msg.ReadUint()).(*Buffer)
-
-		obj.state.Add(buffer)
+		buffer, _ := obj.State().Get(msg.ReadUint()).(*Buffer)
 
 		x := msg.ReadInt()
 
@@ -4029,6 +4555,13 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAttach != nil {
+			obj.OnAttach(SurfaceAttachRequest{
+				Buffer: buffer,
+				X:      x,
+				Y:      y,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4053,6 +4586,14 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDamage != nil {
+			obj.OnDamage(SurfaceDamageRequest{
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4066,15 +4607,20 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 3:
 
-		callback := NewCallback(obj.state)
+		callback := NewCallback(obj.State())
 		callback.SetID(msg.ReadUint())
-
-		obj.state.Add(callback)
+		callback.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(callback)
 
+		if obj.OnFrame != nil {
+			obj.OnFrame(SurfaceFrameRequest{
+				Callback: callback,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4085,14 +4631,17 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 4:
 
-		region, _ := obj.state.Get(msg.ReadUint()).(*Region)
-
-		obj.state.Add(region)
+		region, _ := obj.State().Get(msg.ReadUint()).(*Region)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnSetOpaqueRegion != nil {
+			obj.OnSetOpaqueRegion(SurfaceSetOpaqueRegionRequest{
+				Region: region,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4103,14 +4652,17 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 5:
 
-		region, _ := obj.state.Get(msg.ReadUint()).(*Region)
-
-		obj.state.Add(region)
+		region, _ := obj.State().Get(msg.ReadUint()).(*Region)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnSetInputRegion != nil {
+			obj.OnSetInputRegion(SurfaceSetInputRegionRequest{
+				Region: region,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4124,6 +4676,9 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnCommit != nil {
+			obj.OnCommit(SurfaceCommitRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4138,6 +4693,11 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetBufferTransform != nil {
+			obj.OnSetBufferTransform(SurfaceSetBufferTransformRequest{
+				Transform: transform,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4154,6 +4714,11 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetBufferScale != nil {
+			obj.OnSetBufferScale(SurfaceSetBufferScaleRequest{
+				Scale: scale,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4176,6 +4741,14 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDamageBuffer != nil {
+			obj.OnDamageBuffer(SurfaceDamageBufferRequest{
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4195,22 +4768,8 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Surface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Surface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Surface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Surface) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_surface", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_surface", obj.ID())
 }
 
 func (obj *Surface) MethodName(op uint16) string {
@@ -4253,7 +4812,19 @@ func (obj *Surface) Interface() string {
 	return SurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SurfaceVersion, the same as MaxVersion.
 func (obj *Surface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SurfaceVersion
+}
+
+// MaxVersion returns SurfaceVersion, the highest version of
+// wl_surface that is supported.
+func (obj *Surface) MaxVersion() uint32 {
 	return SurfaceVersion
 }
 
@@ -4269,7 +4840,7 @@ func (obj *Surface) Enter(output *Output) {
 
 	builder.Method = "enter"
 	builder.Args = []any{output}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4289,7 +4860,7 @@ func (obj *Surface) Leave(output *Output) {
 
 	builder.Method = "leave"
 	builder.Args = []any{output}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4307,19 +4878,24 @@ const (
 	SurfaceErrorInvalidSize SurfaceError = 2
 )
 
-func (enum SurfaceError) String() string {
-	switch enum {
-	case 0:
-		return "SurfaceErrorInvalidScale"
-
-	case 1:
-		return "SurfaceErrorInvalidTransform"
+// SurfaceErrorNames maps the values of SurfaceError to their names.
+var SurfaceErrorNames = map[SurfaceError]string{
+	SurfaceErrorInvalidScale:     "SurfaceErrorInvalidScale",
+	SurfaceErrorInvalidTransform: "SurfaceErrorInvalidTransform",
+	SurfaceErrorInvalidSize:      "SurfaceErrorInvalidSize",
+}
 
-	case 2:
-		return "SurfaceErrorInvalidSize"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum SurfaceError) String() string {
+	return wire.EnumString(enum, SurfaceErrorNames)
+}
 
-	return "<invalid SurfaceError>"
+// Since returns the version of wl_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SurfaceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -4365,54 +4941,104 @@ type SeatListener interface {
 	Release()
 }
 
+// SeatGetPointerRequest holds the arguments of a wl_seat.get_pointer
+// request.
+type SeatGetPointerRequest struct {
+	Id *Pointer
+}
+
+// SeatGetKeyboardRequest holds the arguments of a wl_seat.get_keyboard
+// request.
+type SeatGetKeyboardRequest struct {
+	Id *Keyboard
+}
+
+// SeatGetTouchRequest holds the arguments of a wl_seat.get_touch
+// request.
+type SeatGetTouchRequest struct {
+	Id *Touch
+}
+
+// SeatReleaseRequest holds the arguments of a wl_seat.release
+// request.
+type SeatReleaseRequest struct {
+}
+
 // A seat is a group of keyboards, pointer and touch devices. This
 // object is published as a global during start up, or when such a
 // device is hot plugged.  A seat typically has a pointer and
 // maintains a keyboard focus and a pointer focus.
 type Seat struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SeatListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnGetPointer, if not nil, is called with the arguments of
+	// each incoming get_pointer request before Listener is.
+	OnGetPointer func(SeatGetPointerRequest)
+
+	// OnGetKeyboard, if not nil, is called with the arguments of
+	// each incoming get_keyboard request before Listener is.
+	OnGetKeyboard func(SeatGetKeyboardRequest)
+
+	// OnGetTouch, if not nil, is called with the arguments of
+	// each incoming get_touch request before Listener is.
+	OnGetTouch func(SeatGetTouchRequest)
 
-	state wire.State
-	id    uint32
+	// OnRelease, if not nil, is called with the arguments of
+	// each incoming release request before Listener is.
+	OnRelease func(SeatReleaseRequest)
 }
 
+var (
+	_ wire.Object      = (*Seat)(nil)
+	_ wire.DebugObject = (*Seat)(nil)
+)
+
 // NewSeat returns a newly instantiated Seat. It is
 // primarily intended for use by generated code.
 func NewSeat(state wire.State) *Seat {
-	return &Seat{state: state}
+	return &Seat{Proxy: wire.NewProxy(state)}
 }
 
-func BindSeat(state wire.State, id wire.NewID) *Seat {
+// BindSeat creates a new Seat for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindSeat(state wire.State, id wire.NewID) (*Seat, error) {
+	if err := id.Check(SeatInterface, SeatVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewSeat(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *Seat) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 	switch msg.Op() {
 	case 0:
 
-		id := NewPointer(obj.state)
+		id := NewPointer(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetPointer != nil {
+			obj.OnGetPointer(SeatGetPointerRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4423,15 +5049,20 @@ func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		id := NewKeyboard(obj.state)
+		id := NewKeyboard(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetKeyboard != nil {
+			obj.OnGetKeyboard(SeatGetKeyboardRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4442,15 +5073,20 @@ func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 2:
 
-		id := NewTouch(obj.state)
+		id := NewTouch(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetTouch != nil {
+			obj.OnGetTouch(SeatGetTouchRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4464,6 +5100,9 @@ func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRelease != nil {
+			obj.OnRelease(SeatReleaseRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4478,22 +5117,8 @@ func (obj *Seat) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Seat) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Seat) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Seat) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Seat) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_seat", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_seat", obj.ID())
 }
 
 func (obj *Seat) MethodName(op uint16) string {
@@ -4518,7 +5143,19 @@ func (obj *Seat) Interface() string {
 	return SeatInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SeatVersion, the same as MaxVersion.
 func (obj *Seat) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SeatVersion
+}
+
+// MaxVersion returns SeatVersion, the highest version of
+// wl_seat that is supported.
+func (obj *Seat) MaxVersion() uint32 {
 	return SeatVersion
 }
 
@@ -4553,7 +5190,7 @@ func (obj *Seat) Capabilities(capabilities SeatCapability) {
 
 	builder.Method = "capabilities"
 	builder.Args = []any{capabilities}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4565,9 +5202,11 @@ func (obj *Seat) Name(name string) {
 
 	builder.WriteString(name)
 
+	builder.Fail(wire.CheckMessageSize(SeatInterface, "name", builder))
+
 	builder.Method = "name"
 	builder.Args = []any{name}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4586,19 +5225,24 @@ const (
 	SeatCapabilityTouch SeatCapability = 4
 )
 
-func (enum SeatCapability) String() string {
-	switch enum {
-	case 1:
-		return "SeatCapabilityPointer"
-
-	case 2:
-		return "SeatCapabilityKeyboard"
+// SeatCapabilityNames maps the values of SeatCapability to their names.
+var SeatCapabilityNames = map[SeatCapability]string{
+	SeatCapabilityPointer:  "SeatCapabilityPointer",
+	SeatCapabilityKeyboard: "SeatCapabilityKeyboard",
+	SeatCapabilityTouch:    "SeatCapabilityTouch",
+}
 
-	case 4:
-		return "SeatCapabilityTouch"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum SeatCapability) String() string {
+	return wire.FlagString(enum, SeatCapabilityNames)
+}
 
-	return "<invalid SeatCapability>"
+// Since returns the version of wl_seat that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SeatCapability) Since() uint32 {
+	return 1
 }
 
 // These errors can be emitted in response to wl_seat requests.
@@ -4609,13 +5253,22 @@ const (
 	SeatErrorMissingCapability SeatError = 0
 )
 
+// SeatErrorNames maps the values of SeatError to their names.
+var SeatErrorNames = map[SeatError]string{
+	SeatErrorMissingCapability: "SeatErrorMissingCapability",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum SeatError) String() string {
-	switch enum {
-	case 0:
-		return "SeatErrorMissingCapability"
-	}
+	return wire.EnumString(enum, SeatErrorNames)
+}
 
-	return "<invalid SeatError>"
+// Since returns the version of wl_seat that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SeatError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -4667,6 +5320,20 @@ type PointerListener interface {
 	Release()
 }
 
+// PointerSetCursorRequest holds the arguments of a wl_pointer.set_cursor
+// request.
+type PointerSetCursorRequest struct {
+	Serial   uint32
+	Surface  *Surface
+	HotspotX int32
+	HotspotY int32
+}
+
+// PointerReleaseRequest holds the arguments of a wl_pointer.release
+// request.
+type PointerReleaseRequest struct {
+}
+
 // The wl_pointer interface represents one or more input devices,
 // such as mice, which control the pointer location and pointer_focus
 // of a seat.
@@ -4676,27 +5343,32 @@ type PointerListener interface {
 // and button and axis events for button presses, button releases
 // and scrolling.
 type Pointer struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener PointerListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnSetCursor, if not nil, is called with the arguments of
+	// each incoming set_cursor request before Listener is.
+	OnSetCursor func(PointerSetCursorRequest)
 
-	state wire.State
-	id    uint32
+	// OnRelease, if not nil, is called with the arguments of
+	// each incoming release request before Listener is.
+	OnRelease func(PointerReleaseRequest)
 }
 
+var (
+	_ wire.Object      = (*Pointer)(nil)
+	_ wire.DebugObject = (*Pointer)(nil)
+)
+
 // NewPointer returns a newly instantiated Pointer. It is
 // primarily intended for use by generated code.
 func NewPointer(state wire.State) *Pointer {
-	return &Pointer{state: state}
-}
-
-func (obj *Pointer) State() wire.State {
-	return obj.state
+	return &Pointer{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
@@ -4705,9 +5377,7 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 
 		serial := msg.ReadUint()
 
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		hotspotX := msg.ReadInt()
 
@@ -4717,6 +5387,14 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetCursor != nil {
+			obj.OnSetCursor(PointerSetCursorRequest{
+				Serial:   serial,
+				Surface:  surface,
+				HotspotX: hotspotX,
+				HotspotY: hotspotY,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4733,6 +5411,9 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRelease != nil {
+			obj.OnRelease(PointerReleaseRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -4747,22 +5428,8 @@ func (obj *Pointer) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Pointer) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Pointer) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Pointer) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Pointer) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_pointer", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_pointer", obj.ID())
 }
 
 func (obj *Pointer) MethodName(op uint16) string {
@@ -4781,7 +5448,19 @@ func (obj *Pointer) Interface() string {
 	return PointerInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// PointerVersion, the same as MaxVersion.
 func (obj *Pointer) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return PointerVersion
+}
+
+// MaxVersion returns PointerVersion, the highest version of
+// wl_pointer that is supported.
+func (obj *Pointer) MaxVersion() uint32 {
 	return PointerVersion
 }
 
@@ -4801,7 +5480,7 @@ func (obj *Pointer) Enter(serial uint32, surface *Surface, surfaceX wire.Fixed,
 
 	builder.Method = "enter"
 	builder.Args = []any{serial, surface, surfaceX, surfaceY}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4818,7 +5497,7 @@ func (obj *Pointer) Leave(serial uint32, surface *Surface) {
 
 	builder.Method = "leave"
 	builder.Args = []any{serial, surface}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4834,7 +5513,7 @@ func (obj *Pointer) Motion(time uint32, surfaceX wire.Fixed, surfaceY wire.Fixed
 
 	builder.Method = "motion"
 	builder.Args = []any{time, surfaceX, surfaceY}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4862,7 +5541,7 @@ func (obj *Pointer) Button(serial uint32, time uint32, button uint32, state Poin
 
 	builder.Method = "button"
 	builder.Args = []any{serial, time, button, state}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4891,7 +5570,7 @@ func (obj *Pointer) Axis(time uint32, axis PointerAxis, value wire.Fixed) {
 
 	builder.Method = "axis"
 	builder.Args = []any{time, axis, value}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4934,7 +5613,7 @@ func (obj *Pointer) Frame() {
 
 	builder.Method = "frame"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4968,9 +5647,11 @@ func (obj *Pointer) AxisSource(axisSource PointerAxisSource) {
 
 	builder.WriteUint(uint32(axisSource))
 
+	builder.Fail(wire.CheckEnumVersion(PointerInterface, "axis_source", obj.Version(), axisSource))
+
 	builder.Method = "axis_source"
 	builder.Args = []any{axisSource}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -4996,7 +5677,7 @@ func (obj *Pointer) AxisStop(time uint32, axis PointerAxis) {
 
 	builder.Method = "axis_stop"
 	builder.Args = []any{time, axis}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5034,7 +5715,7 @@ func (obj *Pointer) AxisDiscrete(axis PointerAxis, discrete int32) {
 
 	builder.Method = "axis_discrete"
 	builder.Args = []any{axis, discrete}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5045,13 +5726,22 @@ const (
 	PointerErrorRole PointerError = 0
 )
 
+// PointerErrorNames maps the values of PointerError to their names.
+var PointerErrorNames = map[PointerError]string{
+	PointerErrorRole: "PointerErrorRole",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PointerError) String() string {
-	switch enum {
-	case 0:
-		return "PointerErrorRole"
-	}
+	return wire.EnumString(enum, PointerErrorNames)
+}
 
-	return "<invalid PointerError>"
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerError) Since() uint32 {
+	return 1
 }
 
 // Describes the physical state of a button that produced the button
@@ -5066,16 +5756,23 @@ const (
 	PointerButtonStatePressed PointerButtonState = 1
 )
 
-func (enum PointerButtonState) String() string {
-	switch enum {
-	case 0:
-		return "PointerButtonStateReleased"
+// PointerButtonStateNames maps the values of PointerButtonState to their names.
+var PointerButtonStateNames = map[PointerButtonState]string{
+	PointerButtonStateReleased: "PointerButtonStateReleased",
+	PointerButtonStatePressed:  "PointerButtonStatePressed",
+}
 
-	case 1:
-		return "PointerButtonStatePressed"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum PointerButtonState) String() string {
+	return wire.EnumString(enum, PointerButtonStateNames)
+}
 
-	return "<invalid PointerButtonState>"
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerButtonState) Since() uint32 {
+	return 1
 }
 
 // Describes the axis types of scroll events.
@@ -5089,16 +5786,23 @@ const (
 	PointerAxisHorizontalScroll PointerAxis = 1
 )
 
-func (enum PointerAxis) String() string {
-	switch enum {
-	case 0:
-		return "PointerAxisVerticalScroll"
+// PointerAxisNames maps the values of PointerAxis to their names.
+var PointerAxisNames = map[PointerAxis]string{
+	PointerAxisVerticalScroll:   "PointerAxisVerticalScroll",
+	PointerAxisHorizontalScroll: "PointerAxisHorizontalScroll",
+}
 
-	case 1:
-		return "PointerAxisHorizontalScroll"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum PointerAxis) String() string {
+	return wire.EnumString(enum, PointerAxisNames)
+}
 
-	return "<invalid PointerAxis>"
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerAxis) Since() uint32 {
+	return 1
 }
 
 // Describes the source types for axis events. This indicates to the
@@ -5130,25 +5834,34 @@ const (
 	PointerAxisSourceContinuous PointerAxisSource = 2
 
 	// a physical wheel tilt
+	//
+	// Since version 6.
 	PointerAxisSourceWheelTilt PointerAxisSource = 3
 )
 
-func (enum PointerAxisSource) String() string {
-	switch enum {
-	case 0:
-		return "PointerAxisSourceWheel"
-
-	case 1:
-		return "PointerAxisSourceFinger"
+// PointerAxisSourceNames maps the values of PointerAxisSource to their names.
+var PointerAxisSourceNames = map[PointerAxisSource]string{
+	PointerAxisSourceWheel:      "PointerAxisSourceWheel",
+	PointerAxisSourceFinger:     "PointerAxisSourceFinger",
+	PointerAxisSourceContinuous: "PointerAxisSourceContinuous",
+	PointerAxisSourceWheelTilt:  "PointerAxisSourceWheelTilt",
+}
 
-	case 2:
-		return "PointerAxisSourceContinuous"
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum PointerAxisSource) String() string {
+	return wire.EnumString(enum, PointerAxisSourceNames)
+}
 
+// Since returns the version of wl_pointer that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PointerAxisSource) Since() uint32 {
+	switch enum {
 	case 3:
-		return "PointerAxisSourceWheelTilt"
+		return 6
 	}
-
-	return "<invalid PointerAxisSource>"
+	return 1
 }
 
 const (
@@ -5162,30 +5875,36 @@ type KeyboardListener interface {
 	Release()
 }
 
+// KeyboardReleaseRequest holds the arguments of a wl_keyboard.release
+// request.
+type KeyboardReleaseRequest struct {
+}
+
 // The wl_keyboard interface represents one or more keyboards
 // associated with a seat.
 type Keyboard struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener KeyboardListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnRelease, if not nil, is called with the arguments of
+	// each incoming release request before Listener is.
+	OnRelease func(KeyboardReleaseRequest)
 }
 
+var (
+	_ wire.Object      = (*Keyboard)(nil)
+	_ wire.DebugObject = (*Keyboard)(nil)
+)
+
 // NewKeyboard returns a newly instantiated Keyboard. It is
 // primarily intended for use by generated code.
 func NewKeyboard(state wire.State) *Keyboard {
-	return &Keyboard{state: state}
-}
-
-func (obj *Keyboard) State() wire.State {
-	return obj.state
+	return &Keyboard{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
@@ -5195,6 +5914,9 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRelease != nil {
+			obj.OnRelease(KeyboardReleaseRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5209,22 +5931,8 @@ func (obj *Keyboard) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Keyboard) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Keyboard) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Keyboard) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Keyboard) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_keyboard", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_keyboard", obj.ID())
 }
 
 func (obj *Keyboard) MethodName(op uint16) string {
@@ -5240,7 +5948,19 @@ func (obj *Keyboard) Interface() string {
 	return KeyboardInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// KeyboardVersion, the same as MaxVersion.
 func (obj *Keyboard) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return KeyboardVersion
+}
+
+// MaxVersion returns KeyboardVersion, the highest version of
+// wl_keyboard that is supported.
+func (obj *Keyboard) MaxVersion() uint32 {
 	return KeyboardVersion
 }
 
@@ -5258,7 +5978,7 @@ func (obj *Keyboard) Keymap(format KeyboardKeymapFormat, fd *os.File, size uint3
 
 	builder.Method = "keymap"
 	builder.Args = []any{format, fd, size}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5274,9 +5994,11 @@ func (obj *Keyboard) Enter(serial uint32, surface *Surface, keys []byte) {
 	builder.WriteObject(surface)
 	builder.WriteArray(keys)
 
+	builder.Fail(wire.CheckMessageSize(KeyboardInterface, "enter", builder))
+
 	builder.Method = "enter"
 	builder.Args = []any{serial, surface, keys}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5296,7 +6018,7 @@ func (obj *Keyboard) Leave(serial uint32, surface *Surface) {
 
 	builder.Method = "leave"
 	builder.Args = []any{serial, surface}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5319,7 +6041,7 @@ func (obj *Keyboard) Key(serial uint32, time uint32, key uint32, state KeyboardK
 
 	builder.Method = "key"
 	builder.Args = []any{serial, time, key, state}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5336,7 +6058,7 @@ func (obj *Keyboard) Modifiers(serial uint32, modsDepressed uint32, modsLatched
 
 	builder.Method = "modifiers"
 	builder.Args = []any{serial, modsDepressed, modsLatched, modsLocked, group}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5360,7 +6082,7 @@ func (obj *Keyboard) RepeatInfo(rate int32, delay int32) {
 
 	builder.Method = "repeat_info"
 	builder.Args = []any{rate, delay}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5376,16 +6098,23 @@ const (
 	KeyboardKeymapFormatXkbV1 KeyboardKeymapFormat = 1
 )
 
-func (enum KeyboardKeymapFormat) String() string {
-	switch enum {
-	case 0:
-		return "KeyboardKeymapFormatNoKeymap"
+// KeyboardKeymapFormatNames maps the values of KeyboardKeymapFormat to their names.
+var KeyboardKeymapFormatNames = map[KeyboardKeymapFormat]string{
+	KeyboardKeymapFormatNoKeymap: "KeyboardKeymapFormatNoKeymap",
+	KeyboardKeymapFormatXkbV1:    "KeyboardKeymapFormatXkbV1",
+}
 
-	case 1:
-		return "KeyboardKeymapFormatXkbV1"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum KeyboardKeymapFormat) String() string {
+	return wire.EnumString(enum, KeyboardKeymapFormatNames)
+}
 
-	return "<invalid KeyboardKeymapFormat>"
+// Since returns the version of wl_keyboard that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum KeyboardKeymapFormat) Since() uint32 {
+	return 1
 }
 
 // Describes the physical state of a key that produced the key event.
@@ -5399,16 +6128,23 @@ const (
 	KeyboardKeyStatePressed KeyboardKeyState = 1
 )
 
-func (enum KeyboardKeyState) String() string {
-	switch enum {
-	case 0:
-		return "KeyboardKeyStateReleased"
+// KeyboardKeyStateNames maps the values of KeyboardKeyState to their names.
+var KeyboardKeyStateNames = map[KeyboardKeyState]string{
+	KeyboardKeyStateReleased: "KeyboardKeyStateReleased",
+	KeyboardKeyStatePressed:  "KeyboardKeyStatePressed",
+}
 
-	case 1:
-		return "KeyboardKeyStatePressed"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum KeyboardKeyState) String() string {
+	return wire.EnumString(enum, KeyboardKeyStateNames)
+}
 
-	return "<invalid KeyboardKeyState>"
+// Since returns the version of wl_keyboard that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum KeyboardKeyState) Since() uint32 {
+	return 1
 }
 
 const (
@@ -5422,6 +6158,11 @@ type TouchListener interface {
 	Release()
 }
 
+// TouchReleaseRequest holds the arguments of a wl_touch.release
+// request.
+type TouchReleaseRequest struct {
+}
+
 // The wl_touch interface represents a touchscreen
 // associated with a seat.
 //
@@ -5431,27 +6172,28 @@ type TouchListener interface {
 // and ending with an up event. Events relating to the same
 // contact point can be identified by the ID of the sequence.
 type Touch struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener TouchListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnRelease, if not nil, is called with the arguments of
+	// each incoming release request before Listener is.
+	OnRelease func(TouchReleaseRequest)
 }
 
+var (
+	_ wire.Object      = (*Touch)(nil)
+	_ wire.DebugObject = (*Touch)(nil)
+)
+
 // NewTouch returns a newly instantiated Touch. It is
 // primarily intended for use by generated code.
 func NewTouch(state wire.State) *Touch {
-	return &Touch{state: state}
-}
-
-func (obj *Touch) State() wire.State {
-	return obj.state
+	return &Touch{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
@@ -5461,6 +6203,9 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRelease != nil {
+			obj.OnRelease(TouchReleaseRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5475,22 +6220,8 @@ func (obj *Touch) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Touch) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Touch) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Touch) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Touch) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_touch", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_touch", obj.ID())
 }
 
 func (obj *Touch) MethodName(op uint16) string {
@@ -5506,7 +6237,19 @@ func (obj *Touch) Interface() string {
 	return TouchInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// TouchVersion, the same as MaxVersion.
 func (obj *Touch) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return TouchVersion
+}
+
+// MaxVersion returns TouchVersion, the highest version of
+// wl_touch that is supported.
+func (obj *Touch) MaxVersion() uint32 {
 	return TouchVersion
 }
 
@@ -5526,7 +6269,7 @@ func (obj *Touch) Down(serial uint32, time uint32, surface *Surface, id int32, x
 
 	builder.Method = "down"
 	builder.Args = []any{serial, time, surface, id, x, y}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5542,7 +6285,7 @@ func (obj *Touch) Up(serial uint32, time uint32, id int32) {
 
 	builder.Method = "up"
 	builder.Args = []any{serial, time, id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5557,7 +6300,7 @@ func (obj *Touch) Motion(time uint32, id int32, x wire.Fixed, y wire.Fixed) {
 
 	builder.Method = "motion"
 	builder.Args = []any{time, id, x, y}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5574,7 +6317,7 @@ func (obj *Touch) Frame() {
 
 	builder.Method = "frame"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5589,7 +6332,7 @@ func (obj *Touch) Cancel() {
 
 	builder.Method = "cancel"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5627,7 +6370,7 @@ func (obj *Touch) Shape(id int32, major wire.Fixed, minor wire.Fixed) {
 
 	builder.Method = "shape"
 	builder.Args = []any{id, major, minor}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5662,7 +6405,7 @@ func (obj *Touch) Orientation(id int32, orientation wire.Fixed) {
 
 	builder.Method = "orientation"
 	builder.Args = []any{id, orientation}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5679,6 +6422,11 @@ type OutputListener interface {
 	Release()
 }
 
+// OutputReleaseRequest holds the arguments of a wl_output.release
+// request.
+type OutputReleaseRequest struct {
+}
+
 // An output describes part of the compositor geometry.  The
 // compositor works in the 'compositor coordinate system' and an
 // output corresponds to a rectangular area in that space that is
@@ -5686,34 +6434,44 @@ type OutputListener interface {
 // displays part of the compositor space.  This object is published
 // as global during start up, or when a monitor is hotplugged.
 type Output struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener OutputListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnRelease, if not nil, is called with the arguments of
+	// each incoming release request before Listener is.
+	OnRelease func(OutputReleaseRequest)
 }
 
+var (
+	_ wire.Object      = (*Output)(nil)
+	_ wire.DebugObject = (*Output)(nil)
+)
+
 // NewOutput returns a newly instantiated Output. It is
 // primarily intended for use by generated code.
 func NewOutput(state wire.State) *Output {
-	return &Output{state: state}
+	return &Output{Proxy: wire.NewProxy(state)}
 }
 
-func BindOutput(state wire.State, id wire.NewID) *Output {
+// BindOutput creates a new Output for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindOutput(state wire.State, id wire.NewID) (*Output, error) {
+	if err := id.Check(OutputInterface, OutputVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewOutput(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *Output) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
@@ -5723,6 +6481,9 @@ func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRelease != nil {
+			obj.OnRelease(OutputReleaseRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -5737,22 +6498,8 @@ func (obj *Output) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Output) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Output) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Output) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Output) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_output", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_output", obj.ID())
 }
 
 func (obj *Output) MethodName(op uint16) string {
@@ -5768,7 +6515,19 @@ func (obj *Output) Interface() string {
 	return OutputInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// OutputVersion, the same as MaxVersion.
 func (obj *Output) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return OutputVersion
+}
+
+// MaxVersion returns OutputVersion, the highest version of
+// wl_output that is supported.
+func (obj *Output) MaxVersion() uint32 {
 	return OutputVersion
 }
 
@@ -5797,9 +6556,11 @@ func (obj *Output) Geometry(x int32, y int32, physicalWidth int32, physicalHeigh
 	builder.WriteString(model)
 	builder.WriteInt(int32(transform))
 
+	builder.Fail(wire.CheckMessageSize(OutputInterface, "geometry", builder))
+
 	builder.Method = "geometry"
 	builder.Args = []any{x, y, physicalWidth, physicalHeight, subpixel, make, model, transform}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5843,7 +6604,7 @@ func (obj *Output) Mode(flags OutputMode, width int32, height int32, refresh int
 
 	builder.Method = "mode"
 	builder.Args = []any{flags, width, height, refresh}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5857,7 +6618,7 @@ func (obj *Output) Done() {
 
 	builder.Method = "done"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5886,7 +6647,7 @@ func (obj *Output) Scale(factor int32) {
 
 	builder.Method = "scale"
 	builder.Args = []any{factor}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -5914,28 +6675,27 @@ const (
 	OutputSubpixelVerticalBgr OutputSubpixel = 5
 )
 
-func (enum OutputSubpixel) String() string {
-	switch enum {
-	case 0:
-		return "OutputSubpixelUnknown"
-
-	case 1:
-		return "OutputSubpixelNone"
-
-	case 2:
-		return "OutputSubpixelHorizontalRgb"
-
-	case 3:
-		return "OutputSubpixelHorizontalBgr"
-
-	case 4:
-		return "OutputSubpixelVerticalRgb"
+// OutputSubpixelNames maps the values of OutputSubpixel to their names.
+var OutputSubpixelNames = map[OutputSubpixel]string{
+	OutputSubpixelUnknown:       "OutputSubpixelUnknown",
+	OutputSubpixelNone:          "OutputSubpixelNone",
+	OutputSubpixelHorizontalRgb: "OutputSubpixelHorizontalRgb",
+	OutputSubpixelHorizontalBgr: "OutputSubpixelHorizontalBgr",
+	OutputSubpixelVerticalRgb:   "OutputSubpixelVerticalRgb",
+	OutputSubpixelVerticalBgr:   "OutputSubpixelVerticalBgr",
+}
 
-	case 5:
-		return "OutputSubpixelVerticalBgr"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum OutputSubpixel) String() string {
+	return wire.EnumString(enum, OutputSubpixelNames)
+}
 
-	return "<invalid OutputSubpixel>"
+// Since returns the version of wl_output that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum OutputSubpixel) Since() uint32 {
+	return 1
 }
 
 // This describes the transform that a compositor will apply to a
@@ -5977,34 +6737,29 @@ const (
 	OutputTransformFlipped270 OutputTransform = 7
 )
 
-func (enum OutputTransform) String() string {
-	switch enum {
-	case 0:
-		return "OutputTransformNormal"
-
-	case 1:
-		return "OutputTransform90"
-
-	case 2:
-		return "OutputTransform180"
-
-	case 3:
-		return "OutputTransform270"
-
-	case 4:
-		return "OutputTransformFlipped"
-
-	case 5:
-		return "OutputTransformFlipped90"
-
-	case 6:
-		return "OutputTransformFlipped180"
+// OutputTransformNames maps the values of OutputTransform to their names.
+var OutputTransformNames = map[OutputTransform]string{
+	OutputTransformNormal:     "OutputTransformNormal",
+	OutputTransform90:         "OutputTransform90",
+	OutputTransform180:        "OutputTransform180",
+	OutputTransform270:        "OutputTransform270",
+	OutputTransformFlipped:    "OutputTransformFlipped",
+	OutputTransformFlipped90:  "OutputTransformFlipped90",
+	OutputTransformFlipped180: "OutputTransformFlipped180",
+	OutputTransformFlipped270: "OutputTransformFlipped270",
+}
 
-	case 7:
-		return "OutputTransformFlipped270"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum OutputTransform) String() string {
+	return wire.EnumString(enum, OutputTransformNames)
+}
 
-	return "<invalid OutputTransform>"
+// Since returns the version of wl_output that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum OutputTransform) Since() uint32 {
+	return 1
 }
 
 // These flags describe properties of an output mode.
@@ -6019,16 +6774,23 @@ const (
 	OutputModePreferred OutputMode = 2
 )
 
-func (enum OutputMode) String() string {
-	switch enum {
-	case 1:
-		return "OutputModeCurrent"
+// OutputModeNames maps the values of OutputMode to their names.
+var OutputModeNames = map[OutputMode]string{
+	OutputModeCurrent:   "OutputModeCurrent",
+	OutputModePreferred: "OutputModePreferred",
+}
 
-	case 2:
-		return "OutputModePreferred"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum OutputMode) String() string {
+	return wire.FlagString(enum, OutputModeNames)
+}
 
-	return "<invalid OutputMode>"
+// Since returns the version of wl_output that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum OutputMode) Since() uint32 {
+	return 1
 }
 
 const (
@@ -6049,32 +6811,64 @@ type RegionListener interface {
 	Subtract(x int32, y int32, width int32, height int32)
 }
 
+// RegionDestroyRequest holds the arguments of a wl_region.destroy
+// request.
+type RegionDestroyRequest struct {
+}
+
+// RegionAddRequest holds the arguments of a wl_region.add
+// request.
+type RegionAddRequest struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
+// RegionSubtractRequest holds the arguments of a wl_region.subtract
+// request.
+type RegionSubtractRequest struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
 // A region object describes an area.
 //
 // Region objects are used to describe the opaque and input
 // regions of a surface.
 type Region struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener RegionListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(RegionDestroyRequest)
 
-	state wire.State
-	id    uint32
+	// OnAdd, if not nil, is called with the arguments of
+	// each incoming add request before Listener is.
+	OnAdd func(RegionAddRequest)
+
+	// OnSubtract, if not nil, is called with the arguments of
+	// each incoming subtract request before Listener is.
+	OnSubtract func(RegionSubtractRequest)
 }
 
+var (
+	_ wire.Object      = (*Region)(nil)
+	_ wire.DebugObject = (*Region)(nil)
+)
+
 // NewRegion returns a newly instantiated Region. It is
 // primarily intended for use by generated code.
 func NewRegion(state wire.State) *Region {
-	return &Region{state: state}
-}
-
-func (obj *Region) State() wire.State {
-	return obj.state
+	return &Region{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Region) Dispatch(msg *wire.MessageBuffer) error {
@@ -6084,6 +6878,9 @@ func (obj *Region) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(RegionDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6104,6 +6901,14 @@ func (obj *Region) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAdd != nil {
+			obj.OnAdd(RegionAddRequest{
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6129,6 +6934,14 @@ func (obj *Region) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSubtract != nil {
+			obj.OnSubtract(RegionSubtractRequest{
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6148,22 +6961,8 @@ func (obj *Region) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Region) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Region) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Region) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Region) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_region", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_region", obj.ID())
 }
 
 func (obj *Region) MethodName(op uint16) string {
@@ -6185,7 +6984,19 @@ func (obj *Region) Interface() string {
 	return RegionInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// RegionVersion, the same as MaxVersion.
 func (obj *Region) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return RegionVersion
+}
+
+// MaxVersion returns RegionVersion, the highest version of
+// wl_region that is supported.
+func (obj *Region) MaxVersion() uint32 {
 	return RegionVersion
 }
 
@@ -6220,6 +7031,19 @@ type SubcompositorListener interface {
 	GetSubsurface(id *Subsurface, surface *Surface, parent *Surface)
 }
 
+// SubcompositorDestroyRequest holds the arguments of a wl_subcompositor.destroy
+// request.
+type SubcompositorDestroyRequest struct {
+}
+
+// SubcompositorGetSubsurfaceRequest holds the arguments of a wl_subcompositor.get_subsurface
+// request.
+type SubcompositorGetSubsurfaceRequest struct {
+	Id      *Subsurface
+	Surface *Surface
+	Parent  *Surface
+}
+
 // The global interface exposing sub-surface compositing capabilities.
 // A wl_surface, that has sub-surfaces associated, is called the
 // parent surface. Sub-surfaces can be arbitrarily nested and create
@@ -6240,34 +7064,48 @@ type SubcompositorListener interface {
 // objects. This should allow the compositor to pass YUV video buffer
 // processing to dedicated overlay hardware when possible.
 type Subcompositor struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SubcompositorListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(SubcompositorDestroyRequest)
 
-	state wire.State
-	id    uint32
+	// OnGetSubsurface, if not nil, is called with the arguments of
+	// each incoming get_subsurface request before Listener is.
+	OnGetSubsurface func(SubcompositorGetSubsurfaceRequest)
 }
 
+var (
+	_ wire.Object      = (*Subcompositor)(nil)
+	_ wire.DebugObject = (*Subcompositor)(nil)
+)
+
 // NewSubcompositor returns a newly instantiated Subcompositor. It is
 // primarily intended for use by generated code.
 func NewSubcompositor(state wire.State) *Subcompositor {
-	return &Subcompositor{state: state}
+	return &Subcompositor{Proxy: wire.NewProxy(state)}
 }
 
-func BindSubcompositor(state wire.State, id wire.NewID) *Subcompositor {
+// BindSubcompositor creates a new Subcompositor for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindSubcompositor(state wire.State, id wire.NewID) (*Subcompositor, error) {
+	if err := id.Check(SubcompositorInterface, SubcompositorVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewSubcompositor(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *Subcompositor) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *Subcompositor) Dispatch(msg *wire.MessageBuffer) error {
@@ -6277,6 +7115,9 @@ func (obj *Subcompositor) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(SubcompositorDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6285,23 +7126,26 @@ func (obj *Subcompositor) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		id := NewSubsurface(obj.state)
+		id := NewSubsurface(obj.State())
 		id.SetID(msg.ReadUint())
+		id.SetVersion(obj.Version())
 
-		obj.state.Add(id)
-
-		surface, _ := obj.state.Get(msg.ReadUint()).(*Surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
-		obj.state.Add(surface)
-
-		parent, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(parent)
+		parent, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetSubsurface != nil {
+			obj.OnGetSubsurface(SubcompositorGetSubsurfaceRequest{
+				Id:      id,
+				Surface: surface,
+				Parent:  parent,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6320,22 +7164,8 @@ func (obj *Subcompositor) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Subcompositor) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Subcompositor) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Subcompositor) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Subcompositor) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_subcompositor", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_subcompositor", obj.ID())
 }
 
 func (obj *Subcompositor) MethodName(op uint16) string {
@@ -6354,7 +7184,19 @@ func (obj *Subcompositor) Interface() string {
 	return SubcompositorInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SubcompositorVersion, the same as MaxVersion.
 func (obj *Subcompositor) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SubcompositorVersion
+}
+
+// MaxVersion returns SubcompositorVersion, the highest version of
+// wl_subcompositor that is supported.
+func (obj *Subcompositor) MaxVersion() uint32 {
 	return SubcompositorVersion
 }
 
@@ -6365,13 +7207,22 @@ const (
 	SubcompositorErrorBadSurface SubcompositorError = 0
 )
 
+// SubcompositorErrorNames maps the values of SubcompositorError to their names.
+var SubcompositorErrorNames = map[SubcompositorError]string{
+	SubcompositorErrorBadSurface: "SubcompositorErrorBadSurface",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum SubcompositorError) String() string {
-	switch enum {
-	case 0:
-		return "SubcompositorErrorBadSurface"
-	}
+	return wire.EnumString(enum, SubcompositorErrorNames)
+}
 
-	return "<invalid SubcompositorError>"
+// Since returns the version of wl_subcompositor that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SubcompositorError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -6465,6 +7316,40 @@ type SubsurfaceListener interface {
 	SetDesync()
 }
 
+// SubsurfaceDestroyRequest holds the arguments of a wl_subsurface.destroy
+// request.
+type SubsurfaceDestroyRequest struct {
+}
+
+// SubsurfaceSetPositionRequest holds the arguments of a wl_subsurface.set_position
+// request.
+type SubsurfaceSetPositionRequest struct {
+	X int32
+	Y int32
+}
+
+// SubsurfacePlaceAboveRequest holds the arguments of a wl_subsurface.place_above
+// request.
+type SubsurfacePlaceAboveRequest struct {
+	Sibling *Surface
+}
+
+// SubsurfacePlaceBelowRequest holds the arguments of a wl_subsurface.place_below
+// request.
+type SubsurfacePlaceBelowRequest struct {
+	Sibling *Surface
+}
+
+// SubsurfaceSetSyncRequest holds the arguments of a wl_subsurface.set_sync
+// request.
+type SubsurfaceSetSyncRequest struct {
+}
+
+// SubsurfaceSetDesyncRequest holds the arguments of a wl_subsurface.set_desync
+// request.
+type SubsurfaceSetDesyncRequest struct {
+}
+
 // An additional interface to a wl_surface object, which has been
 // made a sub-surface. A sub-surface has one parent surface. A
 // sub-surface's size and position are not limited to that of the parent.
@@ -6515,27 +7400,48 @@ type SubsurfaceListener interface {
 // If the parent wl_surface object is destroyed, the sub-surface is
 // unmapped.
 type Subsurface struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SubsurfaceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(SubsurfaceDestroyRequest)
+
+	// OnSetPosition, if not nil, is called with the arguments of
+	// each incoming set_position request before Listener is.
+	OnSetPosition func(SubsurfaceSetPositionRequest)
 
-	state wire.State
-	id    uint32
+	// OnPlaceAbove, if not nil, is called with the arguments of
+	// each incoming place_above request before Listener is.
+	OnPlaceAbove func(SubsurfacePlaceAboveRequest)
+
+	// OnPlaceBelow, if not nil, is called with the arguments of
+	// each incoming place_below request before Listener is.
+	OnPlaceBelow func(SubsurfacePlaceBelowRequest)
+
+	// OnSetSync, if not nil, is called with the arguments of
+	// each incoming set_sync request before Listener is.
+	OnSetSync func(SubsurfaceSetSyncRequest)
+
+	// OnSetDesync, if not nil, is called with the arguments of
+	// each incoming set_desync request before Listener is.
+	OnSetDesync func(SubsurfaceSetDesyncRequest)
 }
 
+var (
+	_ wire.Object      = (*Subsurface)(nil)
+	_ wire.DebugObject = (*Subsurface)(nil)
+)
+
 // NewSubsurface returns a newly instantiated Subsurface. It is
 // primarily intended for use by generated code.
 func NewSubsurface(state wire.State) *Subsurface {
-	return &Subsurface{state: state}
-}
-
-func (obj *Subsurface) State() wire.State {
-	return obj.state
+	return &Subsurface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
@@ -6545,6 +7451,9 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(SubsurfaceDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6561,6 +7470,12 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetPosition != nil {
+			obj.OnSetPosition(SubsurfaceSetPositionRequest{
+				X: x,
+				Y: y,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6572,14 +7487,17 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 2:
 
-		sibling, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(sibling)
+		sibling, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnPlaceAbove != nil {
+			obj.OnPlaceAbove(SubsurfacePlaceAboveRequest{
+				Sibling: sibling,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6590,14 +7508,17 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 3:
 
-		sibling, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(sibling)
+		sibling, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnPlaceBelow != nil {
+			obj.OnPlaceBelow(SubsurfacePlaceBelowRequest{
+				Sibling: sibling,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6611,6 +7532,9 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetSync != nil {
+			obj.OnSetSync(SubsurfaceSetSyncRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6622,6 +7546,9 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetDesync != nil {
+			obj.OnSetDesync(SubsurfaceSetDesyncRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -6636,22 +7563,8 @@ func (obj *Subsurface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Subsurface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Subsurface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Subsurface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Subsurface) String() string {
-	return fmt.Sprintf("%v(%v)", "wl_subsurface", obj.id)
+	return fmt.Sprintf("%v@%v", "wl_subsurface", obj.ID())
 }
 
 func (obj *Subsurface) MethodName(op uint16) string {
@@ -6682,7 +7595,19 @@ func (obj *Subsurface) Interface() string {
 	return SubsurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SubsurfaceVersion, the same as MaxVersion.
 func (obj *Subsurface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SubsurfaceVersion
+}
+
+// MaxVersion returns SubsurfaceVersion, the highest version of
+// wl_subsurface that is supported.
+func (obj *Subsurface) MaxVersion() uint32 {
 	return SubsurfaceVersion
 }
 
@@ -6693,11 +7618,20 @@ const (
 	SubsurfaceErrorBadSurface SubsurfaceError = 0
 )
 
+// SubsurfaceErrorNames maps the values of SubsurfaceError to their names.
+var SubsurfaceErrorNames = map[SubsurfaceError]string{
+	SubsurfaceErrorBadSurface: "SubsurfaceErrorBadSurface",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum SubsurfaceError) String() string {
-	switch enum {
-	case 0:
-		return "SubsurfaceErrorBadSurface"
-	}
+	return wire.EnumString(enum, SubsurfaceErrorNames)
+}
 
-	return "<invalid SubsurfaceError>"
+// Since returns the version of wl_subsurface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SubsurfaceError) Since() uint32 {
+	return 1
 }
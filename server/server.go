@@ -9,7 +9,7 @@ import (
 	"deedles.dev/wl/wire"
 )
 
-//go:generate go run deedles.dev/wl/cmd/wlgen -out protocol.go -xml ../protocol/wayland.xml
+//go:generate go run deedles.dev/wl/cmd/wlgen -role server -out protocol.go -builtin wayland
 
 // Server serves the Wayland protocol.
 type Server struct {
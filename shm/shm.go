@@ -2,6 +2,7 @@
 package shm
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -28,6 +29,46 @@ func Create() (*os.File, error) {
 	return file, err
 }
 
+// CreateAnonymousFile returns a new file of the given size that
+// exists only in memory and can be mmapped to share memory with
+// another process, such as by sending it with wl_shm.create_pool. The
+// file is created with memfd_create if possible. If that isn't
+// supported, an unnamed temporary file is created in
+// $XDG_RUNTIME_DIR, falling back to /dev/shm, instead. Either way, the
+// file has close-on-exec set and disappears once it has been closed
+// and unmapped everywhere.
+func CreateAnonymousFile(size int) (*os.File, error) {
+	file, err := createAnonymousFile()
+	if err != nil {
+		return nil, err
+	}
+
+	err = file.Truncate(int64(size))
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("truncate file: %w", err)
+	}
+
+	return file, nil
+}
+
+func createAnonymousFile() (*os.File, error) {
+	fd, err := unix.MemfdCreate("wl-shm", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err == nil {
+		return os.NewFile(uintptr(fd), "wl-shm"), nil
+	}
+
+	dir, ok := os.LookupEnv("XDG_RUNTIME_DIR")
+	if !ok {
+		dir = "/dev/shm"
+	}
+	fd, terr := unix.Open(dir, unix.O_TMPFILE|unix.O_RDWR|unix.O_CLOEXEC, 0600)
+	if terr != nil {
+		return nil, fmt.Errorf("create file: memfd_create: %w, O_TMPFILE in %v: %w", err, dir, terr)
+	}
+	return os.NewFile(uintptr(fd), dir), nil
+}
+
 // Mmap is a []byte that represents a mmapped file.
 type Mmap []byte
 
@@ -37,17 +78,22 @@ func mmap(file *os.File, size, prot, flags int) (mmap Mmap, err error) {
 		return nil, err
 	}
 
-	sc.Control(func(fd uintptr) {
+	cerr := sc.Control(func(fd uintptr) {
 		m, merr := unix.Mmap(int(fd), 0, size, prot, flags)
 		mmap, err = Mmap(m), merr
 	})
+	if cerr != nil {
+		return nil, cerr
+	}
 
 	return mmap, err
 }
 
 // MapPrivate maps file into memory at the given size with the given
 // prot. It maps it in private mode, meaning that writes to the memory
-// will not be reflected in the file.
+// will not be reflected in the file. This is the mode required for
+// mapping keymaps received via wl_keyboard.keymap, usually with a
+// prot of unix.PROT_READ.
 func MapPrivate(file *os.File, size int, prot int) (Mmap, error) {
 	return mmap(file, size, prot, unix.MAP_PRIVATE)
 }
@@ -63,3 +109,19 @@ func MapShared(file *os.File, size int, prot int) (Mmap, error) {
 func (mmap Mmap) Unmap() error {
 	return unix.Munmap(mmap)
 }
+
+// MmapFile maps size bytes of f into memory read-only, as is needed
+// for reading a file received from the other side of a connection,
+// such as a keymap from wl_keyboard.keymap. The returned function
+// unmaps the memory and then closes f. If mapping fails, f is left
+// open.
+func MmapFile(f *os.File, size int) ([]byte, func() error, error) {
+	mmap, err := MapPrivate(f, size, unix.PROT_READ)
+	if err != nil {
+		return nil, nil, fmt.Errorf("mmap: %w", err)
+	}
+
+	return mmap, func() error {
+		return errors.Join(mmap.Unmap(), f.Close())
+	}, nil
+}
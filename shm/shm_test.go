@@ -0,0 +1,93 @@
+package shm
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestCreateAnonymousFile(t *testing.T) {
+	const size = 3 * 4096
+
+	file, err := CreateAnonymousFile(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != size {
+		t.Fatalf("file size is %v, want %v", info.Size(), size)
+	}
+
+	flags, err := unix.FcntlInt(file.Fd(), unix.F_GETFD, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags&unix.FD_CLOEXEC == 0 {
+		t.Fatal("file does not have close-on-exec set")
+	}
+
+	data := []byte("hello, world")
+	if _, err := file.WriteAt(data, size-int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+
+	mmap, err := MapShared(file, size, unix.PROT_READ|unix.PROT_WRITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mmap.Unmap()
+
+	if got := mmap[size-len(data):]; !bytes.Equal(got, data) {
+		t.Fatalf("mapped data is %q, want %q", got, data)
+	}
+	if got := mmap[:len(data)]; !bytes.Equal(got, make([]byte, len(data))) {
+		t.Fatalf("start of file is %q, want zeroes", got)
+	}
+
+	// Writes through the mapping are visible through the file.
+	copy(mmap, "mapped")
+	buf := make([]byte, 6)
+	if _, err := file.ReadAt(buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "mapped" {
+		t.Fatalf("read %q from file, want %q", buf, "mapped")
+	}
+}
+
+func TestMmapFile(t *testing.T) {
+	data := []byte("xkb_keymap { };")
+
+	file, err := CreateAnonymousFile(len(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteAt(data, 0); err != nil {
+		file.Close()
+		t.Fatal(err)
+	}
+
+	mmap, closer, err := MmapFile(file, len(data))
+	if err != nil {
+		file.Close()
+		t.Fatal(err)
+	}
+	if !bytes.Equal(mmap, data) {
+		t.Fatalf("mapped data is %q, want %q", mmap, data)
+	}
+
+	if err := closer(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("file was not closed: %v", err)
+	}
+}
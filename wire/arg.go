@@ -0,0 +1,125 @@
+package wire
+
+import (
+	"fmt"
+	"os"
+)
+
+// ArgType is the type of a message argument, as declared by the type
+// attribute of an arg in a protocol XML file.
+type ArgType int
+
+const (
+	ArgInt ArgType = iota
+	ArgUint
+	ArgFixed
+	ArgString
+	ArgObject
+	ArgNewID
+	ArgArray
+	ArgFD
+)
+
+var argTypeNames = [...]string{
+	ArgInt:    "int",
+	ArgUint:   "uint",
+	ArgFixed:  "fixed",
+	ArgString: "string",
+	ArgObject: "object",
+	ArgNewID:  "new_id",
+	ArgArray:  "array",
+	ArgFD:     "fd",
+}
+
+// ParseArgType returns the ArgType with the given name as it appears
+// in protocol XML, such as "new_id".
+func ParseArgType(name string) (ArgType, error) {
+	for t, n := range argTypeNames {
+		if n == name {
+			return ArgType(t), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown argument type %q", name)
+}
+
+func (t ArgType) String() string {
+	if (t < 0) || (int(t) >= len(argTypeNames)) {
+		return fmt.Sprintf("<invalid ArgType %d>", int(t))
+	}
+	return argTypeNames[t]
+}
+
+// ReadArg reads a single argument of type t, for use by tools that
+// only learn a message's signature at run time. The returned value
+// has the type returned by the corresponding Read method, so, for
+// example, an ArgObject is returned as a uint32 and an ArgFD as an
+// *os.File. A new_id with an interface that is known from the
+// signature is sent as a plain object ID, so it should be read as an
+// ArgObject instead of an ArgNewID.
+//
+// As with the other Read methods, errors are reported by Err. If a
+// read fails or t is invalid, nil is returned.
+func (r *MessageBuffer) ReadArg(t ArgType) any {
+	var v any
+	switch t {
+	case ArgInt:
+		v = r.ReadInt()
+	case ArgUint:
+		v = r.ReadUint()
+	case ArgFixed:
+		v = r.ReadFixed()
+	case ArgString:
+		v = r.ReadString()
+	case ArgObject:
+		v = r.ReadObject()
+	case ArgNewID:
+		v = r.ReadNewID()
+	case ArgArray:
+		v = r.ReadArray()
+	case ArgFD:
+		v = r.ReadFile()
+	default:
+		if r.err == nil {
+			r.err = fmt.Errorf("invalid argument type %v", t)
+		}
+	}
+
+	if r.err != nil {
+		return nil
+	}
+	return v
+}
+
+// WriteArg writes v as a single argument, choosing how to encode it
+// based on its type. It is the inverse of ReadArg and accepts the
+// types that that returns, along with *string for nullable strings,
+// Object for objects, and []string for string arrays. Any other type
+// causes an error to be returned by Build.
+func (mb *MessageBuilder) WriteArg(v any) {
+	switch v := v.(type) {
+	case int32:
+		mb.WriteInt(v)
+	case uint32:
+		mb.WriteUint(v)
+	case Fixed:
+		mb.WriteFixed(v)
+	case string:
+		mb.WriteString(v)
+	case *string:
+		mb.WriteNullableString(v)
+	case Object:
+		mb.WriteObject(v)
+	case NewID:
+		mb.WriteNewID(v)
+	case []byte:
+		mb.WriteArray(v)
+	case []string:
+		mb.WriteStringArray(v)
+	case *os.File:
+		mb.WriteFile(v)
+	default:
+		if mb.err == nil {
+			mb.err = fmt.Errorf("unsupported argument type %T", v)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package wire
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestArgRoundTrip(t *testing.T) {
+	r, _ := newPipe(t)
+
+	tests := []struct {
+		t     ArgType
+		write any
+		want  any
+	}{
+		{ArgInt, int32(-12), int32(-12)},
+		{ArgUint, uint32(0xDEADBEEF), uint32(0xDEADBEEF)},
+		{ArgFixed, FixedFromFloat(-2.5), FixedFromFloat(-2.5)},
+		{ArgString, "hello", "hello"},
+		{ArgObject, testObject(7), uint32(7)},
+		{ArgNewID, NewID{Interface: "wl_seat", Version: 7, ID: 9}, NewID{Interface: "wl_seat", Version: 7, ID: 9}},
+		{ArgArray, []byte{1, 2, 3}, []byte{1, 2, 3}},
+		{ArgFD, r, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.t.String(), func(t *testing.T) {
+			if pt, err := ParseArgType(test.t.String()); (err != nil) || (pt != test.t) {
+				t.Fatalf("ParseArgType(%q) = %v, %v", test.t, pt, err)
+			}
+
+			client, server := newConnPair(t)
+
+			mb := NewMessage(testObject(3), 0)
+			mb.WriteArg(test.write)
+			if err := mb.Build(client); err != nil {
+				t.Fatal(err)
+			}
+
+			msg, err := ReadMessage(server)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := msg.ReadArg(test.t)
+			if err := msg.Verify(); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.t == ArgFD {
+				file := got.(*os.File)
+				defer file.Close()
+				if inode(t, int(file.Fd())) != inode(t, int(r.Fd())) {
+					t.Fatal("received a different file")
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Fatalf("read %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestArgInvalid(t *testing.T) {
+	mb := NewMessage(testObject(3), 0)
+	mb.WriteArg(42)
+	if mb.err == nil {
+		t.Fatal("wrote an int argument")
+	}
+
+	msg, err := ReadMessageFrom(bytes.NewReader(rawMessage(3, 0, HeaderSize+4, 1, 0, 0, 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := msg.ReadArg(ArgType(99)); (v != nil) || (msg.Err() == nil) {
+		t.Fatalf("read %v, %v for an invalid argument type", v, msg.Err())
+	}
+	if _, err := ParseArgType("size_t"); err == nil {
+		t.Fatal("parsed an unknown argument type")
+	}
+}
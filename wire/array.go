@@ -0,0 +1,90 @@
+package wire
+
+import (
+	"fmt"
+
+	"deedles.dev/wl/internal/bin"
+)
+
+// ReadArrayOf reads an array argument that holds a packed sequence of
+// 32-bit values, such as the states in xdg_toplevel.configure. As
+// with all arrays, the length sent on the wire is the length of the
+// array's contents in bytes, not the number of elements, so it must
+// be a multiple of 4. Each word is reinterpreted as a T, so signed
+// types such as Fixed keep their sign. Types based on int64, such as
+// the enums in generated code, are given each word's value as an
+// unsigned 32-bit integer, the same as enum arguments of type uint.
+func ReadArrayOf[T Enum](r *MessageBuffer) []T {
+	data := r.ReadArray()
+	if r.err != nil {
+		return nil
+	}
+
+	v, err := DecodeArrayOf[T](data)
+	if err != nil {
+		r.err = err
+		return nil
+	}
+	return v
+}
+
+// DecodeArrayOf decodes the contents of an array argument that has
+// already been read, such as one in a generated event struct, the
+// same way that ReadArrayOf does.
+func DecodeArrayOf[T Enum](data []byte) ([]T, error) {
+	if len(data)%4 != 0 {
+		return nil, fmt.Errorf("array length %v is not a multiple of 4", len(data))
+	}
+
+	v := make([]T, len(data)/4)
+	for i := range v {
+		v[i] = T(bin.Value[uint32]([4]byte(data[i*4:])))
+	}
+	return v, nil
+}
+
+// WriteArrayOf writes v as an array argument holding a packed
+// sequence of 32-bit values. It is the inverse of ReadArrayOf.
+func WriteArrayOf[T Enum](mb *MessageBuilder, v []T) {
+	data := make([]byte, 0, 4*len(v))
+	for _, e := range v {
+		word := bin.Bytes(uint32(e))
+		data = append(data, word[:]...)
+	}
+	mb.WriteArray(data)
+}
+
+// ReadStringArray reads an array argument that holds a sequence of
+// strings. Each string in the array is encoded the same way that a
+// string argument is, with its own length and padding. The length of
+// the array itself is in bytes, as with any other array.
+func (r *MessageBuffer) ReadStringArray() []string {
+	data := r.ReadArray()
+	if r.err != nil {
+		return nil
+	}
+
+	sub := MessageBuffer{sender: r.sender, op: r.op, size: r.size, raw: data}
+	sub.data.Reset(data)
+
+	var v []string
+	for sub.data.Len() > 0 {
+		str := sub.ReadString()
+		if sub.err != nil {
+			r.err = fmt.Errorf("string %v in array: %w", len(v), sub.err)
+			return nil
+		}
+		v = append(v, str)
+	}
+	return v
+}
+
+// WriteStringArray writes v as an array argument holding a sequence
+// of strings. It is the inverse of ReadStringArray.
+func (mb *MessageBuilder) WriteStringArray(v []string) {
+	var sub MessageBuilder
+	for _, str := range v {
+		sub.WriteString(str)
+	}
+	mb.WriteArray(sub.data.Bytes())
+}
@@ -0,0 +1,175 @@
+package wire
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+type testState int64
+
+func testArrayRoundTrip[T Enum](t *testing.T, v []T) {
+	t.Helper()
+
+	mb := NewMessage(testObject(3), 0)
+	WriteArrayOf(mb, v)
+	mb.WriteUint(0xCAFE)
+
+	msg := decodeBuilt(t, mb)
+	got := ReadArrayOf[T](msg)
+	if tail := msg.ReadUint(); tail != 0xCAFE {
+		t.Fatalf("argument after the array is %#x", tail)
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, v) {
+		t.Fatalf("got %v, want %v", got, v)
+	}
+}
+
+func TestArrayOfRoundTrip(t *testing.T) {
+	t.Run("Int32", func(t *testing.T) {
+		testArrayRoundTrip(t, []int32{0, 1, -1, math.MaxInt32, math.MinInt32})
+	})
+	t.Run("Uint32", func(t *testing.T) {
+		testArrayRoundTrip(t, []uint32{0, 1, math.MaxUint32})
+	})
+	t.Run("Fixed", func(t *testing.T) {
+		testArrayRoundTrip(t, []Fixed{FixedFromFloat(1.5), FixedFromFloat(-1.5), FixedFromInt(-1 << 23)})
+	})
+	t.Run("Int64", func(t *testing.T) {
+		testArrayRoundTrip(t, []testState{0, 1, math.MaxUint32})
+	})
+	t.Run("Empty", func(t *testing.T) {
+		testArrayRoundTrip(t, []uint32{})
+	})
+}
+
+func TestArrayOfLength(t *testing.T) {
+	mb := NewMessage(testObject(3), 0)
+	WriteArrayOf(mb, []uint32{1, 2, 3})
+
+	// The length on the wire is in bytes, not elements.
+	msg := decodeBuilt(t, mb)
+	if n := msg.ReadUint(); n != 12 {
+		t.Fatalf("array length is %v, want 12", n)
+	}
+}
+
+func TestDecodeArrayOf(t *testing.T) {
+	data := []byte{
+		0xFF, 0xFF, 0xFF, 0xFF,
+		0x02, 0x00, 0x00, 0x00,
+	}
+
+	ints, err := DecodeArrayOf[int32](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ints, []int32{-1, 2}) {
+		t.Fatalf("got %v as int32", ints)
+	}
+
+	// Types based on int64 get the unsigned value of each word.
+	states, err := DecodeArrayOf[testState](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(states, []testState{math.MaxUint32, 2}) {
+		t.Fatalf("got %v as int64", states)
+	}
+
+	for _, n := range []int{1, 2, 3, 5, 7} {
+		if _, err := DecodeArrayOf[int32](data[:n]); err == nil {
+			t.Errorf("no error for length %v", n)
+		}
+	}
+}
+
+func TestReadArrayOfInvalidLength(t *testing.T) {
+	mb := NewMessage(testObject(3), 0)
+	mb.WriteArray([]byte{1, 2, 3, 4, 5, 6})
+
+	msg := decodeBuilt(t, mb)
+	if v := ReadArrayOf[uint32](msg); v != nil {
+		t.Fatalf("got %v from invalid array", v)
+	}
+	if msg.Err() == nil {
+		t.Fatal("no error for array length 6")
+	}
+}
+
+func TestStringArrayRoundTrip(t *testing.T) {
+	tests := [][]string{
+		nil,
+		{""},
+		{"a"},
+		{"abc", "abcd", "", "hello, world"},
+	}
+
+	for _, v := range tests {
+		mb := NewMessage(testObject(3), 0)
+		mb.WriteStringArray(v)
+		mb.WriteUint(0xCAFE)
+
+		msg := decodeBuilt(t, mb)
+		got := msg.ReadStringArray()
+		if tail := msg.ReadUint(); tail != 0xCAFE {
+			t.Fatalf("%q: argument after the array is %#x", v, tail)
+		}
+		if err := msg.Verify(); err != nil {
+			t.Fatalf("%q: %v", v, err)
+		}
+		if !slices.Equal(got, v) {
+			t.Fatalf("got %q, want %q", got, v)
+		}
+	}
+}
+
+func TestStringArrayInvalid(t *testing.T) {
+	// The string claims to be longer than the array that holds it.
+	mb := NewMessage(testObject(3), 0)
+	mb.WriteArray(stringArg(8, []byte("ab\x00")))
+
+	msg := decodeBuilt(t, mb)
+	if v := msg.ReadStringArray(); v != nil {
+		t.Fatalf("got %q from invalid array", v)
+	}
+	if msg.Err() == nil {
+		t.Fatal("no error for invalid string in array")
+	}
+}
+
+func TestStructSliceRoundTrip(t *testing.T) {
+	type args struct {
+		Ints   []int32
+		Uints  []uint32
+		Fixeds []Fixed
+		Name   string
+	}
+
+	in := args{
+		Ints:   []int32{-1, 0, math.MaxInt32},
+		Uints:  []uint32{math.MaxUint32},
+		Fixeds: []Fixed{FixedFromFloat(-2.5), FixedFromInt(7)},
+		Name:   "test",
+	}
+
+	mb := NewMessage(testObject(3), 0)
+	if err := mb.WriteStruct(in); err != nil {
+		t.Fatal(err)
+	}
+
+	var out args
+	msg := decodeBuilt(t, mb)
+	if err := msg.ReadStruct(&out); err != nil {
+		t.Fatal(err)
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(out.Ints, in.Ints) || !slices.Equal(out.Uints, in.Uints) || !slices.Equal(out.Fixeds, in.Fixeds) || (out.Name != in.Name) {
+		t.Fatalf("got %+v, want %+v", out, in)
+	}
+}
@@ -0,0 +1,258 @@
+package wire
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+var benchArray = make([]byte, 64)
+
+var benchArgs = []struct {
+	name  string
+	write func(*MessageBuilder)
+	read  func(*MessageBuffer)
+}{
+	{
+		name:  "Int",
+		write: func(mb *MessageBuilder) { mb.WriteInt(-3) },
+		read:  func(msg *MessageBuffer) { msg.ReadInt() },
+	},
+	{
+		name:  "Uint",
+		write: func(mb *MessageBuilder) { mb.WriteUint(3) },
+		read:  func(msg *MessageBuffer) { msg.ReadUint() },
+	},
+	{
+		name:  "Fixed",
+		write: func(mb *MessageBuilder) { mb.WriteFixed(FixedFromFloat(1.5)) },
+		read:  func(msg *MessageBuffer) { msg.ReadFixed() },
+	},
+	{
+		name: "FixedPair",
+		write: func(mb *MessageBuilder) {
+			mb.WriteFixed(FixedFromFloat(1.5))
+			mb.WriteFixed(FixedFromFloat(-2.25))
+		},
+		read: func(msg *MessageBuffer) { msg.ReadFixedPair() },
+	},
+	{
+		// The same as FixedPair, but read without ReadFixedPair, for
+		// comparison.
+		name: "FixedTwice",
+		write: func(mb *MessageBuilder) {
+			mb.WriteFixed(FixedFromFloat(1.5))
+			mb.WriteFixed(FixedFromFloat(-2.25))
+		},
+		read: func(msg *MessageBuffer) {
+			msg.ReadFixed()
+			msg.ReadFixed()
+		},
+	},
+	{
+		name:  "Object",
+		write: func(mb *MessageBuilder) { mb.WriteObject(testObject(5)) },
+		read:  func(msg *MessageBuffer) { msg.ReadObject() },
+	},
+	{
+		name:  "NewID",
+		write: func(mb *MessageBuilder) { mb.WriteNewID(NewID{Interface: "wl_compositor", Version: 4, ID: 5}) },
+		read:  func(msg *MessageBuffer) { msg.ReadNewID() },
+	},
+	{
+		name:  "String",
+		write: func(mb *MessageBuilder) { mb.WriteString("wl_compositor") },
+		read:  func(msg *MessageBuffer) { msg.ReadString() },
+	},
+	{
+		name:  "Array",
+		write: func(mb *MessageBuilder) { mb.WriteArray(benchArray) },
+		read:  func(msg *MessageBuffer) { msg.ReadArray() },
+	},
+}
+
+func BenchmarkEncode(b *testing.B) {
+	for _, arg := range benchArgs {
+		b.Run(arg.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			var buf bytes.Buffer
+			for b.Loop() {
+				mb := NewMessage(testObject(3), 0)
+				arg.write(mb)
+				mb.encode(&buf)
+				buf.Reset()
+			}
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	for _, arg := range benchArgs {
+		b.Run(arg.name, func(b *testing.B) {
+			mb := NewMessage(testObject(3), 0)
+			arg.write(mb)
+			msg := decodeBuilt(b, mb)
+
+			b.ReportAllocs()
+			for b.Loop() {
+				msg.Reset()
+				arg.read(msg)
+			}
+			if err := msg.Err(); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
+
+func BenchmarkReadString(b *testing.B) {
+	mb := NewMessage(testObject(3), 0)
+	for range 4 {
+		mb.WriteString("wl_compositor")
+	}
+	msg := decodeBuilt(b, mb)
+
+	var buf []byte
+	reads := []struct {
+		name string
+		read func()
+	}{
+		{"ReadString", func() { msg.ReadString() }},
+		{"ReadStringInto", func() { msg.ReadStringInto(&buf) }},
+		{"ReadStringUnsafe", func() { msg.ReadStringUnsafe() }},
+	}
+
+	for _, read := range reads {
+		b.Run(read.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				msg.Reset()
+				for range 4 {
+					read.read()
+				}
+			}
+			if err := msg.Verify(); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
+
+func BenchmarkConnRoundTrip(b *testing.B) {
+	client, server := newConnPair(b)
+
+	// The same arguments as a wl_registry.global event.
+	send := func() {
+		mb := NewMessage(testObject(2), 0)
+		mb.WriteUint(1)
+		mb.WriteString("wl_compositor")
+		mb.WriteUint(4)
+		if err := mb.Build(client); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		send()
+
+		msg, err := ReadMessage(server)
+		if err != nil {
+			b.Fatal(err)
+		}
+		msg.ReadUint()
+		msg.ReadString()
+		msg.ReadUint()
+		if err := msg.Verify(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadBuffered(b *testing.B) {
+	const batch = 64
+
+	for _, size := range []int{16, defaultReadBufferSize} {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			client, server := newConnPair(b)
+			server.SetReadBufferSize(size)
+
+			w := NewMessageWriter(client)
+			b.ReportAllocs()
+
+			var reads int
+			for b.Loop() {
+				for range batch {
+					mb := NewMessage(testObject(3), 0)
+					mb.WriteUint(1)
+					mb.WriteUint(2)
+					if err := w.Write(mb); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := w.Flush(); err != nil {
+					b.Fatal(err)
+				}
+
+				for range batch {
+					// All of the messages have already been sent and each
+					// one fits into the buffer, so reading one takes a
+					// system call exactly when it isn't buffered yet.
+					if !server.r.available() {
+						reads++
+					}
+					if _, err := ReadMessage(server); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			b.ReportMetric(float64(reads)/float64(b.N*batch), "reads/msg")
+		})
+	}
+}
+
+// BenchmarkBuild compares sending a batch of messages with one Build
+// each, and so one system call each, against buffering them with
+// BuildBuffered and sending them all with a single Flush.
+func BenchmarkBuild(b *testing.B) {
+	const batch = 64
+
+	builds := []struct {
+		name  string
+		build func(*MessageBuilder, *Conn) error
+		flush bool
+	}{
+		{name: "Build", build: (*MessageBuilder).Build},
+		{name: "BuildBuffered", build: (*MessageBuilder).BuildBuffered, flush: true},
+	}
+
+	for _, build := range builds {
+		b.Run(build.name, func(b *testing.B) {
+			client, server := newConnPair(b)
+			b.ReportAllocs()
+
+			for b.Loop() {
+				for range batch {
+					mb := NewMessage(testObject(3), 0)
+					mb.WriteUint(1)
+					mb.WriteUint(2)
+					if err := build.build(mb, client); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if build.flush {
+					if err := client.Flush(); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				for range batch {
+					if _, err := ReadMessage(server); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
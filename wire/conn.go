@@ -1,13 +1,18 @@
 package wire
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"deedles.dev/wl/internal/set"
 	"golang.org/x/sys/unix"
@@ -80,23 +85,92 @@ func NewSocketPath() (string, error) {
 // Conn represents a low-level Wayland connection. It is not generally
 // used directly, instead being handled automatically by a State
 // implementation.
+//
+// A Conn may be used from several goroutines at once. Reading and
+// writing can happen concurrently, so a dedicated goroutine can read
+// incoming messages while others send. Writes are serialized with
+// each other, so each message arrives intact, along with the file
+// descriptors attached to it, no matter how many goroutines send at
+// the same time.
+//
+// Messages can also be added to an output buffer with
+// MessageBuilder.BuildBuffered, in which case they are sent together
+// by as few system calls as possible once Flush is called or the
+// buffer fills up, similar to libwayland's handling of requests and
+// events.
 type Conn struct {
 	conn *net.UnixConn
-	fds  []int
+	wm   sync.Mutex
+	rm   sync.Mutex
+	r    connReader
+
+	om  sync.Mutex
+	out MessageWriter
+
+	fdm     sync.Mutex
+	fds     []int
+	fdLimit int
+
+	argLimit      atomic.Int64
+	idleTimeout   atomic.Int64
+	strictPadding atomic.Bool
+
+	recm sync.Mutex
+	rec  io.Writer
+
+	logm    sync.Mutex
+	logger  io.Writer
+	tracing atomic.Bool
 }
 
 // NewConn creates a new Conn that wraps c. After this is called, use
 // the provided Close method to close c instead of calling its own
 // Close method.
 func NewConn(c *net.UnixConn) *Conn {
-	return &Conn{
-		conn: c,
-	}
+	conn := Conn{conn: c}
+	conn.r.c = &conn
+	conn.out.conn = &conn
+	return &conn
 }
 
-// Close closes the underlying connection.
+// Close closes the underlying connection. It also closes all file
+// descriptors that have been received but not yet read from a
+// message, as well as those attached to buffered messages that have
+// not been flushed.
 func (c *Conn) Close() error {
-	return c.conn.Close()
+	c.om.Lock()
+	errs := []error{c.out.reset()}
+	c.om.Unlock()
+
+	c.fdm.Lock()
+	for _, fd := range c.fds {
+		errs = append(errs, unix.Close(fd))
+	}
+	c.fds = nil
+	c.fdm.Unlock()
+
+	errs = append(errs, c.conn.Close())
+	return errors.Join(errs...)
+}
+
+// Fd returns the file descriptor of the underlying socket so that it
+// can be registered with an external event loop, such as one based on
+// epoll, to find out when messages are available to be read with
+// ReadAvailable. The file descriptor remains owned by c and is only
+// valid until c is closed. It should not be read from or written to
+// directly. If c has already been closed, Fd returns -1.
+func (c *Conn) Fd() int {
+	raw, err := c.conn.SyscallConn()
+	if err != nil {
+		return -1
+	}
+
+	fd := -1
+	err = raw.Control(func(v uintptr) { fd = int(v) })
+	if err != nil {
+		return -1
+	}
+	return fd
 }
 
 func (c *Conn) LocalAddr() net.Addr {
@@ -107,50 +181,281 @@ func (c *Conn) RemoteAddr() net.Addr {
 	return c.conn.RemoteAddr()
 }
 
-func (c *Conn) readFDs(data []byte) error {
+// SetFDLimit sets the maximum number of received file descriptors
+// that may be waiting to be decoded at once. If that would be
+// exceeded, ReadMessage closes the newly received file descriptors
+// and returns an error wrapping ErrTooManyFDs, after which the
+// connection should be closed. A limit of zero or less, the default,
+// disables the check.
+func (c *Conn) SetFDLimit(n int) {
+	c.fdm.Lock()
+	defer c.fdm.Unlock()
+
+	c.fdLimit = n
+}
+
+// SetReadBufferSize sets the size, in bytes, of the buffer that data
+// read from the socket is stored in until it is decoded into
+// messages. All of the data that fits into the buffer is read at
+// once, so a larger buffer reduces the number of system calls needed
+// when many messages arrive in quick succession. The default is 4096
+// bytes. Messages larger than the buffer can still be read.
+func (c *Conn) SetReadBufferSize(n int) {
+	c.rm.Lock()
+	defer c.rm.Unlock()
+
+	c.r.resize(n)
+}
+
+// SetIdleTimeout sets the maximum amount of time that ReadMessage
+// and ReadMessageContext wait for a message to arrive before
+// returning ErrIdleTimeout. The timeout applies to each read
+// separately. Running into it does not affect the connection, so
+// reading can simply be resumed, possibly after sending a
+// wl_display.sync request to check if the remote end is still
+// responsive. A timeout of zero or less, the default, disables it.
+func (c *Conn) SetIdleTimeout(d time.Duration) {
+	c.idleTimeout.Store(int64(d))
+}
+
+// startIdleTimeout sets c's read deadline based on its idle timeout,
+// if it has one, and reports whether it did.
+func (c *Conn) startIdleTimeout() bool {
+	d := time.Duration(c.idleTimeout.Load())
+	if d <= 0 {
+		return false
+	}
+
+	c.conn.SetReadDeadline(time.Now().Add(d))
+	return true
+}
+
+// SetArgLimit sets the maximum length, in bytes, of string and array
+// arguments in messages read from c. Longer arguments fail to decode.
+// Independently of this limit, an argument can never be longer than
+// the rest of the message that contains it. A limit of zero or less,
+// the default, disables the check.
+func (c *Conn) SetArgLimit(n int) {
+	c.argLimit.Store(int64(n))
+}
+
+// SetStrictPadding sets whether the padding after string and array
+// arguments in messages read from c must be zero. The protocol
+// requires it to be, but by default the padding is ignored, as it
+// does not affect the decoded values. If strict checking is enabled,
+// arguments with nonzero padding fail to decode with
+// ErrNonzeroPadding, which can help to catch buggy peers.
+func (c *Conn) SetStrictPadding(strict bool) {
+	c.strictPadding.Store(strict)
+}
+
+// readFDs adds the file descriptors found in the socket control
+// messages in data to c's queue. It returns the number that were
+// added.
+func (c *Conn) readFDs(data []byte) (int, error) {
 	cmsgs, err := unix.ParseSocketControlMessage(data)
 	if err != nil {
-		return fmt.Errorf("parse socket control messages: %w", err)
+		return 0, fmt.Errorf("parse socket control messages: %w", err)
 	}
+
+	var received []int
 	for _, cmsg := range cmsgs {
 		fds, err := unix.ParseUnixRights(&cmsg)
 		if err != nil {
 			if errors.Is(err, unix.EINVAL) {
 				continue
 			}
-			return fmt.Errorf("parse unix control message: %w", err)
+			return 0, fmt.Errorf("parse unix control message: %w", err)
+		}
+		received = append(received, fds...)
+	}
+
+	c.fdm.Lock()
+	defer c.fdm.Unlock()
+
+	if (c.fdLimit > 0) && (len(c.fds)+len(received) > c.fdLimit) {
+		for _, fd := range received {
+			unix.Close(fd)
+		}
+		return 0, fmt.Errorf("%w: limit is %v", ErrTooManyFDs, c.fdLimit)
+	}
+
+	c.fds = append(c.fds, received...)
+	return len(received), nil
+}
+
+// queuedFDs returns the number of file descriptors in c's queue.
+func (c *Conn) queuedFDs() int {
+	c.fdm.Lock()
+	defer c.fdm.Unlock()
+
+	return len(c.fds)
+}
+
+// popFD removes the next file descriptor from c's queue.
+func (c *Conn) popFD() (int, bool) {
+	c.fdm.Lock()
+	defer c.fdm.Unlock()
+
+	return pop(&c.fds)
+}
+
+// writeMsg sends data to the remote end of c with fds attached. It
+// is safe to call concurrently.
+//
+// The file descriptors are always attached to the first system call,
+// which carries the start of data, the same as libwayland does. If
+// the kernel only accepts part of data, the rest is sent by further
+// calls without any file descriptors, so they can never end up
+// attached to a later part of the stream than the message that they
+// belong to.
+func (c *Conn) writeMsg(data []byte, fds []int) error {
+	var oob []byte
+	if len(fds) > 0 {
+		oob = unix.UnixRights(fds...)
+	}
+
+	c.wm.Lock()
+	defer c.wm.Unlock()
+
+	for {
+		n, _, err := c.conn.WriteMsgUnix(data, oob, nil)
+		if err != nil {
+			return err
 		}
-		c.fds = append(c.fds, fds...)
+
+		data, oob = data[n:], nil
+		if len(data) == 0 {
+			return nil
+		}
+	}
+}
+
+// Flush sends all of the messages in c's output buffer. The buffered
+// messages are discarded even if an error occurs. It is safe to call
+// concurrently.
+func (c *Conn) Flush() error {
+	c.om.Lock()
+	defer c.om.Unlock()
+
+	return c.out.Flush()
+}
+
+// flushLocked flushes c's output buffer if there is anything in it.
+// c.om must be held.
+func (c *Conn) flushLocked() error {
+	if c.out.Buffered() == 0 {
+		return nil
 	}
-	return nil
+	return c.out.Flush()
 }
 
 // Dial opens a connection to the Wayland socket based on the current
 // environment. It follows the procedure outlined at
 // https://wayland-book.com/protocol-design/wire-protocol.html#transports
+//
+// If $WAYLAND_SOCKET is set, it is used as the file descriptor of an
+// already connected socket, such as one inherited from a compositor
+// that launched the process. As with libwayland, the variable is
+// unset afterwards so that child processes don't try to use the same
+// socket, and the file descriptor is replaced by one with
+// close-on-exec set.
+//
+// Otherwise, the socket at SocketPath is connected to. If the socket
+// does not exist, the returned error wraps ErrNoCompositor. If it
+// exists but the connection is refused, the error wraps
+// ErrStaleSocket instead.
 func Dial() (*Conn, error) {
+	return DialContext(context.Background())
+}
+
+// DialContext is like Dial, but gives up on connecting to the socket
+// when ctx is done. Once the connection has been established, ctx has
+// no further effect on it.
+func DialContext(ctx context.Context) (*Conn, error) {
 	if v, ok := os.LookupEnv("WAYLAND_SOCKET"); ok {
+		os.Unsetenv("WAYLAND_SOCKET")
+
 		fd, err := strconv.ParseInt(v, 10, 0)
 		if err != nil {
 			return nil, fmt.Errorf("parse WAYLAND_SOCKET fd: %w", err)
 		}
-		file := os.NewFile(uintptr(fd), "WAYLAND_SOCKET")
-		defer file.Close()
 
-		c, err := net.FileConn(file)
+		c, err := fileConn(int(fd), "WAYLAND_SOCKET")
 		if err != nil {
 			return nil, fmt.Errorf("open WAYLAND_SOCKET connection: %w", err)
 		}
-		return NewConn(c.(*net.UnixConn)), nil // TODO: Make sure that this works.
+		return NewConn(c), nil
 	}
 
-	s, err := net.Dial("unix", SocketPath())
+	var d net.Dialer
+	s, err := d.DialContext(ctx, "unix", SocketPath())
 	if err != nil {
-		return nil, err
+		return nil, dialError(err)
 	}
 	return NewConn(s.(*net.UnixConn)), nil
 }
 
+// dialError wraps an error from dialing a socket so that the common
+// reasons for there being no compositor to connect to can be
+// distinguished using errors.Is.
+func dialError(err error) error {
+	switch {
+	case errors.Is(err, unix.ENOENT):
+		if _, ok := os.LookupEnv("WAYLAND_DISPLAY"); !ok {
+			return fmt.Errorf("%w (WAYLAND_DISPLAY is not set): %w", ErrNoCompositor, err)
+		}
+		return fmt.Errorf("%w: %w", ErrNoCompositor, err)
+	case errors.Is(err, unix.ECONNREFUSED):
+		return fmt.Errorf("%w: %w", ErrStaleSocket, err)
+	default:
+		return err
+	}
+}
+
+// SocketPair returns a pair of Unix domain sockets that are connected
+// to each other. It is mostly useful for testing.
+func SocketPair() (client, server *net.UnixConn, err error) {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, nil, os.NewSyscallError("socketpair", err)
+	}
+
+	client, err = fileConn(fds[0], "client")
+	if err != nil {
+		unix.Close(fds[1])
+		return nil, nil, err
+	}
+
+	server, err = fileConn(fds[1], "server")
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+
+	return client, server, nil
+}
+
+// fileConn creates a *net.UnixConn from a Unix domain socket file
+// descriptor. The file descriptor is closed, with the returned
+// connection using a duplicate of it with close-on-exec set instead.
+func fileConn(fd int, name string) (*net.UnixConn, error) {
+	file := os.NewFile(uintptr(fd), name)
+	defer file.Close()
+
+	c, err := net.FileConn(file)
+	if err != nil {
+		return nil, err
+	}
+
+	uc, ok := c.(*net.UnixConn)
+	if !ok {
+		c.Close()
+		return nil, fmt.Errorf("%v is not a Unix domain socket", name)
+	}
+	return uc, nil
+}
+
 // Listen generates a new socket from the environment and listens on
 // it.
 func Listen() (*net.UnixListener, error) {
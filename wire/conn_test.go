@@ -0,0 +1,390 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func newPipe(t testing.TB) (r, w *os.File) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.Close()
+		w.Close()
+	})
+	return r, w
+}
+
+// readEndOpen reports whether the read end of the pipe whose write end
+// is w is still open anywhere.
+func readEndOpen(w *os.File) bool {
+	_, err := w.Write([]byte{0})
+	return !errors.Is(err, unix.EPIPE)
+}
+
+// sendFD sends a message from client with the read end of a new pipe
+// attached and then closes the local copy of it, so that the one that
+// is sent is the only one left. It returns the pipe's write end.
+func sendFD(t *testing.T, client *Conn) *os.File {
+	t.Helper()
+
+	r, w := newPipe(t)
+	err := client.writeMsg(rawMessage(3, 0, HeaderSize), []int{int(r.Fd())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	return w
+}
+
+func TestFDLimit(t *testing.T) {
+	client, server := newConnPair(t)
+	server.SetFDLimit(2)
+
+	var pipes []*os.File
+	for range 3 {
+		pipes = append(pipes, sendFD(t, client))
+	}
+
+	for i := range 2 {
+		if _, err := ReadMessage(server); err != nil {
+			t.Fatalf("message %v: %v", i, err)
+		}
+	}
+	_, err := ReadMessage(server)
+	if !errors.Is(err, ErrTooManyFDs) {
+		t.Fatalf("expected ErrTooManyFDs, got %v", err)
+	}
+
+	if !readEndOpen(pipes[0]) || !readEndOpen(pipes[1]) {
+		t.Fatal("file descriptors within the limit were closed")
+	}
+	if readEndOpen(pipes[2]) {
+		t.Fatal("file descriptor over the limit was not closed")
+	}
+}
+
+func TestFDLimitConsumed(t *testing.T) {
+	client, server := newConnPair(t)
+	server.SetFDLimit(1)
+
+	// Decoded file descriptors no longer count towards the limit.
+	for i := range 5 {
+		sendFD(t, client)
+
+		msg, err := ReadMessage(server)
+		if err != nil {
+			t.Fatalf("message %v: %v", i, err)
+		}
+		unix.Close(msg.ReadFD())
+		if err := msg.Err(); err != nil {
+			t.Fatalf("message %v: %v", i, err)
+		}
+	}
+}
+
+func TestCloseClosesQueuedFDs(t *testing.T) {
+	client, server := newConnPair(t)
+
+	w := sendFD(t, client)
+	if _, err := ReadMessage(server); err != nil {
+		t.Fatal(err)
+	}
+	if !readEndOpen(w) {
+		t.Fatal("received file descriptor closed early")
+	}
+
+	server.Close()
+	if readEndOpen(w) {
+		t.Fatal("Close did not close the file descriptor that was never read")
+	}
+}
+
+func TestBuildBufferedCoalesces(t *testing.T) {
+	c, s, err := SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewConn(c)
+	t.Cleanup(func() {
+		client.Close()
+		s.Close()
+	})
+
+	// Each message has a file descriptor attached so that the kernel
+	// keeps separate writes apart when they are received.
+	r, _ := newPipe(t)
+	for op := range uint16(3) {
+		mb := NewMessage(testObject(3), op)
+		mb.WriteFD(int(r.Fd()))
+		if err := mb.BuildBuffered(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	if n, _, _, _, err := s.ReadMsgUnix(make([]byte, 64), nil); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("read %v bytes before flushing, error %v", n, err)
+	}
+	s.SetReadDeadline(time.Time{})
+
+	if err := client.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	data, fds := recvRaw(t, s)
+	if (len(data) != 3*HeaderSize) || (len(fds) != 3) {
+		t.Fatalf("flush sent %v bytes and %v file descriptors in the first write", len(data), len(fds))
+	}
+	for i := range 3 {
+		if _, op, _ := DecodeHeader(data[i*HeaderSize:]); int(op) != i {
+			t.Fatalf("message %v has opcode %v", i, op)
+		}
+	}
+
+	// Build sends anything that is buffered first.
+	mb := NewMessage(testObject(3), 3)
+	mb.WriteFD(int(r.Fd()))
+	if err := mb.BuildBuffered(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewMessage(testObject(3), 4).Build(client); err != nil {
+		t.Fatal(err)
+	}
+	data, fds = recvRaw(t, s)
+	if _, op, _ := DecodeHeader(data); (op != 3) || (len(data) != HeaderSize) || (len(fds) != 1) {
+		t.Fatalf("got %v bytes starting with message %v before the unbuffered message", len(data), op)
+	}
+	data, _ = recvRaw(t, s)
+	if _, op, _ := DecodeHeader(data); op != 4 {
+		t.Fatalf("got message %v, want 4", op)
+	}
+}
+
+func TestBuildBufferedAutoFlush(t *testing.T) {
+	client, server := newConnPair(t)
+
+	// Adding the last message would take the buffer over its size, so
+	// the ones before it are sent without waiting for a flush.
+	const size = 256
+	n := OutBufferSize / size
+	for op := range uint16(n + 1) {
+		if err := arrayMessage(op, size).BuildBuffered(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for op := range uint16(n) {
+		msg, err := ReadMessage(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Op() != op {
+			t.Fatalf("got message %v, want %v", msg.Op(), op)
+		}
+	}
+	msgs, err := ReadAvailable(server)
+	if (len(msgs) != 0) || (err != nil) {
+		t.Fatalf("got %v messages and error %v before flushing", len(msgs), err)
+	}
+	if got := client.out.Buffered(); got != size {
+		t.Fatalf("%v bytes buffered, want %v", got, size)
+	}
+}
+
+func TestBuildBufferedConcurrent(t *testing.T) {
+	const (
+		senders  = 8
+		messages = 200
+	)
+
+	client, server := newConnPair(t)
+
+	var wg sync.WaitGroup
+	for sender := range uint32(senders) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range uint32(messages) {
+				mb := NewMessage(testObject(sender+1), 0)
+				mb.WriteUint(i)
+				mb.WriteString(strings.Repeat("x", int(i%13)))
+				mb.WriteUint(sender)
+				if err := mb.BuildBuffered(client); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wg.Wait()
+		if err := client.Flush(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// Messages from each sender arrive whole and in order, however
+	// they are interleaved with each other.
+	next := make([]uint32, senders)
+	for range senders * messages {
+		msg, err := ReadMessage(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sender := msg.Sender() - 1
+		i, str, check := msg.ReadUint(), msg.ReadString(), msg.ReadUint()
+		if err := msg.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		if (check != sender) || (i != next[sender]) || (len(str) != int(i%13)) {
+			t.Fatalf("corrupted message from sender %v: %v, %q, %v", sender, i, str, check)
+		}
+		next[sender]++
+	}
+	<-done
+}
+
+// unsetenv unsets the environment variable key for the duration of
+// the test.
+func unsetenv(t *testing.T, key string) {
+	t.Helper()
+
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
+
+func TestDialError(t *testing.T) {
+	unsetenv(t, "WAYLAND_SOCKET")
+
+	t.Run("NoRuntimeDir", func(t *testing.T) {
+		unsetenv(t, "XDG_RUNTIME_DIR")
+		t.Setenv("WAYLAND_DISPLAY", fmt.Sprintf("wl-test-%v", os.Getpid()))
+
+		if dir := filepath.Dir(SocketPath()); dir != fmt.Sprintf("/var/run/user/%v", os.Getuid()) {
+			t.Fatalf("socket is in %v without XDG_RUNTIME_DIR", dir)
+		}
+		_, err := Dial()
+		if !errors.Is(err, ErrNoCompositor) {
+			t.Fatalf("expected ErrNoCompositor, got %v", err)
+		}
+	})
+
+	t.Run("NoDisplay", func(t *testing.T) {
+		t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+		unsetenv(t, "WAYLAND_DISPLAY")
+
+		_, err := Dial()
+		if !errors.Is(err, ErrNoCompositor) || !errors.Is(err, unix.ENOENT) {
+			t.Fatalf("expected ErrNoCompositor wrapping ENOENT, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "WAYLAND_DISPLAY is not set") {
+			t.Fatalf("error does not mention WAYLAND_DISPLAY: %v", err)
+		}
+	})
+
+	t.Run("StaleSocket", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XDG_RUNTIME_DIR", dir)
+		t.Setenv("WAYLAND_DISPLAY", "wayland-stale")
+
+		// Closing the listener without removing the socket leaves it
+		// behind the same way that a compositor that crashed would.
+		l, err := ListenPath(filepath.Join(dir, "wayland-stale"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		l.SetUnlinkOnClose(false)
+		l.Close()
+
+		_, err = Dial()
+		if !errors.Is(err, ErrStaleSocket) || !errors.Is(err, unix.ECONNREFUSED) {
+			t.Fatalf("expected ErrStaleSocket wrapping ECONNREFUSED, got %v", err)
+		}
+		if errors.Is(err, ErrNoCompositor) {
+			t.Fatalf("stale socket reported as missing: %v", err)
+		}
+	})
+
+	t.Run("Listening", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XDG_RUNTIME_DIR", dir)
+		t.Setenv("WAYLAND_DISPLAY", "wayland-test")
+
+		l, err := ListenPath(filepath.Join(dir, "wayland-test"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer l.Close()
+
+		c, err := Dial()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Close()
+	})
+}
+
+func TestIdleTimeout(t *testing.T) {
+	const timeout = 20 * time.Millisecond
+
+	client, server := newConnPair(t)
+	server.SetIdleTimeout(timeout)
+
+	start := time.Now()
+	_, err := ReadMessage(server)
+	if !errors.Is(err, ErrIdleTimeout) || !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("expected ErrIdleTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); (elapsed < timeout) || (elapsed > time.Second) {
+		t.Fatalf("timed out after %v with a timeout of %v", elapsed, timeout)
+	}
+
+	_, err = ReadMessageContext(context.Background(), server)
+	if !errors.Is(err, ErrIdleTimeout) {
+		t.Fatalf("expected ErrIdleTimeout from ReadMessageContext, got %v", err)
+	}
+
+	// Timing out doesn't affect the connection.
+	if err := NewMessage(testObject(3), 1).Build(client); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Op() != 1 {
+		t.Fatalf("got opcode %v, want 1", msg.Op())
+	}
+
+	// Without a timeout, reading waits for as long as it takes.
+	server.SetIdleTimeout(0)
+	go func() {
+		time.Sleep(2 * timeout)
+		NewMessage(testObject(3), 2).Build(client)
+	}()
+	msg, err = ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Op() != 2 {
+		t.Fatalf("got opcode %v, want 2", msg.Op())
+	}
+}
@@ -2,14 +2,19 @@ package wire
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unsafe"
 
 	"deedles.dev/wl/internal/bin"
+	"golang.org/x/sys/unix"
 )
 
 // MessageBuffer holds message data that has been read from the socket
@@ -19,45 +24,205 @@ type MessageBuffer struct {
 	op     uint16
 	size   uint16
 	conn   *Conn
+	raw    []byte
 	data   bytes.Reader
+	fds    []int
+	fdi    int
 	err    error
 	args   []any
 	method string
 }
 
 // ReadMessage reads message data from the socket into a buffer.
+// Data is read from the socket in large chunks, so a single call may
+// read several messages from the socket, with subsequent calls
+// returning the rest of them without needing to wait.
+//
+// If the remote end closes the connection cleanly between messages,
+// ReadMessage returns ErrDisconnected, which wraps io.EOF. If the
+// connection ends in the middle of a message, the returned error
+// wraps io.ErrUnexpectedEOF instead. If an idle timeout has been set
+// with Conn.SetIdleTimeout and no complete message arrives within it,
+// ErrIdleTimeout is returned. Other errors indicate either a
+// transport failure or malformed data.
 func ReadMessage(c *Conn) (*MessageBuffer, error) {
-	mr := MessageBuffer{conn: c}
+	idle := c.startIdleTimeout()
+	if idle {
+		defer c.conn.SetReadDeadline(time.Time{})
+	}
 
-	var oob bytes.Buffer
-	r := unixTee{c: c.conn, oob: &oob}
+	msg, err := readMessage(c)
+	if idle && errors.Is(err, os.ErrDeadlineExceeded) {
+		return nil, ErrIdleTimeout
+	}
+	return msg, err
+}
 
-	sender, err := bin.Read[uint32](r)
-	if err != nil {
-		return nil, fmt.Errorf("read message sender: %w", err)
+// ReadMessageContext is like ReadMessage, but stops waiting for a
+// message when ctx is done, in which case the context's error is
+// returned. The connection's read deadline is used to interrupt the
+// read and is cleared again before returning.
+//
+// Interrupting a read does not lose any data, even if part of a
+// message had already arrived, so the connection can continue to be
+// used afterwards.
+func ReadMessageContext(ctx context.Context, c *Conn) (*MessageBuffer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	mr.sender = sender
 
-	so, err := bin.Read[uint32](r)
-	if err != nil {
-		return nil, fmt.Errorf("read message size and opcode: %w", err)
+	idle := c.startIdleTimeout()
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			c.conn.SetReadDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+	defer func() {
+		close(done)
+		<-stopped
+		c.conn.SetReadDeadline(time.Time{})
+	}()
+
+	msg, err := readMessage(c)
+	if (err != nil) && errors.Is(err, os.ErrDeadlineExceeded) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if idle {
+			return nil, ErrIdleTimeout
+		}
 	}
-	mr.size = uint16(so >> 16)
-	mr.op = uint16(so & 0xFFFF)
+	return msg, err
+}
 
-	data := bytes.NewBuffer(make([]byte, 0, mr.size))
-	_, err = io.CopyN(data, r, int64(mr.size)-8)
+// ReadAvailable reads the data that is available on the socket,
+// up to the size of the read buffer, without waiting for more and
+// returns the complete messages that it contains, if any. Incomplete
+// messages are kept buffered until the rest of them arrives. If more
+// data is available than fits in the buffer, the socket stays
+// readable, so the rest is returned by the next call. It is intended
+// for use with external event loops that wait for the connection to
+// become readable themselves, such as by polling the file descriptor
+// returned by c.Fd.
+//
+// If the remote end has closed the connection, ErrDisconnected is
+// returned once all of the messages that it sent before closing it
+// have been.
+func ReadAvailable(c *Conn) ([]*MessageBuffer, error) {
+	c.rm.Lock()
+	defer c.rm.Unlock()
+
+	r := &c.r
+	r.begin()
+	err := r.fillNow()
+	fds := r.fds
+	if errors.Is(err, errWouldBlock) {
+		err = nil
+	}
+	if fderr := r.takeFDErr(); fderr != nil {
+		return nil, fderr
+	}
+	if errors.Is(err, io.EOF) {
+		err = ErrDisconnected
+	}
+
+	var msgs []*MessageBuffer
+	for r.available() {
+		r.begin()
+		if len(msgs) == 0 {
+			// File descriptors are counted for the message that was
+			// being read when they arrived, which here is the first.
+			r.fds = fds
+		}
+
+		mr, merr := readMessageFrom(r)
+		if merr != nil {
+			return msgs, merr
+		}
+		mr.conn = c
+
+		c.record(Received, mr.Bytes(), r.fds)
+		msgs = append(msgs, mr)
+	}
+	return msgs, err
+}
+
+// readMessage reads a message from c. If the read times out, c is
+// left as though it had never been attempted.
+func readMessage(c *Conn) (*MessageBuffer, error) {
+	c.rm.Lock()
+	defer c.rm.Unlock()
+
+	r := &c.r
+	r.begin()
+
+	mr, err := readMessageFrom(r)
+	if fderr := r.takeFDErr(); fderr != nil {
+		return nil, fderr
+	}
 	if err != nil {
-		return nil, fmt.Errorf("copy data to buffer: %w", err)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			r.rewind()
+			return nil, err
+		}
+		if (r.n == 0) && errors.Is(err, io.EOF) {
+			return nil, ErrDisconnected
+		}
+		return nil, err
 	}
+	mr.conn = c
+
+	c.record(Received, mr.Bytes(), r.fds)
+	return mr, nil
+}
 
-	err = c.readFDs(oob.Bytes())
+// ReadMessageFrom reads a single message from r. It is intended for
+// transports that are unable to pass file descriptors and for
+// decoding previously captured data. The returned MessageBuffer has
+// no connection associated with it, so attempting to decode a file
+// descriptor argument from it fails.
+//
+// If r is at EOF before any of the message has been read, the
+// returned error wraps io.EOF. If it ends partway through a message,
+// the error wraps io.ErrUnexpectedEOF instead.
+func ReadMessageFrom(r io.Reader) (*MessageBuffer, error) {
+	return readMessageFrom(r)
+}
+
+// readMessageFrom reads the header and body of a message from r.
+func readMessageFrom(r io.Reader) (*MessageBuffer, error) {
+	var mr MessageBuffer
+
+	var header [HeaderSize]byte
+	_, err := io.ReadFull(r, header[:])
 	if err != nil {
-		return nil, fmt.Errorf("read FDs: %w", err)
+		return nil, fmt.Errorf("read message header: %w", err)
+	}
+	mr.sender, mr.op, mr.size = DecodeHeader(header[:])
+	if err := checkSize(int(mr.size)); err != nil {
+		return nil, mr.malformed(header[:], err)
+	}
+	if mr.size%4 != 0 {
+		return nil, mr.malformed(header[:], fmt.Errorf("message size %v is not a multiple of 4", mr.size))
 	}
 
-	mr.data.Reset(data.Bytes())
+	data := bytes.NewBuffer(make([]byte, 0, mr.size))
+	_, err = io.CopyN(data, r, int64(mr.size)-HeaderSize)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("copy data to buffer: %w", err)
+	}
 
+	mr.raw = data.Bytes()
+	mr.data.Reset(mr.raw)
 	return &mr, nil
 }
 
@@ -76,14 +241,148 @@ func (r MessageBuffer) Size() uint16 {
 	return r.size
 }
 
-func (r MessageBuffer) Err() error {
-	if errors.Is(r.err, io.EOF) {
-		if r.data.Size() < int64(r.size)-8 {
-			return io.ErrUnexpectedEOF
-		}
+// Conn returns the connection that the message was read from, or nil
+// if it was read with ReadMessageFrom.
+func (r *MessageBuffer) Conn() *Conn {
+	return r.conn
+}
+
+// Bytes returns the complete raw message, including the header,
+// regardless of how much of it has already been decoded.
+func (r *MessageBuffer) Bytes() []byte {
+	buf := make([]byte, HeaderSize+r.data.Size())
+	EncodeHeader(buf, r.sender, r.op, r.size)
+	r.data.ReadAt(buf[HeaderSize:], 0)
+	return buf
+}
+
+// Reset rewinds r to the start of the message so that it can be
+// decoded again, clearing any error. File descriptors that were
+// already read from r are handed out again in the same order by
+// subsequent reads instead of new ones being taken from the
+// connection. Both reads refer to the same file descriptors, so only
+// one of the results should ever be closed.
+func (r *MessageBuffer) Reset() {
+	r.data.Seek(0, io.SeekStart)
+	r.fdi = 0
+	r.err = nil
+	r.args = nil
+}
+
+// Err returns the first error that occurred while decoding the
+// message, if any. Errors caused by the message's data being invalid
+// are returned as a MalformedMessageError wrapping one of
+// ErrShortMessage, ErrNotNullTerminated, ErrLengthOverflow, or
+// ErrNoMoreFDs, so they can be checked for with errors.Is.
+func (r *MessageBuffer) Err() error {
+	if r.err == nil {
 		return nil
 	}
-	return r.err
+	return r.malformed(r.Bytes(), r.err)
+}
+
+// Discard skips the rest of the message's data and closes the next
+// fds file descriptors that it has not read yet. It is intended for
+// messages that are not going to be decoded, such as events that
+// arrive for an object that has already been destroyed, that still
+// need to have their file descriptors removed from the connection so
+// that they are not handed to later messages instead. The number of
+// file descriptors that a message carries can not be determined from
+// its data, so it must be provided from the message's signature.
+//
+// File descriptors that have already been read from r are not closed,
+// since ownership of them has already been passed to the caller.
+func (r *MessageBuffer) Discard(fds int) error {
+	r.data.Seek(0, io.SeekEnd)
+
+	var errs []error
+	for range fds {
+		fd := r.readFD()
+		if r.err != nil {
+			return r.Err()
+		}
+		errs = append(errs, unix.Close(fd))
+	}
+	return errors.Join(errs...)
+}
+
+// Clone returns a copy of r that can be decoded independently of it,
+// such as by another goroutine. The copy starts at the beginning of
+// the message, regardless of how much of r has already been decoded.
+//
+// As with Discard, fds is the number of file descriptors that the
+// message carries. Any of them that r has not taken from the
+// connection yet are taken from it first, after which they are handed
+// out by r the same as if they had been read normally. The clone gets
+// its own duplicates of all of them, made with dup, which it hands out
+// in the same order without any further involvement of the
+// connection. The duplicates are independent of the originals, so
+// each side is responsible for closing the ones that it reads, and
+// either can use Discard to close the ones that it doesn't.
+func (r *MessageBuffer) Clone(fds int) (*MessageBuffer, error) {
+	for len(r.fds) < fds {
+		if r.conn == nil {
+			return nil, fmt.Errorf("%w: message was not read from a connection", ErrNoMoreFDs)
+		}
+		fd, ok := r.conn.popFD()
+		if !ok {
+			return nil, ErrNoMoreFDs
+		}
+		r.fds = append(r.fds, fd)
+	}
+
+	clone := MessageBuffer{
+		sender: r.sender,
+		op:     r.op,
+		size:   r.size,
+		raw:    slices.Clone(r.raw),
+		fds:    make([]int, 0, len(r.fds)),
+		method: r.method,
+	}
+	clone.data.Reset(clone.raw)
+
+	for _, fd := range r.fds {
+		dup, err := unix.FcntlInt(uintptr(fd), unix.F_DUPFD_CLOEXEC, 0)
+		if err != nil {
+			for _, fd := range clone.fds {
+				unix.Close(fd)
+			}
+			return nil, fmt.Errorf("duplicate file descriptor: %w", err)
+		}
+		clone.fds = append(clone.fds, dup)
+	}
+
+	return &clone, nil
+}
+
+// Remaining returns the number of bytes of the message's arguments
+// that have not been decoded yet.
+func (r *MessageBuffer) Remaining() int {
+	return r.data.Len()
+}
+
+// Verify is like Err, but additionally returns a
+// MalformedMessageError if any of the message's data has not been
+// decoded. If that happens after all of the arguments of a message
+// have been read, the definition of the message that was used to
+// decode it does not match the one that the sender used to encode it.
+func (r *MessageBuffer) Verify() error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if n := r.Remaining(); n > 0 {
+		return r.malformed(r.Bytes(), fmt.Errorf("%v bytes left over after decoding", n))
+	}
+	return nil
+}
+
+func (r *MessageBuffer) malformed(data []byte, err error) error {
+	return MalformedMessageError{
+		Sender: r.sender,
+		Op:     r.op,
+		Data:   data,
+		Err:    err,
+	}
 }
 
 func (r *MessageBuffer) ReadInt() (v int32) {
@@ -91,8 +390,8 @@ func (r *MessageBuffer) ReadInt() (v int32) {
 		return
 	}
 
-	v, r.err = bin.Read[int32](&r.data)
-	r.args = append(r.args, v)
+	v = readWord[int32](r)
+	addArg(r, v)
 	return v
 }
 
@@ -101,8 +400,8 @@ func (r *MessageBuffer) ReadUint() (v uint32) {
 		return
 	}
 
-	v, r.err = bin.Read[uint32](&r.data)
-	r.args = append(r.args, v)
+	v = readWord[uint32](r)
+	addArg(r, v)
 	return v
 }
 
@@ -123,91 +422,360 @@ func (r *MessageBuffer) ReadFixed() (v Fixed) {
 		return
 	}
 
-	v, r.err = bin.Read[Fixed](&r.data)
-	r.args = append(r.args, v)
+	v = readWord[Fixed](r)
+	addArg(r, v)
 	return v
 }
 
+// ReadFixedPair reads two consecutive fixed arguments, such as the
+// coordinates in a wl_pointer.motion event. It is equivalent to, but
+// faster than, calling ReadFixed twice.
+func (r *MessageBuffer) ReadFixedPair() (x, y Fixed) {
+	if r.err != nil {
+		return
+	}
+
+	var data [8]byte
+	r.read(data[:])
+	if r.err != nil {
+		return
+	}
+
+	x = bin.Value[Fixed]([4]byte(data[:4]))
+	y = bin.Value[Fixed]([4]byte(data[4:]))
+	addArg(r, x)
+	addArg(r, y)
+	return x, y
+}
+
 func (r *MessageBuffer) ReadString() string {
+	var buf []byte
+	data := r.readString(&buf)
 	if r.err != nil {
 		return ""
 	}
 
-	length := r.ReadUint()
+	// buf is never used again, so it's safe to convert without
+	// copying.
+	v := unsafe.String(unsafe.SliceData(data), len(data))
+	addArg(r, v)
+	return v
+}
+
+// ReadStringInto is like ReadString, but it reads the string's raw
+// data into *dst, growing it if necessary, instead of into a newly
+// allocated buffer. This allows a single buffer to be reused for
+// decoding many strings without allocating at all once it has grown
+// large enough.
+//
+// The returned string refers directly to the memory of *dst, so it is
+// only valid until *dst is next reused, such as by reading another
+// string into it, at which point its contents change. Strings that
+// need to be kept for longer should be copied with strings.Clone.
+func (r *MessageBuffer) ReadStringInto(dst *[]byte) string {
+	data := r.readString(dst)
 	if r.err != nil {
 		return ""
 	}
-	pad := padding(length)
 
-	var str strings.Builder
-	str.Grow(int(length + pad))
-	_, r.err = io.CopyN(&str, &r.data, int64(length+pad))
+	v := unsafe.String(unsafe.SliceData(data), len(data))
+	if (r.conn != nil) && r.conn.Tracing() {
+		// The trace output is produced later, after *dst may have been
+		// reused.
+		addArg(r, strings.Clone(v))
+	}
+	return v
+}
+
+// ReadStringUnsafe is like ReadString, but instead of copying the
+// string's data into a newly allocated buffer, it returns a string
+// that refers directly to the memory holding r's message data,
+// avoiding the allocation completely.
+//
+// The returned string shares memory with r. As long as it, or any
+// substring of it, is reachable, the entirety of r's message data is
+// kept alive. Holding on to such strings, such as by storing them in
+// long-lived data structures, can therefore waste large amounts of
+// memory. It is intended only for hot paths in which the string is
+// inspected and then discarded before the next message is read.
+// Strings that need to be kept should either be read with ReadString
+// instead or copied with strings.Clone.
+func (r *MessageBuffer) ReadStringUnsafe() string {
 	if r.err != nil {
 		return ""
 	}
-	v := str.String()
-	if v[length-1] != 0 {
-		r.err = errors.New("string is not null-terminated")
+
+	length := readWord[uint32](r)
+	if !r.checkLength(length) {
+		return ""
+	}
+	if length == 0 {
+		r.err = fmt.Errorf("%w: null string in non-nullable argument", ErrNotNullTerminated)
+		return ""
+	}
+
+	start := len(r.raw) - r.data.Len()
+	end := start + int(length)
+	next := end + int(padding(length))
+	r.data.Seek(int64(next), io.SeekStart)
+	if r.raw[end-1] != 0 {
+		r.err = ErrNotNullTerminated
+		return ""
+	}
+	if !r.checkPadding(r.raw[end:next]) {
 		return ""
 	}
 
-	r.args = append(r.args, v[:length-1])
-	return v[:length-1]
+	data := r.raw[start : end-1]
+	v := unsafe.String(unsafe.SliceData(data), len(data))
+	addArg(r, v)
+	return v
 }
 
-func (r *MessageBuffer) ReadArray() []byte {
+// ReadNullableString reads a string argument that is allowed to be
+// null. A null string is sent as a length of zero and is returned as
+// nil.
+func (r *MessageBuffer) ReadNullableString() *string {
+	if r.err != nil {
+		return nil
+	}
+
+	length := readWord[uint32](r)
+	if (r.err != nil) || (length == 0) {
+		addArg(r, (*string)(nil))
+		return nil
+	}
+
+	var buf []byte
+	data := r.readStringData(length, &buf)
 	if r.err != nil {
 		return nil
 	}
 
-	length := r.ReadUint()
+	v := unsafe.String(unsafe.SliceData(data), len(data))
+	addArg(r, &v)
+	return &v
+}
+
+// readString reads a string argument into *buf and returns its
+// contents without the null terminator.
+func (r *MessageBuffer) readString(buf *[]byte) []byte {
 	if r.err != nil {
 		return nil
 	}
+
+	length := readWord[uint32](r)
+	return r.readStringData(length, buf)
+}
+
+// readStringData reads the contents of a string argument whose
+// length has already been read.
+func (r *MessageBuffer) readStringData(length uint32, buf *[]byte) []byte {
+	if !r.checkLength(length) {
+		return nil
+	}
+	if length == 0 {
+		// Even an empty string has a null terminator, so only a null
+		// string can have a length of zero.
+		r.err = fmt.Errorf("%w: null string in non-nullable argument", ErrNotNullTerminated)
+		return nil
+	}
+	if length == 1 {
+		// An empty string is just the null terminator and padding, so
+		// there's no need to touch buf.
+		var data [4]byte
+		r.read(data[:])
+		if (r.err == nil) && (data[0] != 0) {
+			r.err = ErrNotNullTerminated
+		}
+		r.checkPadding(data[1:])
+		return nil
+	}
 	pad := padding(length)
 
-	buf := make([]byte, length+pad)
-	_, r.err = io.ReadFull(&r.data, buf)
+	*buf = slices.Grow((*buf)[:0], int(length+pad))[:length+pad]
+	r.read(*buf)
 	if r.err != nil {
 		return nil
 	}
+	if (*buf)[length-1] != 0 {
+		r.err = ErrNotNullTerminated
+		return nil
+	}
+	if !r.checkPadding((*buf)[length:]) {
+		return nil
+	}
 
-	r.args = append(r.args, buf[:length])
-	return buf[:length]
+	return (*buf)[:length-1]
 }
 
-func (r *MessageBuffer) ReadFile() *os.File {
+func (r *MessageBuffer) ReadArray() []byte {
 	if r.err != nil {
 		return nil
 	}
 
-	fd, ok := pop(&r.conn.fds)
-	if !ok {
-		r.err = errors.New("no more file descriptors")
+	length := readWord[uint32](r)
+	if !r.checkLength(length) {
+		return nil
+	}
+	pad := padding(length)
+
+	buf := make([]byte, length+pad)
+	r.read(buf)
+	if !r.checkPadding(buf[length:]) {
+		return nil
+	}
+
+	addArg(r, buf[:length])
+	return buf[:length]
+}
+
+// checkLength checks that a string or array argument with the given
+// length, not including padding, fits both in the remainder of the
+// message and in the connection's argument size limit.
+func (r *MessageBuffer) checkLength(length uint32) bool {
+	if r.err != nil {
+		return false
+	}
+
+	if size := uint64(length) + uint64(padding(length)); size > uint64(r.data.Len()) {
+		r.err = fmt.Errorf("%w: %v exceeds remaining message size %v", ErrLengthOverflow, length, r.data.Len())
+		return false
+	}
+	if r.conn != nil {
+		if limit := r.conn.argLimit.Load(); (limit > 0) && (int64(length) > limit) {
+			r.err = fmt.Errorf("%w: %v exceeds limit %v", ErrLengthOverflow, length, limit)
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkPadding checks that the padding after a string or array
+// argument is all zeroes if the connection requires it to be.
+func (r *MessageBuffer) checkPadding(pad []byte) bool {
+	if r.err != nil {
+		return false
+	}
+	if (r.conn == nil) || !r.conn.strictPadding.Load() {
+		return true
+	}
+
+	for _, b := range pad {
+		if b != 0 {
+			r.err = ErrNonzeroPadding
+			return false
+		}
+	}
+	return true
+}
+
+// ReadFile reads a file descriptor argument and wraps it in an
+// *os.File. See ReadFD for details about ownership.
+func (r *MessageBuffer) ReadFile() *os.File {
+	fd := r.readFD()
+	if r.err != nil {
 		return nil
 	}
 
 	f := os.NewFile(uintptr(fd), "")
-	r.args = append(r.args, f)
+	addArg(r, f)
 	return f
 }
 
-func (r *MessageBuffer) Debug(sender Object) string {
-	args := make([]string, 0, len(r.args))
-	for _, arg := range r.args {
-		switch arg := arg.(type) {
-		case string:
-			args = append(args, strconv.Quote(arg))
-		case *os.File:
-			args = append(args, fmt.Sprint(arg.Fd()))
-		default:
-			args = append(args, fmt.Sprint(arg))
-		}
+// ReadFD reads a file descriptor argument and returns it without
+// wrapping it. The caller becomes responsible for closing it. Each
+// file descriptor argument must be read with exactly one of ReadFD
+// and ReadFile, as both hand ownership of the file descriptor to the
+// caller.
+func (r *MessageBuffer) ReadFD() int {
+	fd := r.readFD()
+	if r.err != nil {
+		return -1
+	}
+
+	addArg(r, fd)
+	return fd
+}
+
+// RemainingFDs returns the number of file descriptors that are
+// available to be read from r. This includes ones that have been
+// received from the connection but not yet read by any message, so it
+// is an upper bound.
+func (r *MessageBuffer) RemainingFDs() int {
+	n := len(r.fds) - r.fdi
+	if r.conn != nil {
+		n += r.conn.queuedFDs()
+	}
+	return n
+}
+
+func (r *MessageBuffer) readFD() int {
+	if r.err != nil {
+		return -1
 	}
 
+	if r.fdi < len(r.fds) {
+		fd := r.fds[r.fdi]
+		r.fdi++
+		return fd
+	}
+
+	if r.conn == nil {
+		r.err = fmt.Errorf("%w: message was not read from a connection", ErrNoMoreFDs)
+		return -1
+	}
+
+	fd, ok := r.conn.popFD()
+	if !ok {
+		r.err = ErrNoMoreFDs
+		return -1
+	}
+
+	r.fds = append(r.fds, fd)
+	r.fdi++
+	return fd
+}
+
+// read fills buf from the message body. It avoids the allocation
+// that passing r.data to functions that take an io.Reader causes.
+func (r *MessageBuffer) read(buf []byte) {
+	if len(buf) == 0 {
+		return
+	}
+
+	n, _ := r.data.Read(buf)
+	if n < len(buf) {
+		r.err = ErrShortMessage
+	}
+}
+
+func readWord[T ~int32 | ~uint32](r *MessageBuffer) T {
+	var data [4]byte
+	r.read(data[:])
+	return bin.Value[T](data)
+}
+
+// addArg records a decoded argument for use by Debug. Arguments are
+// only recorded when the connection that the message was read from is
+// being traced in order to avoid allocating for every argument.
+func addArg[T any](r *MessageBuffer, arg T) {
+	if (r.conn == nil) || !r.conn.Tracing() {
+		return
+	}
+
+	r.args = append(r.args, arg)
+}
+
+// Debug returns a string representation of the message as sent to
+// sender, including any arguments decoded so far. Arguments are only
+// included if the connection that the message was read from is being
+// traced. See Conn.SetLogger.
+func (r *MessageBuffer) Debug(sender Object) string {
 	method := strconv.FormatUint(uint64(r.op), 10)
 	if mn, ok := sender.(DebugObject); ok {
 		method = mn.MethodName(r.op)
 	}
-	return fmt.Sprintf("%v.%v(%v)", sender, method, strings.Join(args, ", "))
+	return fmt.Sprintf("%v.%v(%v)", sender, method, formatArgs(r.args))
 }
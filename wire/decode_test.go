@@ -0,0 +1,994 @@
+package wire
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	"deedles.dev/wl/internal/bin"
+	"golang.org/x/sys/unix"
+)
+
+func TestReadMessageInvalidSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size uint16
+	}{
+		{"SmallerThanHeader", 4},
+		{"Unaligned", 9},
+		{"Zero", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := rawMessage(3, 1, test.size, make([]byte, 16)...)
+
+			_, err := ReadMessageFrom(bytes.NewReader(data))
+			var malformed MalformedMessageError
+			if !errors.As(err, &malformed) {
+				t.Fatalf("expected MalformedMessageError, got %v", err)
+			}
+			if (malformed.Sender != 3) || (malformed.Op != 1) {
+				t.Fatalf("error has sender %v and opcode %v", malformed.Sender, malformed.Op)
+			}
+			if !bytes.Equal(malformed.Data, data[:HeaderSize]) {
+				t.Fatalf("error data %x is not the header", malformed.Data)
+			}
+
+			client, server := newConnPair(t)
+			if err := client.writeMsg(data, nil); err != nil {
+				t.Fatal(err)
+			}
+			_, err = ReadMessage(server)
+			if !errors.As(err, &malformed) {
+				t.Fatalf("expected MalformedMessageError from ReadMessage, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReadMessageMinimal(t *testing.T) {
+	msg, err := ReadMessageFrom(bytes.NewReader(rawMessage(3, 1, HeaderSize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (msg.Size() != HeaderSize) || (msg.Remaining() != 0) {
+		t.Fatalf("got size %v with %v bytes remaining", msg.Size(), msg.Remaining())
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// stringArg returns the encoding of a string argument with the given
+// declared length and content, padded to a multiple of 4 bytes.
+func stringArg(length uint32, content []byte) []byte {
+	word := bin.Bytes(length)
+	data := append(word[:], content...)
+	for len(data)%4 != 0 {
+		data = append(data, 0)
+	}
+	return data
+}
+
+// sendRaw sends data from client to server as is and reads it back as
+// a message.
+func sendRaw(t *testing.T, client, server *Conn, data []byte) *MessageBuffer {
+	t.Helper()
+
+	if err := client.writeMsg(data, nil); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestLengthOverflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		length uint32
+	}{
+		{"PastEnd", 100},
+		{"Huge", 0xFFFFFFFF},
+		{"PaddingPastEnd", 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := stringArg(test.length, []byte("hi\x00\x00"))
+			data := rawMessage(3, 0, uint16(HeaderSize+len(body)), body...)
+
+			for _, read := range []struct {
+				name string
+				read func(*MessageBuffer)
+			}{
+				{"ReadString", func(msg *MessageBuffer) { msg.ReadString() }},
+				{"ReadStringUnsafe", func(msg *MessageBuffer) { msg.ReadStringUnsafe() }},
+				{"ReadNullableString", func(msg *MessageBuffer) { msg.ReadNullableString() }},
+				{"ReadArray", func(msg *MessageBuffer) { msg.ReadArray() }},
+			} {
+				msg, err := ReadMessageFrom(bytes.NewReader(data))
+				if err != nil {
+					t.Fatal(err)
+				}
+				read.read(msg)
+				if err := msg.Err(); !errors.Is(err, ErrLengthOverflow) {
+					t.Errorf("%v: expected ErrLengthOverflow, got %v", read.name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestArgLimit(t *testing.T) {
+	client, server := newConnPair(t)
+	server.SetArgLimit(4)
+
+	short := stringArg(4, []byte("abc\x00"))
+	msg := sendRaw(t, client, server, rawMessage(3, 0, uint16(HeaderSize+len(short)), short...))
+	if s := msg.ReadString(); s != "abc" {
+		t.Fatalf("got %q, want %q", s, "abc")
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	long := stringArg(5, []byte("abcd\x00"))
+	msg = sendRaw(t, client, server, rawMessage(3, 0, uint16(HeaderSize+len(long)), long...))
+	msg.ReadString()
+	err := msg.Err()
+	if !errors.Is(err, ErrLengthOverflow) {
+		t.Fatalf("expected ErrLengthOverflow, got %v", err)
+	}
+	var malformed MalformedMessageError
+	if !errors.As(err, &malformed) {
+		t.Fatalf("expected MalformedMessageError, got %T", err)
+	}
+
+	array := stringArg(8, make([]byte, 8))
+	msg = sendRaw(t, client, server, rawMessage(3, 0, uint16(HeaderSize+len(array)), array...))
+	msg.ReadArray()
+	if err := msg.Err(); !errors.Is(err, ErrLengthOverflow) {
+		t.Fatalf("expected ErrLengthOverflow for array, got %v", err)
+	}
+}
+
+func TestStrictPadding(t *testing.T) {
+	tests := []struct {
+		name  string
+		zero  []byte
+		bad   []byte
+		read  func(*MessageBuffer) any
+		value any
+	}{
+		{
+			name:  "ReadString",
+			zero:  stringArg(3, []byte("ab\x00\x00")),
+			bad:   stringArg(3, []byte("ab\x00\x09")),
+			read:  func(msg *MessageBuffer) any { return msg.ReadString() },
+			value: "ab",
+		},
+		{
+			name:  "ReadStringUnsafe",
+			zero:  stringArg(3, []byte("ab\x00\x00")),
+			bad:   stringArg(3, []byte("ab\x00\x09")),
+			read:  func(msg *MessageBuffer) any { return msg.ReadStringUnsafe() },
+			value: "ab",
+		},
+		{
+			name: "ReadNullableString",
+			zero: stringArg(3, []byte("ab\x00\x00")),
+			bad:  stringArg(3, []byte("ab\x00\x09")),
+			read: func(msg *MessageBuffer) any {
+				if s := msg.ReadNullableString(); s != nil {
+					return *s
+				}
+				return nil
+			},
+			value: "ab",
+		},
+		{
+			name:  "EmptyString",
+			zero:  stringArg(1, []byte("\x00\x00\x00\x00")),
+			bad:   stringArg(1, []byte("\x00\x00\x09\x00")),
+			read:  func(msg *MessageBuffer) any { return msg.ReadString() },
+			value: "",
+		},
+		{
+			name:  "ReadArray",
+			zero:  stringArg(1, []byte{7, 0, 0, 0}),
+			bad:   stringArg(1, []byte{7, 9, 9, 9}),
+			read:  func(msg *MessageBuffer) any { return string(msg.ReadArray()) },
+			value: "\x07",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, strict := range []bool{false, true} {
+				client, server := newConnPair(t)
+				server.SetStrictPadding(strict)
+
+				for _, body := range [][]byte{test.zero, test.bad} {
+					msg := sendRaw(t, client, server, rawMessage(3, 0, uint16(HeaderSize+len(body)), body...))
+					v := test.read(msg)
+
+					if strict && bytes.Equal(body, test.bad) {
+						if err := msg.Err(); !errors.Is(err, ErrNonzeroPadding) {
+							t.Fatalf("strict: expected ErrNonzeroPadding for %x, got %v", body, err)
+						}
+						continue
+					}
+					if err := msg.Verify(); err != nil {
+						t.Fatalf("strict %v: %x: %v", strict, body, err)
+					}
+					if v != test.value {
+						t.Fatalf("strict %v: %x: got %q, want %q", strict, body, v, test.value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	client, server := newConnPair(t)
+
+	r, w := newPipe(t)
+	err := client.writeMsg(rawMessage(3, 0, HeaderSize+4, 5, 0, 0, 0), []int{int(r.Fd())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := msg.ReadUint(); v != 5 {
+		t.Fatalf("got %v, want 5", v)
+	}
+
+	clone, err := msg.Clone(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if &clone.raw[0] == &msg.raw[0] {
+		t.Fatal("clone shares its data with the original")
+	}
+
+	// The clone starts from the beginning, even though the original
+	// was partially decoded already.
+	if v := clone.ReadUint(); v != 5 {
+		t.Fatalf("clone got %v, want 5", v)
+	}
+	cfd := clone.ReadFD()
+	if err := clone.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	fd := msg.ReadFD()
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if fd == cfd {
+		t.Fatalf("clone got the same file descriptor, %v, as the original", fd)
+	}
+
+	// Either copy keeps the pipe open on its own.
+	unix.Close(fd)
+	if !readEndOpen(w) {
+		t.Fatal("closing the original closed the clone's file descriptor")
+	}
+	unix.Close(cfd)
+	if readEndOpen(w) {
+		t.Fatal("pipe is still open after both file descriptors were closed")
+	}
+
+	// The clone has no connection to take further file descriptors
+	// from.
+	sendFD(t, client)
+	clone.ReadFD()
+	if err := clone.Err(); !errors.Is(err, ErrNoMoreFDs) {
+		t.Fatalf("expected ErrNoMoreFDs, got %v", err)
+	}
+}
+
+func TestCloneWithoutConn(t *testing.T) {
+	msg, err := ReadMessageFrom(bytes.NewReader(rawMessage(3, 0, HeaderSize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := msg.Clone(1); !errors.Is(err, ErrNoMoreFDs) {
+		t.Fatalf("expected ErrNoMoreFDs, got %v", err)
+	}
+	clone, err := msg.Clone(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := clone.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestZeroLengthString(t *testing.T) {
+	reads := []struct {
+		name string
+		read func(*MessageBuffer)
+	}{
+		{"ReadString", func(msg *MessageBuffer) { msg.ReadString() }},
+		{"ReadStringUnsafe", func(msg *MessageBuffer) { msg.ReadStringUnsafe() }},
+		{"ReadStringInto", func(msg *MessageBuffer) {
+			var buf []byte
+			msg.ReadStringInto(&buf)
+		}},
+		{"ReadNewID", func(msg *MessageBuffer) { msg.ReadNewID() }},
+	}
+
+	// The length is the last thing in the message, so there is nothing
+	// after it that could be mistaken for a terminator.
+	data := rawMessage(3, 0, HeaderSize+4, 0, 0, 0, 0)
+	for _, read := range reads {
+		msg, err := ReadMessageFrom(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		read.read(msg)
+		err = msg.Err()
+		if !errors.Is(err, ErrNotNullTerminated) {
+			t.Errorf("%v: expected ErrNotNullTerminated, got %v", read.name, err)
+		}
+		var malformed MalformedMessageError
+		if !errors.As(err, &malformed) {
+			t.Errorf("%v: expected MalformedMessageError, got %T", read.name, err)
+		}
+	}
+
+	// A nullable string with a length of zero is just null.
+	msg, err := ReadMessageFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := msg.ReadNullableString(); s != nil {
+		t.Fatalf("got %q, want nil", *s)
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadAvailable(t *testing.T) {
+	client, server := newConnPair(t)
+
+	msgs, err := ReadAvailable(server)
+	if (len(msgs) != 0) || (err != nil) {
+		t.Fatalf("got %v messages and error %v with nothing sent", len(msgs), err)
+	}
+
+	for op := range uint16(3) {
+		mb := NewMessage(testObject(3), op)
+		mb.WriteString("hi")
+		if err := mb.Build(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w := sendFD(t, client)
+
+	partial := NewMessage(testObject(3), 9)
+	partial.WriteString("partial")
+	var buf bytes.Buffer
+	partial.encode(&buf)
+	if err := client.writeMsg(buf.Bytes()[:10], nil); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs, err = ReadAvailable(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 4 {
+		t.Fatalf("got %v messages, want 4", len(msgs))
+	}
+	for op, msg := range msgs[:3] {
+		if (msg.Op() != uint16(op)) || (msg.ReadString() != "hi") {
+			t.Fatalf("message %v is wrong: %v", op, msg.Bytes())
+		}
+		if err := msg.Verify(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// File descriptors that arrived along with the data can be read
+	// from the messages.
+	fd := msgs[3].ReadFD()
+	if err := msgs[3].Verify(); err != nil {
+		t.Fatal(err)
+	}
+	unix.Close(fd)
+	if readEndOpen(w) {
+		t.Fatal("received file descriptor is not the one that was sent")
+	}
+
+	// The incomplete message stays buffered until the rest of it
+	// arrives.
+	msgs, err = ReadAvailable(server)
+	if (len(msgs) != 0) || (err != nil) {
+		t.Fatalf("got %v messages and error %v from a partial message", len(msgs), err)
+	}
+	if err := client.writeMsg(buf.Bytes()[10:], nil); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err = ReadAvailable(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (len(msgs) != 1) || (msgs[0].Op() != 9) || (msgs[0].ReadString() != "partial") {
+		t.Fatalf("did not get the completed message: %v", msgs)
+	}
+
+	// Messages that arrive before the connection is closed are still
+	// returned first.
+	if err := NewMessage(testObject(3), 10).Build(client); err != nil {
+		t.Fatal(err)
+	}
+	client.Close()
+	msgs, err = ReadAvailable(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (len(msgs) != 1) || (msgs[0].Op() != 10) {
+		t.Fatalf("got %v messages before disconnecting, want 1", len(msgs))
+	}
+	msgs, err = ReadAvailable(server)
+	if (len(msgs) != 0) || !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("expected only ErrDisconnected, got %v messages and %v", len(msgs), err)
+	}
+}
+
+func TestReadAvailableBufferSize(t *testing.T) {
+	const total = 20
+
+	client, server := newConnPair(t)
+	server.SetReadBufferSize(4 * HeaderSize)
+
+	for op := range uint16(total) {
+		if err := NewMessage(testObject(3), op).Build(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each call reads at most a buffer's worth of data, leaving the
+	// rest for later calls.
+	var got int
+	for calls := 0; got < total; calls++ {
+		if calls > total {
+			t.Fatalf("only %v messages read after %v calls", got, calls)
+		}
+
+		msgs, err := ReadAvailable(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(msgs) > 4 {
+			t.Fatalf("got %v messages from a buffer that fits 4", len(msgs))
+		}
+		for _, msg := range msgs {
+			if msg.Op() != uint16(got) {
+				t.Fatalf("got message %v, want %v", msg.Op(), got)
+			}
+			got++
+		}
+	}
+}
+
+func TestReadMessageContextRewind(t *testing.T) {
+	client, server := newConnPair(t)
+
+	mb := NewMessage(testObject(3), 1)
+	mb.WriteString("interrupted")
+	mb.WriteUint(5)
+	var buf bytes.Buffer
+	mb.encode(&buf)
+	data := buf.Bytes()
+
+	// Part of the message arrives, along with its file descriptor,
+	// before the read is interrupted.
+	r, w := newPipe(t)
+	if err := client.writeMsg(data[:12], []int{int(r.Fd())}); err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := ReadMessageContext(ctx, server); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if err := client.writeMsg(data[12:], nil); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := ReadMessageContext(context.Background(), server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, v := msg.ReadString(), msg.ReadUint(); (s != "interrupted") || (v != 5) {
+		t.Fatalf("got %q and %v after rewinding", s, v)
+	}
+	fd := msg.ReadFD()
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	unix.Close(fd)
+	if readEndOpen(w) {
+		t.Fatal("file descriptor read after rewinding is not the one that was sent")
+	}
+}
+
+func TestReadMessageContextCancel(t *testing.T) {
+	client, server := newConnPair(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ReadMessageContext(ctx, server); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from a canceled context, got %v", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := ReadMessageContext(ctx, server); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	// The read deadline is cleared again, so later reads wait normally.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		NewMessage(testObject(3), 2).Build(client)
+	}()
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Op() != 2 {
+		t.Fatalf("got message %v, want 2", msg.Op())
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	client, server := newConnPair(t)
+
+	kept, keptw := newPipe(t)
+	dropped, droppedw := newPipe(t)
+	next, nextw := newPipe(t)
+
+	err := client.writeMsg(rawMessage(3, 0, HeaderSize+8, 1, 0, 0, 0, 2, 0, 0, 0), []int{int(kept.Fd()), int(dropped.Fd())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.writeMsg(rawMessage(3, 1, HeaderSize+4, 7, 0, 0, 0), []int{int(next.Fd())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept.Close()
+	dropped.Close()
+	next.Close()
+
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := msg.ReadUint(); v != 1 {
+		t.Fatalf("got %v, want 1", v)
+	}
+	file := msg.ReadFile()
+	defer file.Close()
+
+	// Only the file descriptor that hadn't been read is closed.
+	if err := msg.Discard(1); err != nil {
+		t.Fatal(err)
+	}
+	if readEndOpen(droppedw) {
+		t.Fatal("discarded file descriptor is still open")
+	}
+	if !readEndOpen(keptw) {
+		t.Fatal("file descriptor that was already read was closed")
+	}
+
+	// The rest of the body was skipped.
+	msg.ReadUint()
+	if err := msg.Err(); err == nil {
+		t.Fatal("read past the end of a discarded message")
+	}
+
+	// The next message gets its own file descriptor, not the discarded
+	// one.
+	msg, err = ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := msg.ReadUint(); v != 7 {
+		t.Fatalf("got %v, want 7", v)
+	}
+	nextFile := msg.ReadFile()
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	defer nextFile.Close()
+	if inode(t, int(nextFile.Fd())) != inode(t, int(nextw.Fd())) {
+		t.Fatal("next message did not get its own file descriptor")
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	full := rawMessage(3, 1, HeaderSize+8, 1, 0, 0, 0, 2, 0, 0, 0)
+
+	tests := []struct {
+		name  string
+		data  []byte
+		clean bool
+	}{
+		{"BetweenMessages", full, true},
+		{"Nothing", nil, true},
+		{"PartialHeader", full[:5], false},
+		{"PartialBody", full[:HeaderSize+4], false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, server := newConnPair(t)
+			if len(test.data) > 0 {
+				if err := client.writeMsg(test.data, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+			client.Close()
+
+			if len(test.data) == len(full) {
+				if _, err := ReadMessage(server); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := ReadMessage(server)
+			if test.clean {
+				if !errors.Is(err, ErrDisconnected) || !errors.Is(err, io.EOF) {
+					t.Fatalf("expected ErrDisconnected, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, io.ErrUnexpectedEOF) {
+				t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+			}
+			if errors.Is(err, ErrDisconnected) || errors.Is(err, io.EOF) {
+				t.Fatalf("truncated message reported as a clean disconnect: %v", err)
+			}
+		})
+	}
+
+	// Garbage is reported as malformed, not as a disconnect, even when
+	// the connection is closed right after it.
+	client, server := newConnPair(t)
+	if err := client.writeMsg(rawMessage(3, 1, 2, 0, 0, 0, 0), nil); err != nil {
+		t.Fatal(err)
+	}
+	client.Close()
+	_, err := ReadMessage(server)
+	var malformed MalformedMessageError
+	if !errors.As(err, &malformed) || errors.Is(err, ErrDisconnected) {
+		t.Fatalf("expected MalformedMessageError, got %v", err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	client, server := newConnPair(t)
+
+	r, w := newPipe(t)
+	mb := NewMessage(testObject(3), 0)
+	mb.WriteUint(5)
+	mb.WriteString("peek")
+	mb.WriteFile(r)
+	if err := mb.Build(client); err != nil {
+		t.Fatal(err)
+	}
+	next := sendFD(t, client)
+
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := msg.ReadUint(); v != 5 {
+		t.Fatalf("peeked %v, want 5", v)
+	}
+	if s := msg.ReadString(); s != "peek" {
+		t.Fatalf("peeked %q, want %q", s, "peek")
+	}
+	fd := msg.ReadFD()
+	msg.ReadUint()
+	if msg.Err() == nil {
+		t.Fatal("read past the end of the message")
+	}
+
+	msg.Reset()
+	if err := msg.Err(); err != nil {
+		t.Fatalf("error not cleared by Reset: %v", err)
+	}
+	if v := msg.ReadUint(); v != 5 {
+		t.Fatalf("read %v after Reset, want 5", v)
+	}
+	if s := msg.ReadString(); s != "peek" {
+		t.Fatalf("read %q after Reset, want %q", s, "peek")
+	}
+	if again := msg.ReadFD(); again != fd {
+		t.Fatalf("got file descriptor %v after Reset, want %v", again, fd)
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if inode(t, fd) != inode(t, int(w.Fd())) {
+		t.Fatal("message got the wrong file descriptor")
+	}
+	unix.Close(fd)
+
+	// Reading the file descriptor again did not take the next
+	// message's from the connection.
+	msg, err = ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd = msg.ReadFD()
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	defer unix.Close(fd)
+	if inode(t, fd) != inode(t, int(next.Fd())) {
+		t.Fatal("next message got the wrong file descriptor")
+	}
+}
+
+func TestReadStringInto(t *testing.T) {
+	strs := []string{"alpha", "bravo", "a much longer string than the others", ""}
+
+	mb := NewMessage(testObject(3), 0)
+	for _, s := range strs {
+		mb.WriteString(s)
+	}
+	msg := decodeBuilt(t, mb)
+
+	var buf []byte
+	first := msg.ReadStringInto(&buf)
+	kept := strings.Clone(first)
+	for _, want := range strs[1:] {
+		if s := msg.ReadStringInto(&buf); s != want {
+			t.Fatalf("read %q, want %q", s, want)
+		}
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if kept != strs[0] {
+		t.Fatalf("read %q, want %q", kept, strs[0])
+	}
+
+	// The strings share buf's memory, so reading the next one changed
+	// the first.
+	msg.Reset()
+	first = msg.ReadStringInto(&buf)
+	msg.ReadStringInto(&buf)
+	if first != strs[1] {
+		t.Fatalf("first string is %q after reusing the buffer, want %q", first, strs[1])
+	}
+
+	// Once buf is large enough, decoding doesn't allocate.
+	allocs := testing.AllocsPerRun(100, func() {
+		msg.Reset()
+		for range strs {
+			msg.ReadStringInto(&buf)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("decoding %v strings allocated %v times", len(strs), allocs)
+	}
+}
+
+func TestReadStringUnsafe(t *testing.T) {
+	mb := NewMessage(testObject(3), 0)
+	mb.WriteString("alias")
+	mb.WriteString("other")
+	msg := decodeBuilt(t, mb)
+
+	s := msg.ReadStringUnsafe()
+	if s != "alias" {
+		t.Fatalf("read %q, want %q", s, "alias")
+	}
+	if o := msg.ReadStringUnsafe(); o != "other" {
+		t.Fatalf("read %q, want %q", o, "other")
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The string refers to the message's own data, so it changes along
+	// with it.
+	if unsafe.StringData(s) != &msg.raw[4] {
+		t.Fatal("string does not point into the message data")
+	}
+	copy(msg.raw[4:], "ALIAS")
+	if s != "ALIAS" {
+		t.Fatalf("string is %q after its memory was overwritten, want %q", s, "ALIAS")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		msg.Reset()
+		msg.ReadStringUnsafe()
+	})
+	if allocs != 0 {
+		t.Fatalf("ReadStringUnsafe allocated %v times", allocs)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		words  int
+		read   int
+		short  bool
+		remain int
+	}{
+		{"Exact", 2, 2, false, 0},
+		{"Empty", 0, 0, false, 0},
+		{"Trailing", 3, 1, false, 8},
+		{"Short", 1, 2, true, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := make([]byte, 4*test.words)
+			data := rawMessage(3, 1, uint16(HeaderSize+len(body)), body...)
+			msg, err := ReadMessageFrom(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range test.read {
+				msg.ReadUint()
+			}
+			if r := msg.Remaining(); r != test.remain {
+				t.Fatalf("%v bytes remaining, want %v", r, test.remain)
+			}
+
+			err = msg.Verify()
+			switch {
+			case test.short:
+				if !errors.Is(err, ErrShortMessage) || !errors.Is(msg.Err(), ErrShortMessage) {
+					t.Fatalf("expected ErrShortMessage from both Err and Verify, got %v and %v", msg.Err(), err)
+				}
+			case test.remain > 0:
+				if msg.Err() != nil {
+					t.Fatalf("Err reported %v for trailing data", msg.Err())
+				}
+				var merr MalformedMessageError
+				if !errors.As(err, &merr) || !bytes.Equal(merr.Data, data) {
+					t.Fatalf("expected MalformedMessageError with the message data, got %v", err)
+				}
+				if !strings.Contains(err.Error(), fmt.Sprintf("%v bytes left over", test.remain)) {
+					t.Fatalf("error does not say how much was left over: %v", err)
+				}
+			default:
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestReadMessageFrom(t *testing.T) {
+	var buf bytes.Buffer
+	for i := range uint32(3) {
+		mb := NewMessage(testObject(3+i), uint16(i))
+		mb.WriteUint(i * 10)
+		mb.WriteString("plain")
+		mb.encode(&buf)
+	}
+	data := buf.Bytes()
+
+	r := bytes.NewReader(data)
+	for i := range uint32(3) {
+		msg, err := ReadMessageFrom(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (msg.Sender() != 3+i) || (msg.Op() != uint16(i)) {
+			t.Fatalf("message %v is from %v with opcode %v", i, msg.Sender(), msg.Op())
+		}
+		if msg.Conn() != nil {
+			t.Fatal("message read from a plain reader has a Conn")
+		}
+		if v, s := msg.ReadUint(), msg.ReadString(); (v != i*10) || (s != "plain") {
+			t.Fatalf("message %v has arguments %v and %q", i, v, s)
+		}
+		if err := msg.Verify(); err != nil {
+			t.Fatal(err)
+		}
+
+		// There is no connection to take file descriptors from.
+		msg.ReadFD()
+		if err := msg.Err(); !errors.Is(err, ErrNoMoreFDs) {
+			t.Fatalf("expected ErrNoMoreFDs, got %v", err)
+		}
+	}
+
+	if _, err := ReadMessageFrom(r); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF after the last message, got %v", err)
+	}
+
+	_, err := ReadMessageFrom(bytes.NewReader(data[:len(data)/3-2]))
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected io.ErrUnexpectedEOF for a truncated message, got %v", err)
+	}
+}
+
+func TestFDOwnership(t *testing.T) {
+	client, server := newConnPair(t)
+
+	filew := sendFD(t, client)
+	fdw := sendFD(t, client)
+
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := msg.RemainingFDs(); n < 1 {
+		t.Fatalf("got %v remaining file descriptors, want at least 1", n)
+	}
+	file := msg.ReadFile()
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err = ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := msg.ReadFD()
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Neither file descriptor belongs to the connection anymore.
+	client.Close()
+	server.Close()
+	if !readEndOpen(filew) {
+		t.Fatal("closing the connection closed a file returned by ReadFile")
+	}
+	if !readEndOpen(fdw) {
+		t.Fatal("closing the connection closed a file descriptor returned by ReadFD")
+	}
+
+	// Closing them is up to the caller.
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if readEndOpen(filew) {
+		t.Fatal("closing the file returned by ReadFile did not close the file descriptor")
+	}
+	if err := unix.Close(fd); err != nil {
+		t.Fatal(err)
+	}
+	if readEndOpen(fdw) {
+		t.Fatal("closing the file descriptor returned by ReadFD did not close it")
+	}
+}
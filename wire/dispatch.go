@@ -0,0 +1,60 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+)
+
+// Dispatcher routes incoming messages to the objects tracked by a
+// State. It is intended for use by implementations of State that
+// don't need to handle messages any more specially than that.
+type Dispatcher struct {
+	// Conn is the connection that Run reads messages from.
+	Conn *Conn
+
+	// State is used to look up the object that each message was sent
+	// to.
+	State State
+
+	// Unknown is called with messages sent to objects that State
+	// doesn't know about. If it returns nil, the message is dropped.
+	// If Unknown is nil, such messages result in an
+	// UnknownSenderIDError.
+	Unknown func(*MessageBuffer) error
+}
+
+// Dispatch passes msg to the Dispatch method of the object that it
+// was sent to.
+func (d *Dispatcher) Dispatch(msg *MessageBuffer) error {
+	obj := d.State.Get(msg.Sender())
+	if obj == nil {
+		if d.Unknown != nil {
+			return d.Unknown(msg)
+		}
+		return UnknownSenderIDError{Msg: msg}
+	}
+
+	return obj.Dispatch(msg)
+}
+
+// Run reads messages from d.Conn and dispatches them until either ctx
+// is done, in which case the context's error is returned, or an
+// error occurs. If the connection is closed, Run returns nil.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	for {
+		msg, err := ReadMessageContext(ctx, d.Conn)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		err = d.Dispatch(msg)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// recordingObject is an Object that records the opcodes of the
+// messages dispatched to it.
+type recordingObject struct {
+	testObject
+	ops []uint16
+}
+
+func (obj *recordingObject) Dispatch(msg *MessageBuffer) error {
+	obj.ops = append(obj.ops, msg.Op())
+	return nil
+}
+
+// mapState is a State that looks objects up in a map.
+type mapState map[uint32]Object
+
+func (s mapState) Add(obj Object)             { s[obj.ID()] = obj }
+func (s mapState) Get(id uint32) Object       { return s[id] }
+func (s mapState) Enqueue(mb *MessageBuilder) {}
+
+func TestDispatcher(t *testing.T) {
+	client, server := newConnPair(t)
+
+	first, second := &recordingObject{testObject: 3}, &recordingObject{testObject: 4}
+	d := Dispatcher{Conn: server, State: mapState{3: first, 4: second}}
+
+	for _, msg := range []struct {
+		sender uint32
+		op     uint16
+	}{{3, 0}, {4, 1}, {3, 2}, {9, 5}} {
+		if err := NewMessage(testObject(msg.sender), msg.op).Build(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for range 3 {
+		msg, err := ReadMessage(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Dispatch(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !slices.Equal(first.ops, []uint16{0, 2}) || !slices.Equal(second.ops, []uint16{1}) {
+		t.Fatalf("objects received opcodes %v and %v, want [0 2] and [1]", first.ops, second.ops)
+	}
+
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.Dispatch(msg)
+	var serr UnknownSenderIDError
+	if !errors.As(err, &serr) || (serr.Msg.Sender() != 9) {
+		t.Fatalf("expected UnknownSenderIDError for object 9, got %v", err)
+	}
+
+	var unknown []uint32
+	d.Unknown = func(msg *MessageBuffer) error {
+		unknown = append(unknown, msg.Sender())
+		return nil
+	}
+	if err := d.Dispatch(msg); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(unknown, []uint32{9}) {
+		t.Fatalf("Unknown was called for %v, want [9]", unknown)
+	}
+}
+
+func TestDispatcherRun(t *testing.T) {
+	client, server := newConnPair(t)
+
+	obj := &recordingObject{testObject: 3}
+	d := Dispatcher{Conn: server, State: mapState{3: obj}}
+
+	for op := range uint16(3) {
+		if err := NewMessage(testObject(3), op).Build(client); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client.Close()
+
+	// Run stops without an error once the other end has disconnected.
+	if err := d.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(obj.ops, []uint16{0, 1, 2}) {
+		t.Fatalf("object received opcodes %v, want [0 1 2]", obj.ops)
+	}
+}
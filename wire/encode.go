@@ -104,6 +104,16 @@ func (mb *MessageBuilder) WriteString(v string) {
 	}
 }
 
+// WriteNullableString writes a string argument that is allowed to be
+// null. If v is nil, a null string is written.
+func (mb *MessageBuilder) WriteNullableString(v *string) {
+	if v == nil {
+		mb.WriteUint(0)
+		return
+	}
+	mb.WriteString(*v)
+}
+
 func (mb *MessageBuilder) WriteArray(v []byte) {
 	if mb.err != nil {
 		return
@@ -135,25 +145,105 @@ func (mb *MessageBuilder) WriteFD(file int) {
 	mb.fds = append(mb.fds, fd)
 }
 
-// Build builds the message and sends it to c. The MessageBuilder
-// should not be used again after this method is called.
+// Fail records err as the error that building mb fails with, unless
+// an earlier error has already been recorded. A nil err is ignored. It
+// is intended for generated code that detects invalid arguments.
+func (mb *MessageBuilder) Fail(err error) {
+	if mb.err == nil {
+		mb.err = err
+	}
+}
+
+// CheckMessageSize returns a MessageSizeError if mb has become too
+// large to be sent. It is used by generated code to report messages
+// that are too large because of the arguments that they were given,
+// such as a very long string, before they are sent.
+func CheckMessageSize(iface, method string, mb *MessageBuilder) error {
+	size := HeaderSize + mb.data.Len()
+	if size > MaxMessageSize {
+		return MessageSizeError{Interface: iface, Method: method, Size: size}
+	}
+	return nil
+}
+
+// Build builds the message and sends it to c. If c has buffered
+// messages that have not been sent yet, they are flushed first so
+// that messages always arrive in the order in which they were built.
+// The MessageBuilder should not be used again after this method is
+// called.
 func (mb *MessageBuilder) Build(c *Conn) error {
-	if mb.err != nil {
-		return mb.err
+	if err := mb.check(); err != nil {
+		return err
 	}
 
-	length := uint32(8 + mb.data.Len())
-	msg := bytes.NewBuffer(make([]byte, 0, length))
-	bin.Write(msg, mb.sender.ID())
-	bin.Write(msg, (length<<16)|uint32(mb.op))
+	var msg bytes.Buffer
+	mb.encode(&msg)
 
-	io.Copy(msg, &mb.data)
-	oob := unix.UnixRights(mb.fds...)
+	c.om.Lock()
+	defer c.om.Unlock()
 
-	_, _, mb.err = c.conn.WriteMsgUnix(msg.Bytes(), oob, nil)
+	if mb.err = c.flushLocked(); mb.err != nil {
+		return mb.err
+	}
+
+	mb.err = c.writeMsg(msg.Bytes(), mb.fds)
+	if mb.err == nil {
+		c.record(Sent, msg.Bytes(), len(mb.fds))
+	}
 	return mb.err
 }
 
+// BuildBuffered builds the message and adds it to c's output buffer
+// instead of sending it immediately. Buffered messages are sent
+// together, with as few system calls as possible, when c.Flush is
+// called, when a message is sent with Build, or when adding mb would
+// take the amount of buffered data over OutBufferSize, in which case
+// the buffer is flushed before mb is added. It is safe to call
+// concurrently: each message is added to the buffer as a whole, in
+// the order in which the calls happen. The MessageBuilder should not
+// be used again after this method is called.
+//
+// The returned error is either from checking mb, in which case mb is
+// not added to the buffer, or from automatically flushing the buffer.
+func (mb *MessageBuilder) BuildBuffered(c *Conn) error {
+	if err := mb.check(); err != nil {
+		return err
+	}
+
+	c.om.Lock()
+	defer c.om.Unlock()
+
+	if c.out.Buffered()+HeaderSize+mb.data.Len() > OutBufferSize {
+		if mb.err = c.out.Flush(); mb.err != nil {
+			return mb.err
+		}
+	}
+
+	return c.out.Write(mb)
+}
+
+// check returns an error if mb can not be sent.
+func (mb *MessageBuilder) check() error {
+	if mb.err != nil {
+		return mb.err
+	}
+	if len(mb.fds) > maxFDs {
+		return ErrMessageFDs
+	}
+	return checkSize(HeaderSize + mb.data.Len())
+}
+
+// encode writes the complete message, including the header, to dst.
+func (mb *MessageBuilder) encode(dst *bytes.Buffer) {
+	length := HeaderSize + mb.data.Len()
+	dst.Grow(length)
+
+	var header [HeaderSize]byte
+	EncodeHeader(header[:], mb.sender.ID(), mb.op, uint16(length))
+	dst.Write(header[:])
+	dst.Write(mb.data.Bytes())
+}
+
 func (mb *MessageBuilder) close() {
 	errs := make([]error, 0, len(mb.fds))
 	for _, fd := range mb.fds {
@@ -167,19 +257,81 @@ func (mb *MessageBuilder) close() {
 }
 
 func (mb *MessageBuilder) String() string {
-	args := make([]string, 0, len(mb.Args))
-	for _, arg := range mb.Args {
+	return fmt.Sprintf("%v.%v(%v)", mb.sender, mb.Method, formatArgs(mb.Args))
+}
+
+// Encoder writes message arguments to an io.Writer. File descriptors
+// can't be written to the stream along with the rest of the arguments,
+// so they are collected separately instead and the caller is
+// responsible for sending them together with the written data, such
+// as with unix.UnixRights. The file descriptors are not duplicated, so
+// the files that they came from must be kept open until they have
+// been sent.
+type Encoder struct {
+	w   io.Writer
+	fds []int
+}
+
+// NewEncoder returns an Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes val as a single argument, encoding it the same way
+// as MessageBuilder.WriteArg does. The file descriptor of an *os.File
+// is added to the list returned by Fds instead of being written.
+func (e *Encoder) Encode(val any) error {
+	if file, ok := val.(*os.File); ok {
+		e.fds = append(e.fds, int(file.Fd()))
+		return nil
+	}
+
+	var mb MessageBuilder
+	mb.WriteArg(val)
+	if mb.err != nil {
+		return mb.err
+	}
+
+	_, err := e.w.Write(mb.data.Bytes())
+	return err
+}
+
+// Fds returns the file descriptors that have been encoded so far, in
+// the order in which they were encoded.
+func (e *Encoder) Fds() []int {
+	return e.fds
+}
+
+// formatArgs formats message arguments for debug output the same way
+// that libwayland does.
+func formatArgs(args []any) string {
+	strs := make([]string, 0, len(args))
+	for _, arg := range args {
 		switch arg := arg.(type) {
 		case string:
-			args = append(args, strconv.Quote(arg))
+			strs = append(strs, strconv.Quote(arg))
+		case *string:
+			strs = append(strs, quoteNullable(arg))
 		case *os.File:
-			args = append(args, fmt.Sprint(arg.Fd()))
+			strs = append(strs, fmt.Sprintf("fd %v", arg.Fd()))
+		case Object:
+			if isNil(arg) {
+				strs = append(strs, "nil")
+				continue
+			}
+			strs = append(strs, fmt.Sprint(arg))
 		default:
-			args = append(args, fmt.Sprint(arg))
+			strs = append(strs, fmt.Sprint(arg))
 		}
 	}
+	return strings.Join(strs, ", ")
+}
 
-	return fmt.Sprintf("%v.%v(%v)", mb.sender, mb.Method, strings.Join(args, ", "))
+func quoteNullable(v *string) string {
+	if v == nil {
+		return "nil"
+	}
+	return strconv.Quote(*v)
 }
 
 func isNil(v any) bool {
@@ -0,0 +1,90 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"deedles.dev/wl/internal/bin"
+)
+
+// arrayMessage returns a message whose total size, including the
+// header, is size bytes. size must be a multiple of 4.
+func arrayMessage(op uint16, size int) *MessageBuilder {
+	mb := NewMessage(testObject(3), op)
+	mb.WriteArray(make([]byte, size-HeaderSize-4))
+	return mb
+}
+
+func TestMessageTooLarge(t *testing.T) {
+	const largest = MaxMessageSize &^ 3
+
+	client, server := newConnPair(t)
+
+	send := []struct {
+		name string
+		send func(*MessageBuilder) error
+	}{
+		{"Build", func(mb *MessageBuilder) error { return mb.Build(client) }},
+		{"BuildBuffered", func(mb *MessageBuilder) error { return mb.BuildBuffered(client) }},
+		{"MessageWriter", func(mb *MessageBuilder) error {
+			w := NewMessageWriter(client)
+			if err := w.Write(mb); err != nil {
+				return err
+			}
+			return w.Flush()
+		}},
+	}
+
+	for op, s := range send {
+		if err := s.send(arrayMessage(uint16(op), largest+4)); !errors.Is(err, ErrMessageTooLarge) {
+			t.Fatalf("%v: expected ErrMessageTooLarge, got %v", s.name, err)
+		}
+		if err := client.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		// The rejected message must not have been sent, so the first
+		// message to arrive is the one after it.
+		if err := s.send(arrayMessage(uint16(op), largest)); err != nil {
+			t.Fatalf("%v: %v", s.name, err)
+		}
+		if err := client.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		msg, err := ReadMessage(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (msg.Op() != uint16(op)) || (msg.Size() != largest) {
+			t.Fatalf("%v: got message %v of size %v, want %v of size %v", s.name, msg.Op(), msg.Size(), op, largest)
+		}
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	r, _ := newPipe(t)
+
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+	for _, val := range []any{"hello", int32(-2), r} {
+		if err := e.Encode(val); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	word := bin.Bytes(int32(-2))
+	body := append(stringArg(6, []byte("hello\x00")), word[:]...)
+	if !bytes.Equal(buf.Bytes(), body) {
+		t.Fatalf("encoded %x, want %x", buf.Bytes(), body)
+	}
+	if fds := e.Fds(); !slices.Equal(fds, []int{int(r.Fd())}) {
+		t.Fatalf("collected fds %v, want [%v]", fds, r.Fd())
+	}
+
+	if err := e.Encode(42); err == nil {
+		t.Fatal("expected an error for an int argument")
+	}
+}
@@ -0,0 +1,84 @@
+package wire
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Enum is the set of types that enums in generated code can have.
+type Enum interface {
+	~int32 | ~uint32 | ~int64
+}
+
+// EnumString returns the name of value from names, or value formatted
+// as a decimal number if it has no name. It is primarily intended for
+// use by generated code.
+func EnumString[T Enum](value T, names map[T]string) string {
+	if name, ok := names[value]; ok {
+		return name
+	}
+	return strconv.FormatInt(int64(value), 10)
+}
+
+// FlagString returns a string representation of a bitfield enum
+// value. The names of the set bits are joined with "|" and any bits
+// that have no corresponding entry in names are appended as a single
+// hexadecimal number. It is primarily intended for use by generated
+// code.
+func FlagString[T Enum](value T, names map[T]string) string {
+	if value == 0 {
+		if name, ok := names[0]; ok {
+			return name
+		}
+		return "0"
+	}
+
+	var parts []string
+	for _, flag := range slices.Sorted(maps.Keys(names)) {
+		if (flag == 0) || (value&flag != flag) {
+			continue
+		}
+
+		parts = append(parts, names[flag])
+		value &^= flag
+	}
+	if value != 0 {
+		parts = append(parts, fmt.Sprintf("%#x", uint64(value)))
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// VersionedEnum is implemented by generated enum types, whose values
+// can have been added in later versions of their interface.
+type VersionedEnum interface {
+	Enum
+	fmt.Stringer
+
+	// Since returns the version of the enum's interface that
+	// introduced the value.
+	Since() uint32
+}
+
+// CheckEnumVersion returns an EnumVersionError if value was added in a
+// later version of iface than version, the version of the object that
+// it is being sent to or received by in a message for method. A version
+// of 0, which objects have until they are bound, is not checked. It is
+// primarily intended for use by generated code.
+func CheckEnumVersion[T VersionedEnum](iface, method string, version uint32, value T) error {
+	since := value.Since()
+	if (version == 0) || (since <= version) {
+		return nil
+	}
+
+	return EnumVersionError{
+		Interface: iface,
+		Method:    method,
+		Value:     value.String(),
+		Since:     since,
+		Version:   version,
+	}
+}
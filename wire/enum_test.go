@@ -0,0 +1,50 @@
+package wire
+
+import "testing"
+
+type testFlags uint32
+
+var testFlagNames = map[testFlags]string{
+	0: "None",
+	1: "Top",
+	2: "Bottom",
+	4: "Left",
+	8: "Right",
+}
+
+func TestFlagString(t *testing.T) {
+	tests := []struct {
+		value testFlags
+		str   string
+	}{
+		{0, "None"},
+		{1, "Top"},
+		{1 | 4, "Top|Left"},
+		{8 | 2, "Bottom|Right"},
+		{1 | 2 | 4 | 8, "Top|Bottom|Left|Right"},
+		{1 | 0x40, "Top|0x40"},
+		{0x30, "0x30"},
+	}
+
+	for _, test := range tests {
+		if got := FlagString(test.value, testFlagNames); got != test.str {
+			t.Errorf("FlagString(%#x) = %q, want %q", uint32(test.value), got, test.str)
+		}
+	}
+}
+
+func TestFlagStringNoZeroName(t *testing.T) {
+	names := map[testFlags]string{1: "A", 2: "B"}
+	if got := FlagString(testFlags(0), names); got != "0" {
+		t.Errorf("got %q, want %q", got, "0")
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	if got := EnumString(testFlags(2), testFlagNames); got != "Bottom" {
+		t.Errorf("got %q, want %q", got, "Bottom")
+	}
+	if got := EnumString(testFlags(3), testFlagNames); got != "3" {
+		t.Errorf("got %q, want %q", got, "3")
+	}
+}
@@ -1,9 +1,66 @@
 package wire
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 )
 
+// ErrDisconnected is returned when the remote end of a connection
+// closes it cleanly. It wraps io.EOF.
+var ErrDisconnected = fmt.Errorf("connection closed by remote end: %w", io.EOF)
+
+// ErrNoCompositor is returned by Dial if there is no socket at the
+// path that it tries to connect to.
+var ErrNoCompositor = errors.New("no Wayland compositor socket found")
+
+// ErrStaleSocket is returned by Dial if the socket that it tries to
+// connect to exists but nothing is listening on it, usually because
+// the compositor that created it is no longer running.
+var ErrStaleSocket = errors.New("socket is not accepting connections")
+
+// ErrIdleTimeout is returned when no message arrives within the idle
+// timeout set with Conn.SetIdleTimeout. It wraps
+// os.ErrDeadlineExceeded.
+var ErrIdleTimeout = fmt.Errorf("no message received within idle timeout: %w", os.ErrDeadlineExceeded)
+
+// ErrShortMessage is returned when decoding an argument that would
+// extend past the end of the message. It wraps io.ErrUnexpectedEOF.
+var ErrShortMessage = fmt.Errorf("message too short for its arguments: %w", io.ErrUnexpectedEOF)
+
+// ErrNotNullTerminated is returned when decoding a string argument
+// whose data does not end with a null byte.
+var ErrNotNullTerminated = errors.New("string is not null-terminated")
+
+// ErrNonzeroPadding is the error set when the padding after a string
+// or array argument is not all zeroes and the Conn that the message
+// was read from requires it to be. See Conn.SetStrictPadding.
+var ErrNonzeroPadding = errors.New("nonzero padding after argument")
+
+// ErrLengthOverflow is returned when decoding a string or array
+// argument whose declared length is longer than either the rest of
+// the message or the limit set with Conn.SetArgLimit.
+var ErrLengthOverflow = errors.New("argument length too large")
+
+// ErrNoMoreFDs is returned when decoding a file descriptor argument
+// when no more file descriptors have been received.
+var ErrNoMoreFDs = errors.New("no more file descriptors")
+
+// ErrTooManyFDs is returned when more file descriptors have been
+// received than the limit set with Conn.SetFDLimit.
+var ErrTooManyFDs = errors.New("too many file descriptors received")
+
+// ErrMessageTooLarge is returned when attempting to send a message
+// that is larger than MaxMessageSize.
+var ErrMessageTooLarge = errors.New("message too large")
+
+// ErrMessageFDs is returned when attempting to send a single message
+// with more file descriptors attached to it than can be sent in one
+// write. No message in any known protocol needs more than a handful.
+var ErrMessageFDs = errors.New("too many file descriptors attached to message")
+
 // UnknownOpError is returned by Object.Dispatch if it is given a
 // message with an invalid opcode.
 type UnknownOpError struct {
@@ -16,6 +73,80 @@ func (err UnknownOpError) Error() string {
 	return fmt.Sprintf("unknown %v opcode for %v: %v", err.Type, err.Interface, err.Op)
 }
 
+// MissingFDsError is returned by Object.Dispatch if a message has
+// more file descriptor arguments than there are file descriptors
+// available to decode them with. This is checked before any of the
+// message's arguments are decoded.
+type MissingFDsError struct {
+	Interface string
+	Method    string
+	Want      int
+	Have      int
+}
+
+func (err MissingFDsError) Error() string {
+	return fmt.Sprintf("%v.%v needs %v file descriptors but only %v are available", err.Interface, err.Method, err.Want, err.Have)
+}
+
+// VersionError is returned when attempting to bind a global for
+// which there is no version supported by both ends of the connection.
+type VersionError struct {
+	Interface string
+	Local     uint32
+	Remote    uint32
+}
+
+func (err VersionError) Error() string {
+	return fmt.Sprintf("no common version of %v: local supports up to %v, remote advertised %v", err.Interface, err.Local, err.Remote)
+}
+
+// EnumVersionError is returned when a message for an object contains
+// an enum value that was added in a later version of the object's
+// interface than the one that the object was bound with.
+type EnumVersionError struct {
+	Interface string
+	Method    string
+	Value     string
+	Since     uint32
+	Version   uint32
+}
+
+func (err EnumVersionError) Error() string {
+	return fmt.Sprintf("%v.%v: %v requires version %v, but object is version %v", err.Interface, err.Method, err.Value, err.Since, err.Version)
+}
+
+// MessageSizeError is returned when a message being sent would be
+// larger than MaxMessageSize because of the values of its arguments.
+// It wraps ErrMessageTooLarge.
+type MessageSizeError struct {
+	Interface string
+	Method    string
+	Size      int
+}
+
+func (err MessageSizeError) Error() string {
+	return fmt.Sprintf("%v.%v: message would be %v bytes, more than the maximum of %v", err.Interface, err.Method, err.Size, MaxMessageSize)
+}
+
+func (err MessageSizeError) Unwrap() error {
+	return ErrMessageTooLarge
+}
+
+// BindError is returned when the new_id sent in a request to bind a
+// global does not match the global's interface or supported versions.
+type BindError struct {
+	Interface string
+	Version   uint32
+	ID        NewID
+}
+
+func (err BindError) Error() string {
+	if err.ID.Interface != err.Interface {
+		return fmt.Sprintf("attempted to bind %v global as %v", err.Interface, err.ID.Interface)
+	}
+	return fmt.Sprintf("attempted to bind %v at version %v, but only versions 1 through %v are supported", err.Interface, err.ID.Version, err.Version)
+}
+
 // UnknownSenderIDError is returned by an attempt to dispatch an
 // incoming message that indicates a method call on an object that the
 // State doesn't know about.
@@ -26,3 +157,29 @@ type UnknownSenderIDError struct {
 func (err UnknownSenderIDError) Error() string {
 	return fmt.Sprintf("unknown sender object ID: %v", err.Msg.Sender())
 }
+
+// MalformedMessageError is returned when a message can't be decoded
+// because its data is invalid.
+type MalformedMessageError struct {
+	Sender uint32
+	Op     uint16
+
+	// Data is the raw message, including the header. If the header
+	// itself was invalid, it contains only the header.
+	Data []byte
+
+	Err error
+}
+
+func (err MalformedMessageError) Error() string {
+	return fmt.Sprintf("malformed message for object %v, opcode %v: %v", err.Sender, err.Op, err.Err)
+}
+
+func (err MalformedMessageError) Unwrap() error {
+	return err.Err
+}
+
+// Dump returns a hex dump of the message's data.
+func (err MalformedMessageError) Dump() string {
+	return hex.Dump(err.Data)
+}
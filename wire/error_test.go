@@ -0,0 +1,137 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   []byte
+		decode func(*MessageBuffer)
+		want   error
+	}{
+		{"ShortMessage", nil, func(msg *MessageBuffer) { msg.ReadUint() }, ErrShortMessage},
+		{"NotNullTerminated", stringArg(4, []byte("abcd")), func(msg *MessageBuffer) { msg.ReadString() }, ErrNotNullTerminated},
+		{"LengthOverflow", stringArg(64, []byte("abc\x00")), func(msg *MessageBuffer) { msg.ReadString() }, ErrLengthOverflow},
+		{"NoMoreFDs", nil, func(msg *MessageBuffer) { msg.ReadFD() }, ErrNoMoreFDs},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, server := newConnPair(t)
+
+			data := rawMessage(3, 2, uint16(HeaderSize+len(test.body)), test.body...)
+			msg := sendRaw(t, client, server, data)
+			test.decode(msg)
+			checkDecodeError(t, msg.Err(), test.want)
+		})
+	}
+}
+
+func checkDecodeError(t *testing.T, err, want error) {
+	t.Helper()
+
+	if !errors.Is(err, want) {
+		t.Fatalf("expected %v, got %v", want, err)
+	}
+	var merr MalformedMessageError
+	if !errors.As(err, &merr) || (merr.Sender != 3) || (merr.Op != 2) {
+		t.Fatalf("expected MalformedMessageError for object 3, opcode 2, got %v", err)
+	}
+}
+
+func TestErrorWrapping(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		is   []error
+	}{
+		{"Disconnected", ErrDisconnected, []error{io.EOF}},
+		{"IdleTimeout", ErrIdleTimeout, []error{os.ErrDeadlineExceeded}},
+		{"ShortMessage", ErrShortMessage, []error{io.ErrUnexpectedEOF}},
+		{"NoCompositor", fmt.Errorf("dial: %w", ErrNoCompositor), []error{ErrNoCompositor}},
+		{"StaleSocket", fmt.Errorf("dial: %w", ErrStaleSocket), []error{ErrStaleSocket}},
+		{"MessageSize", MessageSizeError{Interface: "wl_surface", Method: "commit", Size: MaxMessageSize + 4}, []error{ErrMessageTooLarge}},
+		{"Malformed", MalformedMessageError{Err: ErrShortMessage}, []error{ErrShortMessage, io.ErrUnexpectedEOF}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, target := range test.is {
+				if !errors.Is(test.err, target) {
+					t.Errorf("%v does not wrap %v", test.err, target)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorTypes(t *testing.T) {
+	wrap := func(err error) error { return fmt.Errorf("dispatch: %w", err) }
+
+	var berr BindError
+	err := wrap(NewID{Interface: "wl_seat", Version: 9, ID: 4}.Check("wl_seat", 7))
+	if !errors.As(err, &berr) || (berr.ID.Version != 9) || (berr.Version != 7) {
+		t.Errorf("expected BindError for version 9, got %v", err)
+	}
+
+	var ferr MissingFDsError
+	err = wrap(MissingFDsError{Interface: "wl_keyboard", Method: "keymap", Want: 1, Have: 0})
+	if !errors.As(err, &ferr) || (ferr.Method != "keymap") || (ferr.Want != 1) {
+		t.Errorf("expected MissingFDsError for keymap, got %v", err)
+	}
+
+	var verr VersionError
+	err = wrap(VersionError{Interface: "wl_output", Local: 4, Remote: 0})
+	if !errors.As(err, &verr) || (verr.Interface != "wl_output") {
+		t.Errorf("expected VersionError for wl_output, got %v", err)
+	}
+
+	var eerr EnumVersionError
+	err = wrap(EnumVersionError{Interface: "wl_output", Method: "geometry", Since: 2, Version: 1})
+	if !errors.As(err, &eerr) || (eerr.Since != 2) {
+		t.Errorf("expected EnumVersionError, got %v", err)
+	}
+
+	var oerr UnknownOpError
+	err = wrap(UnknownOpError{Interface: "wl_output", Type: "event", Op: 9})
+	if !errors.As(err, &oerr) || (oerr.Op != 9) {
+		t.Errorf("expected UnknownOpError for opcode 9, got %v", err)
+	}
+}
+
+func TestMalformedMessageError(t *testing.T) {
+	client, server := newConnPair(t)
+
+	data := rawMessage(7, 4, HeaderSize+8, stringArg(9, []byte("bad"))...)
+	msg := sendRaw(t, client, server, data)
+	msg.ReadString()
+
+	var merr MalformedMessageError
+	if err := msg.Err(); !errors.As(err, &merr) {
+		t.Fatalf("expected MalformedMessageError, got %v", err)
+	}
+	if (merr.Sender != 7) || (merr.Op != 4) {
+		t.Fatalf("error has sender %v and opcode %v, want 7 and 4", merr.Sender, merr.Op)
+	}
+	if !bytes.Equal(merr.Data, data) {
+		t.Fatalf("error data is %x, want the whole message, %x", merr.Data, data)
+	}
+	if !errors.Is(merr, ErrLengthOverflow) {
+		t.Fatalf("error wraps %v, want ErrLengthOverflow", merr.Err)
+	}
+
+	if s := merr.Error(); !strings.Contains(s, "object 7") || !strings.Contains(s, "opcode 4") {
+		t.Fatalf("error message %q does not name the message", s)
+	}
+	if dump := merr.Dump(); !strings.Contains(dump, "07 00 00 00") {
+		t.Fatalf("dump does not contain the header:\n%v", dump)
+	}
+}
@@ -1,45 +1,74 @@
 package wire
 
-import (
-	"fmt"
-	"math"
-	"strings"
-	"unsafe"
-)
+import "strconv"
 
 // Fixed is a 24_8 fixed-point number. Wayland does not have support
 // for floating point numbers in its core protocol and uses these
-// instead.
+// instead. It is a signed, two's complement number with 8 fractional
+// bits, the same as libwayland's wl_fixed_t, so a Fixed can be
+// converted to and from an int32 to get its raw representation.
 type Fixed int32
 
+// FixedFromInt returns v as a Fixed. Values outside of the range of a
+// Fixed wrap around.
+func FixedFromInt(v int) Fixed {
+	return Fixed(v * 256)
+}
+
+// FixedFromFloat returns v as a Fixed. As with libwayland's
+// wl_fixed_from_double, any precision beyond the 8 fractional bits is
+// truncated towards zero.
+func FixedFromFloat(v float64) Fixed {
+	return Fixed(v * 256)
+}
+
+// FixedInt is the same as FixedFromInt.
 func FixedInt(v int) Fixed {
-	return Fixed(v << 8)
+	return FixedFromInt(v)
 }
 
+// FixedFloat is the same as FixedFromFloat.
 func FixedFloat(v float64) Fixed {
-	i, frac := math.Modf(v)
-	return Fixed((int(i) << 8) | int(math.Abs(frac)*math.Exp2(8)))
+	return FixedFromFloat(v)
 }
 
+// Int returns the integer part of f, truncating towards zero the same
+// as libwayland's wl_fixed_to_int. For other rounding behavior, see
+// Floor, Ceil, and Round.
 func (f Fixed) Int() int {
-	return int(f >> 8)
+	return int(f) / 256
+}
+
+// Floor returns the greatest integer less than or equal to f.
+func (f Fixed) Floor() int {
+	return int(f) >> 8
+}
+
+// Ceil returns the least integer greater than or equal to f.
+func (f Fixed) Ceil() int {
+	return (int(f) + 0xFF) >> 8
+}
+
+// Round returns the integer nearest to f, rounding halfway values up
+// towards positive infinity, so that 1.5 becomes 2 and -1.5 becomes
+// -1. This matches the rounding usually expected when converting
+// surface-local coordinates to pixels.
+func (f Fixed) Round() int {
+	return (int(f) + 0x80) >> 8
 }
 
+// Frac returns the fractional bits of f, in 256ths. As f is in two's
+// complement, this is the amount that f is above f.Floor(), so it is
+// never negative.
 func (f Fixed) Frac() int {
-	return int(*(*uint32)(unsafe.Pointer(&f)) & 0xFF)
+	return int(f) & 0xFF
 }
 
+// Float returns f as a float64. The conversion is always exact.
 func (f Fixed) Float() float64 {
-	i := f.Int()
-	frac := f.Frac()
-	return float64(i) + math.Abs(float64(frac)*math.Exp2(-8))
+	return float64(f) / 256
 }
 
 func (f Fixed) String() string {
-	var sb strings.Builder
-	fmt.Fprint(&sb, f.Int())
-	if frac := f.Frac(); frac != 0 {
-		fmt.Fprintf(&sb, ".%v", frac)
-	}
-	return sb.String()
+	return strconv.FormatFloat(f.Float(), 'f', -1, 64)
 }
@@ -0,0 +1,146 @@
+package wire
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFixedFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Fixed
+		raw  int32
+	}{
+		{"Int(0)", FixedFromInt(0), 0},
+		{"Int(1)", FixedFromInt(1), 256},
+		{"Int(-1)", FixedFromInt(-1), -256},
+		{"Int(-3)", FixedFromInt(-3), -768},
+		{"Int(max)", FixedFromInt(1<<23 - 1), math.MaxInt32 - 0xFF},
+		{"Int(min)", FixedFromInt(-1 << 23), math.MinInt32},
+		{"Float(1.5)", FixedFromFloat(1.5), 384},
+		{"Float(-1.5)", FixedFromFloat(-1.5), -384},
+		{"Float(1/256)", FixedFromFloat(1.0 / 256), 1},
+		{"Float(-1/256)", FixedFromFloat(-1.0 / 256), -1},
+		{"Float(0.999)", FixedFromFloat(0.999), 255},
+		{"Float(-0.999)", FixedFromFloat(-0.999), -255},
+		{"Float(2.004)", FixedFromFloat(2.004), 513},
+		{"Float(-2.004)", FixedFromFloat(-2.004), -513},
+	}
+
+	for _, test := range tests {
+		if int32(test.got) != test.raw {
+			t.Errorf("%v: got raw %v, want %v", test.name, int32(test.got), test.raw)
+		}
+	}
+}
+
+func TestFixedRounding(t *testing.T) {
+	tests := []struct {
+		raw                         int32
+		i, floor, ceil, round, frac int
+	}{
+		{raw: 0, i: 0, floor: 0, ceil: 0, round: 0, frac: 0},
+		{raw: 1, i: 0, floor: 0, ceil: 1, round: 0, frac: 1},
+		{raw: -1, i: 0, floor: -1, ceil: 0, round: 0, frac: 255},
+		{raw: 127, i: 0, floor: 0, ceil: 1, round: 0, frac: 127},
+		{raw: 128, i: 0, floor: 0, ceil: 1, round: 1, frac: 128},
+		{raw: -128, i: 0, floor: -1, ceil: 0, round: 0, frac: 128},
+		{raw: -129, i: 0, floor: -1, ceil: 0, round: -1, frac: 127},
+		{raw: 255, i: 0, floor: 0, ceil: 1, round: 1, frac: 255},
+		{raw: 256, i: 1, floor: 1, ceil: 1, round: 1, frac: 0},
+		{raw: 257, i: 1, floor: 1, ceil: 2, round: 1, frac: 1},
+		{raw: -256, i: -1, floor: -1, ceil: -1, round: -1, frac: 0},
+		{raw: -257, i: -1, floor: -2, ceil: -1, round: -1, frac: 255},
+		{raw: 384, i: 1, floor: 1, ceil: 2, round: 2, frac: 128},
+		{raw: -384, i: -1, floor: -2, ceil: -1, round: -1, frac: 128},
+		{raw: math.MaxInt32, i: 1<<23 - 1, floor: 1<<23 - 1, ceil: 1 << 23, round: 1 << 23, frac: 255},
+		{raw: math.MinInt32, i: -1 << 23, floor: -1 << 23, ceil: -1 << 23, round: -1 << 23, frac: 0},
+	}
+
+	for _, test := range tests {
+		f := Fixed(test.raw)
+		if got := f.Int(); got != test.i {
+			t.Errorf("Fixed(%v).Int() = %v, want %v", test.raw, got, test.i)
+		}
+		if got := f.Floor(); got != test.floor {
+			t.Errorf("Fixed(%v).Floor() = %v, want %v", test.raw, got, test.floor)
+		}
+		if got := f.Ceil(); got != test.ceil {
+			t.Errorf("Fixed(%v).Ceil() = %v, want %v", test.raw, got, test.ceil)
+		}
+		if got := f.Round(); got != test.round {
+			t.Errorf("Fixed(%v).Round() = %v, want %v", test.raw, got, test.round)
+		}
+		if got := f.Frac(); got != test.frac {
+			t.Errorf("Fixed(%v).Frac() = %v, want %v", test.raw, got, test.frac)
+		}
+		if got := FixedFromFloat(f.Float()); got != f {
+			t.Errorf("Fixed(%v) did not survive a round trip through float64: got %v", test.raw, int32(got))
+		}
+	}
+}
+
+func TestFixedString(t *testing.T) {
+	tests := []struct {
+		raw int32
+		str string
+	}{
+		{0, "0"},
+		{256, "1"},
+		{-256, "-1"},
+		{384, "1.5"},
+		{-384, "-1.5"},
+		{1, "0.00390625"},
+		{-1, "-0.00390625"},
+		{-257, "-1.00390625"},
+		{math.MaxInt32, "8388607.99609375"},
+		{math.MinInt32, "-8388608"},
+	}
+
+	for _, test := range tests {
+		if got := Fixed(test.raw).String(); got != test.str {
+			t.Errorf("Fixed(%v).String() = %q, want %q", test.raw, got, test.str)
+		}
+	}
+}
+
+func TestReadFixedPair(t *testing.T) {
+	pairs := [][2]float64{{0, 0}, {1.5, -2.25}, {-100.5, 3000.75}, {0.00390625, -0.00390625}}
+
+	for _, pair := range pairs {
+		mb := NewMessage(testObject(3), 0)
+		mb.WriteFixed(FixedFromFloat(pair[0]))
+		mb.WriteFixed(FixedFromFloat(pair[1]))
+		mb.WriteUint(7)
+		msg := decodeBuilt(t, mb)
+
+		x, y := msg.ReadFixedPair()
+		if (x.Float() != pair[0]) || (y.Float() != pair[1]) {
+			t.Errorf("read (%v, %v), want (%v, %v)", x, y, pair[0], pair[1])
+		}
+
+		// The result is the same as reading them separately.
+		msg.Reset()
+		if (msg.ReadFixed() != x) || (msg.ReadFixed() != y) {
+			t.Errorf("ReadFixed disagrees with ReadFixedPair for %v", pair)
+		}
+		if v := msg.ReadUint(); v != 7 {
+			t.Errorf("read %v after the pair, want 7", v)
+		}
+		if err := msg.Verify(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// Only one of the pair is in the message.
+	mb := NewMessage(testObject(3), 0)
+	mb.WriteFixed(FixedFromFloat(1))
+	msg := decodeBuilt(t, mb)
+	if x, y := msg.ReadFixedPair(); (x != 0) || (y != 0) {
+		t.Errorf("read (%v, %v) from a short message", x, y)
+	}
+	if err := msg.Err(); !errors.Is(err, ErrShortMessage) {
+		t.Errorf("expected ErrShortMessage, got %v", err)
+	}
+}
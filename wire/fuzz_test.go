@@ -0,0 +1,149 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fuzzReaders are the ways in which FuzzDecode can decode an argument.
+var fuzzReaders = []func(*MessageBuffer){
+	func(msg *MessageBuffer) { msg.ReadInt() },
+	func(msg *MessageBuffer) { msg.ReadUint() },
+	func(msg *MessageBuffer) { msg.ReadFixed() },
+	func(msg *MessageBuffer) { msg.ReadFixedPair() },
+	func(msg *MessageBuffer) { msg.ReadObject() },
+	func(msg *MessageBuffer) { msg.ReadNewID() },
+	func(msg *MessageBuffer) { msg.ReadString() },
+	func(msg *MessageBuffer) { msg.ReadNullableString() },
+	func(msg *MessageBuffer) { msg.ReadArray() },
+	func(msg *MessageBuffer) { msg.ReadStringArray() },
+	func(msg *MessageBuffer) { ReadArrayOf[int32](msg) },
+	func(msg *MessageBuffer) { msg.ReadFile() },
+	func(msg *MessageBuffer) { msg.ReadStringUnsafe() },
+}
+
+// fuzzSeed is a valid message along with the indices into
+// fuzzReaders that decode it completely.
+type fuzzSeed struct {
+	data []byte
+	sig  []byte
+}
+
+// fuzzSeeds returns a few valid messages to seed the fuzzers with.
+func fuzzSeeds() []fuzzSeed {
+	build := []struct {
+		sig   []byte
+		build func(*MessageBuilder)
+	}{
+		{nil, func(mb *MessageBuilder) {}},
+		{[]byte{1, 6, 1}, func(mb *MessageBuilder) {
+			mb.WriteUint(1)
+			mb.WriteString("wl_compositor")
+			mb.WriteUint(4)
+		}},
+		{[]byte{5}, func(mb *MessageBuilder) {
+			mb.WriteNewID(NewID{Interface: "wl_seat", Version: 7, ID: 5})
+		}},
+		{[]byte{2, 0, 7}, func(mb *MessageBuilder) {
+			mb.WriteFixed(FixedFromFloat(-1.5))
+			mb.WriteInt(-3)
+			mb.WriteNullableString(nil)
+		}},
+		{[]byte{10, 9}, func(mb *MessageBuilder) {
+			WriteArrayOf(mb, []uint32{1, 2, 3})
+			mb.WriteStringArray([]string{"a", "bcd", ""})
+		}},
+	}
+
+	seeds := make([]fuzzSeed, 0, len(build))
+	for op, b := range build {
+		mb := NewMessage(testObject(3), uint16(op))
+		b.build(mb)
+
+		var buf bytes.Buffer
+		mb.encode(&buf)
+		seeds = append(seeds, fuzzSeed{data: buf.Bytes(), sig: b.sig})
+	}
+	return seeds
+}
+
+func checkMalformed(t *testing.T, err error) {
+	t.Helper()
+
+	var malformed MalformedMessageError
+	if !errors.As(err, &malformed) {
+		t.Fatalf("expected MalformedMessageError, got %T: %v", err, err)
+	}
+}
+
+func FuzzReadMessage(f *testing.F) {
+	var all []byte
+	for _, seed := range fuzzSeeds() {
+		f.Add(seed.data)
+		all = append(all, seed.data...)
+	}
+	f.Add(all)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := bytes.NewReader(data)
+		for {
+			start := len(data) - r.Len()
+			msg, err := ReadMessageFrom(r)
+			if err != nil {
+				if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+					checkMalformed(t, err)
+				}
+				return
+			}
+
+			raw := data[start : len(data)-r.Len()]
+			if int(msg.Size()) != len(raw) {
+				t.Fatalf("message size is %v, but %v bytes were read", msg.Size(), len(raw))
+			}
+			if !bytes.Equal(msg.Bytes(), raw) {
+				t.Fatalf("message is %x, but %x was read", msg.Bytes(), raw)
+			}
+
+			for msg.Remaining() > 0 {
+				msg.ReadUint()
+			}
+			if err := msg.Verify(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	for _, seed := range fuzzSeeds() {
+		f.Add(seed.data[HeaderSize:], seed.sig)
+	}
+
+	f.Fuzz(func(t *testing.T, body, sig []byte) {
+		body = body[:min(len(body), MaxMessageSize-HeaderSize)&^3]
+		data := rawMessage(3, 0, uint16(HeaderSize+len(body)), body...)
+
+		msg, err := ReadMessageFrom(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, b := range sig {
+			fuzzReaders[int(b)%len(fuzzReaders)](msg)
+		}
+		if err := msg.Err(); err != nil {
+			checkMalformed(t, err)
+			return
+		}
+
+		err = msg.Verify()
+		if (err != nil) != (msg.Remaining() > 0) {
+			t.Fatalf("Verify returned %v with %v bytes left", err, msg.Remaining())
+		}
+		if err != nil {
+			checkMalformed(t, err)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package wire
+
+import "errors"
+
+// Proxy holds the state that is common to every protocol object. It
+// is embedded by generated types to implement most of Object for
+// them and is not generally useful on its own.
+type Proxy struct {
+	// OnDelete is called when the object is removed from the tracking
+	// system.
+	OnDelete func()
+
+	state    State
+	id       uint32
+	version  uint32
+	cleanups []func() error
+}
+
+// NewProxy returns a Proxy for an object tracked by state.
+func NewProxy(state State) Proxy {
+	return Proxy{state: state}
+}
+
+// State returns the State that the object belongs to.
+func (p *Proxy) State() State {
+	return p.state
+}
+
+// ID returns the object's ID, or 0 if it hasn't been assigned one.
+func (p *Proxy) ID() uint32 {
+	return p.id
+}
+
+// SetID sets the object's ID. It should almost never be called
+// manually.
+func (p *Proxy) SetID(id uint32) {
+	p.id = id
+}
+
+// Version returns the version of the interface that the object was
+// bound or created with, or 0 if it hasn't been set. Objects created
+// by requests and events of another object have the same version as
+// that object.
+func (p *Proxy) Version() uint32 {
+	return p.version
+}
+
+// SetVersion sets the object's version. It is called by generated code
+// when the object is bound or created and should almost never be
+// called manually.
+func (p *Proxy) SetVersion(version uint32) {
+	p.version = version
+}
+
+// AddCleanup registers f to be called when the object is deleted or
+// when Cleanup is called, such as to close a file descriptor that the
+// object holds. Functions are called in the reverse of the order in
+// which they were added.
+func (p *Proxy) AddCleanup(f func() error) {
+	p.cleanups = append(p.cleanups, f)
+}
+
+// Cleanup calls the functions registered with AddCleanup and forgets
+// about them, so that each is only ever called once. It returns the
+// errors that they returned, joined with errors.Join.
+func (p *Proxy) Cleanup() error {
+	var errs []error
+	for i := len(p.cleanups) - 1; i >= 0; i-- {
+		errs = append(errs, p.cleanups[i]())
+	}
+	p.cleanups = nil
+	return errors.Join(errs...)
+}
+
+// Delete calls OnDelete, if it is set, and then Cleanup.
+func (p *Proxy) Delete() {
+	if p.OnDelete != nil {
+		p.OnDelete()
+	}
+	p.Cleanup()
+}
@@ -0,0 +1,193 @@
+package wire
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// defaultReadBufferSize is the default size of a Conn's read buffer.
+// It matches the size of the buffers used by libwayland, so a single
+// read from the socket can return as much data as the remote end is
+// likely to have sent at once.
+const defaultReadBufferSize = 4096
+
+// 128 bytes are enough for about 32 FDs which covers the protocol
+// with a margin.
+var oobSpace = unix.CmsgSpace(128)
+
+// connReader buffers data read from a Conn's socket so that many
+// small messages can be read with a single system call. File
+// descriptors received along with the data are added to the Conn's
+// queue as soon as they arrive, which keeps them in the same order
+// relative to each other as the messages that they belong to.
+//
+// The data of the message currently being read is kept in the buffer
+// until the next one is started so that, if reading it fails part way
+// through because of a timeout, it can be read again from the start.
+type connReader struct {
+	c    *Conn
+	buf  []byte
+	r, w int
+	mark int
+
+	// n and fds count the bytes returned and file descriptors
+	// received since the current message was started. They are used
+	// to track what was read for each individual message.
+	n   int
+	fds int
+
+	// retry is set when the current message is rewound so that the
+	// file descriptors that have already been received for it are
+	// still counted when it is read again.
+	retry bool
+
+	// fdErr is the error from handling the file descriptors that were
+	// received by the last fill. It is kept separately from the
+	// errors returned by Read, as those are ignored by io.ReadFull if
+	// enough data was read along with the file descriptors.
+	fdErr error
+}
+
+func (cr *connReader) Read(buf []byte) (int, error) {
+	if len(buf) == 0 {
+		return 0, nil
+	}
+
+	var err error
+	if cr.r == cr.w {
+		err = cr.fill()
+		if cr.r == cr.w {
+			return 0, err
+		}
+	}
+
+	n := copy(buf, cr.buf[cr.r:cr.w])
+	cr.r += n
+	cr.n += n
+	return n, err
+}
+
+// errWouldBlock is returned by fillNow when there is no data
+// available to be read.
+var errWouldBlock = errors.New("read would block")
+
+// fill reads more data from the socket into the buffer, discarding
+// everything before the start of the current message to make room
+// and growing the buffer if the current message doesn't fit.
+func (cr *connReader) fill() error {
+	return cr.fillFrom(func(buf, oob []byte) (int, int, error) {
+		n, oobn, _, _, err := cr.c.conn.ReadMsgUnix(buf, oob)
+		return n, oobn, err
+	})
+}
+
+// fillNow is like fill, but returns errWouldBlock instead of waiting
+// if no data is available.
+func (cr *connReader) fillNow() error {
+	sc, err := cr.c.conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	return cr.fillFrom(func(buf, oob []byte) (n, oobn int, err error) {
+		cerr := sc.Read(func(fd uintptr) bool {
+			n, oobn, _, _, err = unix.Recvmsg(int(fd), buf, oob, unix.MSG_DONTWAIT|unix.MSG_CMSG_CLOEXEC)
+			return true
+		})
+		if errors.Is(err, unix.EAGAIN) {
+			return 0, 0, errWouldBlock
+		}
+		if err != nil {
+			return 0, 0, os.NewSyscallError("recvmsg", err)
+		}
+		return n, oobn, cerr
+	})
+}
+
+// available returns whether a complete message, or at least a header
+// declaring an invalid size, is in the buffer, so that reading it
+// will not need to read from the socket.
+func (cr *connReader) available() bool {
+	if cr.w-cr.r < HeaderSize {
+		return false
+	}
+
+	_, _, size := DecodeHeader(cr.buf[cr.r:cr.w])
+	return (size < HeaderSize) || (cr.w-cr.r >= int(size))
+}
+
+func (cr *connReader) fillFrom(recv func(buf, oob []byte) (n, oobn int, err error)) error {
+	if cr.buf == nil {
+		cr.buf = make([]byte, defaultReadBufferSize)
+	}
+
+	if cr.mark > 0 {
+		cr.w = copy(cr.buf, cr.buf[cr.mark:cr.w])
+		cr.r -= cr.mark
+		cr.mark = 0
+	}
+	if cr.w == len(cr.buf) {
+		cr.buf = append(cr.buf, make([]byte, len(cr.buf))...)
+	}
+
+	oob := make([]byte, oobSpace)
+	n, oobn, err := recv(cr.buf[cr.w:], oob)
+	if (n == 0) && (oobn == 0) && (err == nil) {
+		// ReadMsgUnix doesn't report EOF like Read does.
+		err = io.EOF
+	}
+	cr.w += max(n, 0)
+
+	if oobn > 0 {
+		fds, fderr := cr.c.readFDs(oob[:oobn])
+		cr.fds += fds
+		if fderr != nil {
+			cr.fdErr = fderr
+		}
+	}
+	return err
+}
+
+// takeFDErr returns and clears the error from handling received file
+// descriptors, if there was one.
+func (cr *connReader) takeFDErr() error {
+	err := cr.fdErr
+	cr.fdErr = nil
+	return err
+}
+
+// begin starts a new message at the current position.
+func (cr *connReader) begin() {
+	cr.mark = cr.r
+	cr.n = 0
+	if !cr.retry {
+		cr.fds = 0
+	}
+	cr.retry = false
+}
+
+// rewind returns to the start of the current message so that it can
+// be read again. File descriptors that have been received stay in
+// the Conn's queue.
+func (cr *connReader) rewind() {
+	cr.r = cr.mark
+	cr.n = 0
+	cr.retry = true
+}
+
+// resize changes the size of the buffer to at least size bytes,
+// keeping any data that is currently buffered.
+func (cr *connReader) resize(size int) {
+	if size <= 0 {
+		size = defaultReadBufferSize
+	}
+	size = max(size, cr.w-cr.r)
+	buf := make([]byte, size)
+	cr.w = copy(buf, cr.buf[cr.r:cr.w])
+	cr.r = 0
+	cr.mark = 0
+	cr.buf = buf
+}
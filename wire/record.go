@@ -0,0 +1,122 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"deedles.dev/wl/internal/bin"
+)
+
+// Direction indicates which way a recorded message was traveling.
+type Direction uint32
+
+const (
+	// Received messages were read from the connection.
+	Received Direction = iota
+
+	// Sent messages were written to the connection.
+	Sent
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Received:
+		return "received"
+	case Sent:
+		return "sent"
+	}
+
+	return "<invalid Direction>"
+}
+
+// Record starts recording every message read from or written to c
+// into w, replacing any previous recording target. If w is nil,
+// recording is stopped. The recording can be read back with a
+// ReplayReader.
+//
+// Each message is recorded as a frame consisting of the direction,
+// the number of file descriptors transferred along with the message,
+// and then the raw message itself, header included. The direction
+// and file descriptor count are both 32-bit unsigned integers in
+// host byte order, the same as the rest of the wire protocol. The
+// file descriptors themselves are not recorded.
+//
+// Errors writing to w are ignored.
+func (c *Conn) Record(w io.Writer) {
+	c.recm.Lock()
+	defer c.recm.Unlock()
+
+	c.rec = w
+}
+
+func (c *Conn) record(dir Direction, msg []byte, fds int) {
+	c.recm.Lock()
+	defer c.recm.Unlock()
+
+	if c.rec == nil {
+		return
+	}
+
+	var frame bytes.Buffer
+	frame.Grow(8 + len(msg))
+	bin.Write(&frame, uint32(dir))
+	bin.Write(&frame, uint32(fds))
+	frame.Write(msg)
+	c.rec.Write(frame.Bytes())
+}
+
+// RecordedMessage is a message read back from a recording.
+type RecordedMessage struct {
+	// Direction is the direction in which the message was originally
+	// traveling.
+	Direction Direction
+
+	// FDs is the number of file descriptors that were transferred
+	// along with the message.
+	FDs int
+
+	// Msg holds the message's data. Because the file descriptors were
+	// not recorded, attempting to decode an fd argument will fail.
+	Msg *MessageBuffer
+}
+
+// ReplayReader reads back messages recorded with Conn.Record.
+type ReplayReader struct {
+	r io.Reader
+}
+
+// NewReplayReader returns a ReplayReader that reads a recording from
+// r.
+func NewReplayReader(r io.Reader) *ReplayReader {
+	return &ReplayReader{r: r}
+}
+
+// Next returns the next message in the recording. It returns io.EOF
+// once the recording has been completely consumed.
+func (rr *ReplayReader) Next() (*RecordedMessage, error) {
+	dir, err := bin.Read[uint32](rr.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("read direction: %w", err)
+	}
+
+	fds, err := bin.Read[uint32](rr.r)
+	if err != nil {
+		return nil, fmt.Errorf("read fd count: %w", err)
+	}
+
+	msg, err := readMessageFrom(rr.r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordedMessage{
+		Direction: Direction(dir),
+		FDs:       int(fds),
+		Msg:       msg,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	client, server := newConnPair(t)
+	r, _ := newPipe(t)
+
+	var rec bytes.Buffer
+	client.Record(&rec)
+
+	mb := NewMessage(testObject(3), 1)
+	mb.WriteInt(-7)
+	mb.WriteString("sent")
+	mb.WriteFile(r)
+	if err := mb.Build(client); err != nil {
+		t.Fatal(err)
+	}
+
+	mb = NewMessage(testObject(5), 2)
+	mb.WriteString("received")
+	if err := mb.Build(server); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadMessage(client); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing after recording stops is included.
+	client.Record(nil)
+	if err := NewMessage(testObject(3), 4).Build(client); err != nil {
+		t.Fatal(err)
+	}
+
+	replay := NewReplayReader(bytes.NewReader(rec.Bytes()))
+
+	sent, err := replay.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (sent.Direction != Sent) || (sent.FDs != 1) || (sent.Msg.Sender() != 3) || (sent.Msg.Op() != 1) {
+		t.Fatalf("first message is %v %v fds from %v op %v, want sent 1 fd from 3 op 1", sent.Direction, sent.FDs, sent.Msg.Sender(), sent.Msg.Op())
+	}
+	if v, s := sent.Msg.ReadInt(), sent.Msg.ReadString(); (v != -7) || (s != "sent") {
+		t.Fatalf("first message has arguments %v and %q, want -7 and %q", v, s, "sent")
+	}
+
+	received, err := replay.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (received.Direction != Received) || (received.FDs != 0) || (received.Msg.Sender() != 5) || (received.Msg.Op() != 2) {
+		t.Fatalf("second message is %v %v fds from %v op %v, want received 0 fds from 5 op 2", received.Direction, received.FDs, received.Msg.Sender(), received.Msg.Op())
+	}
+	if s := received.Msg.ReadString(); s != "received" {
+		t.Fatalf("second message has argument %q, want %q", s, "received")
+	}
+
+	if _, err := replay.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF at the end of the recording, got %v", err)
+	}
+
+	// A recording that ends partway through a frame is not a clean
+	// end.
+	replay = NewReplayReader(bytes.NewReader(rec.Bytes()[:rec.Len()-1]))
+	if _, err := replay.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replay.Next(); (err == nil) || (err == io.EOF) {
+		t.Fatalf("expected an error for a truncated frame, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package wire
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+)
+
+var (
+	fixedType = reflect.TypeFor[Fixed]()
+	newIDType = reflect.TypeFor[NewID]()
+	fileType  = reflect.TypeFor[*os.File]()
+)
+
+// ReadStruct reads arguments into the fields of the struct pointed to
+// by v, one argument per field, in the order in which the fields are
+// declared. Fields of type Fixed, NewID, *os.File, and []byte are
+// read with the corresponding methods of r. Slices of int32 or uint32
+// kinds, such as []Fixed, are read as arrays of 32-bit words, as with
+// ReadArrayOf. Other fields are read according to their kind, with
+// int32, uint32, and string kinds being read as ints, uints, and
+// strings, respectively.
+//
+// If v is not a pointer to a struct, or if the struct has any
+// unexported fields or fields of any other types, an error is
+// returned without reading anything.
+func (r *MessageBuffer) ReadStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() != reflect.Pointer) || (rv.Elem().Kind() != reflect.Struct) {
+		return fmt.Errorf("%T is not a pointer to a struct", v)
+	}
+	rv = rv.Elem()
+	t := rv.Type()
+
+	readers := make([]func(reflect.Value), 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			return fmt.Errorf("field %v of %v is not exported", field.Name, t)
+		}
+
+		read := r.fieldReader(field.Type)
+		if read == nil {
+			return fmt.Errorf("field %v of %v has unsupported type %v", field.Name, t, field.Type)
+		}
+		readers = append(readers, read)
+	}
+
+	for i, read := range readers {
+		read(rv.Field(i))
+	}
+	return r.Err()
+}
+
+func (r *MessageBuffer) fieldReader(t reflect.Type) func(reflect.Value) {
+	switch {
+	case t == fixedType:
+		return func(v reflect.Value) { v.Set(reflect.ValueOf(r.ReadFixed())) }
+	case t == newIDType:
+		return func(v reflect.Value) { v.Set(reflect.ValueOf(r.ReadNewID())) }
+	case t == fileType:
+		return func(v reflect.Value) { v.Set(reflect.ValueOf(r.ReadFile())) }
+	case (t.Kind() == reflect.Slice) && (t.Elem().Kind() == reflect.Uint8):
+		return func(v reflect.Value) { v.SetBytes(r.ReadArray()) }
+	case (t.Kind() == reflect.Slice) && (t.Elem().Kind() == reflect.Int32):
+		return func(v reflect.Value) {
+			words := ReadArrayOf[int32](r)
+			s := reflect.MakeSlice(t, len(words), len(words))
+			for i, w := range words {
+				s.Index(i).SetInt(int64(w))
+			}
+			v.Set(s)
+		}
+	case (t.Kind() == reflect.Slice) && (t.Elem().Kind() == reflect.Uint32):
+		return func(v reflect.Value) {
+			words := ReadArrayOf[uint32](r)
+			s := reflect.MakeSlice(t, len(words), len(words))
+			for i, w := range words {
+				s.Index(i).SetUint(uint64(w))
+			}
+			v.Set(s)
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Int32:
+		return func(v reflect.Value) { v.SetInt(int64(r.ReadInt())) }
+	case reflect.Uint32:
+		return func(v reflect.Value) { v.SetUint(uint64(r.ReadUint())) }
+	case reflect.String:
+		return func(v reflect.Value) { v.SetString(r.ReadString()) }
+	}
+
+	return nil
+}
+
+// WriteStruct writes the fields of the struct v, or of the struct
+// pointed to by v, as arguments, one argument per field, in the order
+// in which the fields are declared. It is the inverse of ReadStruct
+// and supports the same field types. File descriptors from *os.File
+// fields are attached to the message in field order.
+//
+// If v is not a struct or a pointer to one, or if the struct has any
+// unexported fields or fields of unsupported types, an error is
+// returned without writing anything.
+func (mb *MessageBuilder) WriteStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%T is not a struct or a pointer to one", v)
+	}
+	t := rv.Type()
+
+	writers := make([]func(reflect.Value), 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			return fmt.Errorf("field %v of %v is not exported", field.Name, t)
+		}
+
+		write := mb.fieldWriter(field.Type)
+		if write == nil {
+			return fmt.Errorf("field %v of %v has unsupported type %v", field.Name, t, field.Type)
+		}
+		writers = append(writers, write)
+	}
+
+	for i, write := range writers {
+		write(rv.Field(i))
+	}
+	return mb.err
+}
+
+func (mb *MessageBuilder) fieldWriter(t reflect.Type) func(reflect.Value) {
+	switch {
+	case t == fixedType:
+		return func(v reflect.Value) { mb.WriteFixed(v.Interface().(Fixed)) }
+	case t == newIDType:
+		return func(v reflect.Value) { mb.WriteNewID(v.Interface().(NewID)) }
+	case t == fileType:
+		return func(v reflect.Value) { mb.WriteFile(v.Interface().(*os.File)) }
+	case (t.Kind() == reflect.Slice) && (t.Elem().Kind() == reflect.Uint8):
+		return func(v reflect.Value) { mb.WriteArray(v.Bytes()) }
+	case (t.Kind() == reflect.Slice) && (t.Elem().Kind() == reflect.Int32):
+		return func(v reflect.Value) {
+			words := make([]int32, v.Len())
+			for i := range words {
+				words[i] = int32(v.Index(i).Int())
+			}
+			WriteArrayOf(mb, words)
+		}
+	case (t.Kind() == reflect.Slice) && (t.Elem().Kind() == reflect.Uint32):
+		return func(v reflect.Value) {
+			words := make([]uint32, v.Len())
+			for i := range words {
+				words[i] = uint32(v.Index(i).Uint())
+			}
+			WriteArrayOf(mb, words)
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Int32:
+		return func(v reflect.Value) { mb.WriteInt(int32(v.Int())) }
+	case reflect.Uint32:
+		return func(v reflect.Value) { mb.WriteUint(uint32(v.Uint())) }
+	case reflect.String:
+		return func(v reflect.Value) { mb.WriteString(v.String()) }
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package wire
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+type testEnum uint32
+
+type mixedStruct struct {
+	Count  int32
+	Serial uint32
+	X      Fixed
+	Name   string
+	ID     NewID
+	Data   []byte
+	Coords []Fixed
+	Flags  testEnum
+	File   *os.File
+}
+
+func TestReadStruct(t *testing.T) {
+	client, server := newConnPair(t)
+	r, w := newPipe(t)
+
+	want := mixedStruct{
+		Count:  -3,
+		Serial: 17,
+		X:      FixedFromFloat(1.5),
+		Name:   "mixed",
+		ID:     NewID{Interface: "wl_output", Version: 2, ID: 8},
+		Data:   []byte{9, 8, 7},
+		Coords: []Fixed{FixedFromFloat(-1), FixedFromFloat(0.25)},
+		Flags:  5,
+		File:   r,
+	}
+
+	mb := NewMessage(testObject(3), 0)
+	if err := mb.WriteStruct(&want); err != nil {
+		t.Fatal(err)
+	}
+	if err := mb.Build(client); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := ReadMessage(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got mixedStruct
+	if err := msg.ReadStruct(&got); err != nil {
+		t.Fatal(err)
+	}
+	if err := msg.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	defer got.File.Close()
+
+	if inode(t, int(got.File.Fd())) != inode(t, int(w.Fd())) {
+		t.Fatal("received a different file")
+	}
+	got.File, want.File = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("read %+v, want %+v", got, want)
+	}
+}
+
+func TestReadStructUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"NotPointer", struct{ A uint32 }{}},
+		{"NotStruct", new(uint32)},
+		{"Unexported", &struct {
+			A uint32
+			b uint32
+		}{}},
+		{"Int64", &struct {
+			A uint32
+			B int64
+		}{}},
+		{"Map", &struct{ M map[string]string }{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg, err := ReadMessageFrom(bytes.NewReader(rawMessage(3, 0, HeaderSize+8, 1, 0, 0, 0, 2, 0, 0, 0)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := msg.ReadStruct(test.v); err == nil {
+				t.Fatal("expected an error")
+			}
+
+			// Nothing was read.
+			if v := msg.ReadUint(); v != 1 {
+				t.Fatalf("first argument is %v after failed ReadStruct, want 1", v)
+			}
+		})
+	}
+}
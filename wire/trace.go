@@ -0,0 +1,59 @@
+package wire
+
+import (
+	"io"
+
+	"deedles.dev/wl/internal/debug"
+)
+
+// SetLogger sets w as the destination of c's protocol trace, replacing
+// any previous one. If w is nil, tracing is turned off. While tracing
+// is on, every message that is sent or dispatched by the State using
+// c is written to w as a line in the same format as libwayland's
+// WAYLAND_DEBUG output, including the values of the message's
+// arguments.
+//
+// By default, the Client and Server types trace to stderr if the
+// WAYLAND_DEBUG environment variable turns tracing on for their end of
+// the connection, which is the case if it is 1 or contains "client" or
+// "server", respectively. Calling SetLogger before creating either
+// overrides that.
+//
+// Errors writing to w are ignored.
+func (c *Conn) SetLogger(w io.Writer) {
+	c.logm.Lock()
+	defer c.logm.Unlock()
+
+	c.logger = w
+	c.tracing.Store(w != nil)
+}
+
+// Logger returns the destination of c's protocol trace, or nil if
+// tracing is turned off.
+func (c *Conn) Logger() io.Writer {
+	c.logm.Lock()
+	defer c.logm.Unlock()
+
+	return c.logger
+}
+
+// Tracing reports whether c has a logger. Checking it first avoids
+// having to format trace output that would not be written anywhere.
+func (c *Conn) Tracing() bool {
+	return c.tracing.Load()
+}
+
+// Tracef writes a line to c's logger, if it has one, prefixed with a
+// timestamp. It is intended for use by State implementations.
+func (c *Conn) Tracef(format string, args ...any) {
+	if !c.Tracing() {
+		return
+	}
+
+	c.logm.Lock()
+	defer c.logm.Unlock()
+
+	if c.logger != nil {
+		debug.Fprintf(c.logger, format, args...)
+	}
+}
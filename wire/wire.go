@@ -3,13 +3,64 @@
 package wire
 
 import (
-	"errors"
-	"io"
-	"net"
+	"fmt"
 
-	"golang.org/x/sys/unix"
+	"deedles.dev/wl/internal/bin"
 )
 
+// HeaderSize is the size, in bytes, of the header at the start of
+// every message.
+const HeaderSize = 8
+
+// MaxMessageSize is the largest total size, in bytes, that a message,
+// including its header, can have. The size field of the header is
+// only 16 bits. Note that some implementations, including older
+// versions of libwayland, only accept messages of up to 4096 bytes.
+const MaxMessageSize = 1<<16 - 1
+
+// EncodeHeader writes a message header into the first HeaderSize
+// bytes of dst. The size is the total size of the message, including
+// the header.
+func EncodeHeader(dst []byte, sender uint32, op uint16, size uint16) {
+	_ = dst[HeaderSize-1]
+	*(*[4]byte)(dst[0:4]) = bin.Bytes(sender)
+	*(*[4]byte)(dst[4:8]) = bin.Bytes(sizeOp(size, op))
+}
+
+// DecodeHeader reads a message header from the first HeaderSize
+// bytes of src. It is the inverse of EncodeHeader.
+func DecodeHeader(src []byte) (sender uint32, op uint16, size uint16) {
+	_ = src[HeaderSize-1]
+	sender = bin.Value[uint32]([4]byte(src[0:4]))
+	size, op = splitSizeOp(bin.Value[uint32]([4]byte(src[4:8])))
+	return sender, op, size
+}
+
+// sizeOp combines a message size and opcode into the second word of
+// a message header. The size occupies the upper 16 bits.
+func sizeOp(size, op uint16) uint32 {
+	return (uint32(size) << 16) | uint32(op)
+}
+
+// splitSizeOp splits the second word of a message header into the
+// message size and opcode. It is the inverse of sizeOp.
+func splitSizeOp(so uint32) (size, op uint16) {
+	return uint16(so >> 16), uint16(so & 0xFFFF)
+}
+
+// checkSize checks that size is a valid total size for a message,
+// meaning that it is large enough to hold the header and small enough
+// to fit into the header's size field.
+func checkSize(size int) error {
+	if size < HeaderSize {
+		return fmt.Errorf("message size %v is smaller than the header", size)
+	}
+	if size > MaxMessageSize {
+		return fmt.Errorf("%w: %v bytes is more than the maximum of %v", ErrMessageTooLarge, size, MaxMessageSize)
+	}
+	return nil
+}
+
 func padding(length uint32) uint32 {
 	pad := 4 - (length % (32 / 8))
 	if pad == 4 {
@@ -18,23 +69,15 @@ func padding(length uint32) uint32 {
 	return pad
 }
 
-// unixTee reads from c, but also reads out-of-band data
-// simultaneously, writing it into oob.
-type unixTee struct {
-	c   *net.UnixConn
-	oob io.Writer
-}
-
-// 128 bytes are enough for about 32 FDs which covers the protocol
-// with a margin.
-var oobSpace = unix.CmsgSpace(128)
-
-func (t unixTee) Read(buf []byte) (int, error) {
-	oob := make([]byte, oobSpace)
-	n, oobn, _, _, err := t.c.ReadMsgUnix(buf, oob)
-	_, ooberr := t.oob.Write(oob[:oobn])
-	return n, errors.Join(err, ooberr)
-}
+// The ranges of object IDs that each end of a connection allocates
+// IDs for its new objects from. ID 0 is the null object and is never
+// allocated.
+const (
+	ClientIDMin = 1
+	ClientIDMax = 0xFEFFFFFF
+	ServerIDMin = 0xFF000000
+	ServerIDMax = 0xFFFFFFFF
+)
 
 // NewID represents the Wayland new_id type when it doesn't have a
 // pre-defined interface.
@@ -44,6 +87,32 @@ type NewID struct {
 	ID        uint32
 }
 
+// Check returns a BindError if id can not be used to bind a global
+// that implements iface at up to the given version, either because
+// it requests a different interface or because it requests a version
+// outside of the range from 1 to version.
+func (id NewID) Check(iface string, version uint32) error {
+	if (id.Interface != iface) || (id.Version == 0) || (id.Version > version) {
+		return BindError{Interface: iface, Version: version, ID: id}
+	}
+	return nil
+}
+
+// InterfaceInfo describes an interface. Generated protocol packages
+// list the interfaces that they define as InterfaceInfo values.
+type InterfaceInfo struct {
+	Name    string
+	Version uint32
+}
+
+// NegotiateVersion returns the version of an interface to use when
+// one end of the connection supports up to local and the other end
+// supports up to remote. A result of 0 means that there is no version
+// that both ends support.
+func NegotiateVersion(local, remote uint32) uint32 {
+	return min(local, remote)
+}
+
 // Object represents a Wayland protocol object.
 type Object interface {
 	// ID returns the ID of the object. It returns 0 before the Object
@@ -0,0 +1,148 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"deedles.dev/wl/internal/bin"
+)
+
+func TestHeaderRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	buf := make([]byte, HeaderSize)
+	for range 1000 {
+		sender := rng.Uint32()
+		op := uint16(rng.UintN(1 << 16))
+		size := uint16(rng.UintN(1 << 16))
+
+		EncodeHeader(buf, sender, op, size)
+		gs, gop, gsize := DecodeHeader(buf)
+		if (gs != sender) || (gop != op) || (gsize != size) {
+			t.Fatalf("encoded (%v, %v, %v), decoded (%v, %v, %v)", sender, op, size, gs, gop, gsize)
+		}
+	}
+}
+
+func TestHeaderLayout(t *testing.T) {
+	buf := make([]byte, HeaderSize)
+	EncodeHeader(buf, 0x11223344, 0x5566, 0x7788)
+
+	// The second word holds the size in its upper 16 bits and the
+	// opcode in its lower ones.
+	if got := bin.Value[uint32]([4]byte(buf[0:4])); got != 0x11223344 {
+		t.Errorf("sender word is %#x", got)
+	}
+	if got := bin.Value[uint32]([4]byte(buf[4:8])); got != 0x77885566 {
+		t.Errorf("size and opcode word is %#x", got)
+	}
+}
+
+// testObject is a minimal Object for building messages from.
+type testObject uint32
+
+func (obj testObject) ID() uint32                    { return uint32(obj) }
+func (obj testObject) SetID(uint32)                  {}
+func (obj testObject) Dispatch(*MessageBuffer) error { return nil }
+func (obj testObject) Delete()                       {}
+
+// newConnPair returns two Conns that are connected to each other.
+func newConnPair(t testing.TB) (*Conn, *Conn) {
+	t.Helper()
+
+	c, s, err := SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, server := NewConn(c), NewConn(s)
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+// rawMessage returns a message with the given header and body, which
+// do not need to be consistent with each other.
+func rawMessage(sender uint32, op, size uint16, body ...byte) []byte {
+	data := make([]byte, HeaderSize, HeaderSize+len(body))
+	EncodeHeader(data, sender, op, size)
+	return append(data, body...)
+}
+
+// decodeBuilt encodes mb and decodes the result without going through
+// a socket. File descriptors are not transferred.
+func decodeBuilt(t testing.TB, mb *MessageBuilder) *MessageBuffer {
+	t.Helper()
+
+	if mb.err != nil {
+		t.Fatal(mb.err)
+	}
+
+	var buf bytes.Buffer
+	mb.encode(&buf)
+	msg, err := ReadMessageFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestNegotiateVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		local, remote uint32
+		want          uint32
+	}{
+		{"Equal", 3, 3, 3},
+		{"RemoteAboveLocal", 4, 9, 4},
+		{"LocalAboveRemote", 6, 2, 2},
+		{"ZeroLocal", 0, 5, 0},
+		{"ZeroRemote", 5, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if v := NegotiateVersion(test.local, test.remote); v != test.want {
+				t.Fatalf("NegotiateVersion(%v, %v) = %v, want %v", test.local, test.remote, v, test.want)
+			}
+		})
+	}
+}
+
+func TestNewIDCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		id   NewID
+		ok   bool
+	}{
+		{"Zero", NewID{Interface: "wl_output", Version: 0, ID: 5}, false},
+		{"AboveMax", NewID{Interface: "wl_output", Version: 5, ID: 5}, false},
+		{"MidRange", NewID{Interface: "wl_output", Version: 2, ID: 5}, true},
+		{"Max", NewID{Interface: "wl_output", Version: 4, ID: 5}, true},
+		{"WrongInterface", NewID{Interface: "wl_seat", Version: 2, ID: 5}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.id.Check("wl_output", 4)
+			if test.ok {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			var berr BindError
+			if !errors.As(err, &berr) {
+				t.Fatalf("expected BindError, got %v", err)
+			}
+			if (berr.Interface != "wl_output") || (berr.Version != 4) || (berr.ID != test.id) {
+				t.Fatalf("unexpected error: %#v", berr)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// maxFDs is the maximum number of file descriptors sent in a single
+// write. The kernel allows more than this, but libwayland only
+// reserves room for this many when receiving, so anything beyond it
+// would be lost on the remote end. A single message with more than
+// this many file descriptors can not be sent at all.
+const maxFDs = 28
+
+// OutBufferSize is the maximum amount of message data, in bytes, that
+// is held in a Conn's output buffer before it is flushed
+// automatically. It is the same as the size of libwayland's
+// connection buffers.
+const OutBufferSize = 4096
+
+// MessageWriter batches outgoing messages so that they can be sent
+// to a Conn with as few system calls as possible.
+//
+// Messages are sent in the order in which they were written. The
+// file descriptors attached to a message are always sent along with
+// the data of that message, but if the messages pending in a
+// MessageWriter have too many file descriptors between them to be
+// sent at once, Flush will split the data into several writes at
+// message boundaries.
+type MessageWriter struct {
+	conn *Conn
+	data bytes.Buffer
+	fds  []int
+	msgs []pendingMessage
+}
+
+type pendingMessage struct {
+	start, end int
+	fds        int
+}
+
+// NewMessageWriter returns a MessageWriter that sends messages to c.
+func NewMessageWriter(c *Conn) *MessageWriter {
+	return &MessageWriter{conn: c}
+}
+
+// Write adds mb to the pending messages. Nothing is sent until Flush
+// is called. The MessageBuilder should not be used again after this
+// method is called.
+//
+// If mb can not be sent, either because it has more file descriptors
+// attached than can be sent in a single write or because it is larger
+// than MaxMessageSize, an error is returned and mb is not added.
+func (w *MessageWriter) Write(mb *MessageBuilder) error {
+	if err := mb.check(); err != nil {
+		return err
+	}
+
+	start := w.data.Len()
+	mb.encode(&w.data)
+	w.fds = append(w.fds, mb.fds...)
+	w.msgs = append(w.msgs, pendingMessage{start: start, end: w.data.Len(), fds: len(mb.fds)})
+
+	// The file descriptors are owned by the MessageWriter now.
+	mb.fds = nil
+	runtime.SetFinalizer(mb, nil)
+
+	return nil
+}
+
+// Buffered returns the number of bytes of message data waiting to be
+// sent.
+func (w *MessageWriter) Buffered() int {
+	return w.data.Len()
+}
+
+// Flush sends all pending messages. The pending messages are
+// discarded even if an error occurs.
+func (w *MessageWriter) Flush() error {
+	defer w.reset()
+
+	data, fds := w.data.Bytes(), w.fds
+	var first, n int
+	for i, msg := range w.msgs {
+		if (n+msg.fds > maxFDs) && (i > first) {
+			err := w.send(data, fds[:n], w.msgs[first:i])
+			if err != nil {
+				return err
+			}
+			first, fds, n = i, fds[n:], 0
+		}
+
+		n += msg.fds
+	}
+	if first == len(w.msgs) {
+		return nil
+	}
+
+	return w.send(data, fds[:n], w.msgs[first:])
+}
+
+// send writes the messages in msgs as a single write.
+func (w *MessageWriter) send(data []byte, fds []int, msgs []pendingMessage) error {
+	err := w.conn.writeMsg(data[msgs[0].start:msgs[len(msgs)-1].end], fds)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range msgs {
+		w.conn.record(Sent, data[msg.start:msg.end], msg.fds)
+	}
+	return nil
+}
+
+func (w *MessageWriter) reset() error {
+	errs := make([]error, 0, len(w.fds))
+	for _, fd := range w.fds {
+		errs = append(errs, unix.Close(fd))
+	}
+
+	w.data.Reset()
+	w.fds = w.fds[:0]
+	w.msgs = w.msgs[:0]
+	return errors.Join(errs...)
+}
@@ -0,0 +1,155 @@
+package wire
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// recvRaw does a single read from c and returns the data and the file
+// descriptors that it received. The kernel never joins the data of
+// separate writes that carry file descriptors, so each call returns
+// exactly one of the writes made by the other end.
+func recvRaw(t *testing.T, c *net.UnixConn) ([]byte, []int) {
+	t.Helper()
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, unix.CmsgSpace(64*4))
+	n, oobn, _, _, err := c.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmsgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fds []int
+	for _, cmsg := range cmsgs {
+		rights, err := unix.ParseUnixRights(&cmsg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fds = append(fds, rights...)
+	}
+	t.Cleanup(func() {
+		for _, fd := range fds {
+			unix.Close(fd)
+		}
+	})
+	return buf[:n], fds
+}
+
+func inode(t *testing.T, fd int) uint64 {
+	t.Helper()
+
+	var stat unix.Stat_t
+	if err := unix.Fstat(fd, &stat); err != nil {
+		t.Fatal(err)
+	}
+	return stat.Ino
+}
+
+func TestMessageWriterSplitsFDs(t *testing.T) {
+	c, s, err := SocketPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewConn(c)
+	t.Cleanup(func() {
+		client.Close()
+		s.Close()
+	})
+
+	// Each message carries copies of the read end of its own pipe, so
+	// that the receiver can tell which message each one came with.
+	counts := []int{10, 10, 10, 0, 8, 10, 1}
+	inodes := make([]uint64, len(counts))
+	w := NewMessageWriter(client)
+	for op, n := range counts {
+		r, _ := newPipe(t)
+		inodes[op] = inode(t, int(r.Fd()))
+
+		mb := NewMessage(testObject(3), uint16(op))
+		for range n {
+			mb.WriteFD(int(r.Fd()))
+		}
+		if err := w.Write(mb); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 10 + 10 + 10 is over the limit, so the first write stops after
+	// two messages. The next four have exactly maxFDs between them, and
+	// the last one needs a write of its own.
+	writes := [][]int{{0, 1}, {2, 3, 4, 5}, {6}}
+
+	for _, ops := range writes {
+		data, fds := recvRaw(t, s)
+		if len(data) != HeaderSize*len(ops) {
+			t.Fatalf("write has %v bytes, want %v messages", len(data), len(ops))
+		}
+		if len(fds) > maxFDs {
+			t.Fatalf("write has %v file descriptors", len(fds))
+		}
+
+		var fdi int
+		for i, op := range ops {
+			_, gop, _ := DecodeHeader(data[i*HeaderSize:])
+			if int(gop) != op {
+				t.Fatalf("got message %v, want %v", gop, op)
+			}
+			for range counts[op] {
+				if fdi >= len(fds) {
+					t.Fatalf("message %v is missing file descriptors", op)
+				}
+				if inode(t, fds[fdi]) != inodes[op] {
+					t.Fatalf("file descriptor %v of the write does not belong to message %v", fdi, op)
+				}
+				fdi++
+			}
+		}
+		if fdi != len(fds) {
+			t.Fatalf("write has %v file descriptors, messages have %v", len(fds), fdi)
+		}
+	}
+}
+
+func TestMessageFDLimit(t *testing.T) {
+	client, _ := newConnPair(t)
+	r, _ := newPipe(t)
+
+	build := func(n int) *MessageBuilder {
+		mb := NewMessage(testObject(3), 0)
+		for range n {
+			mb.WriteFD(int(r.Fd()))
+		}
+		return mb
+	}
+
+	if err := build(maxFDs + 1).Build(client); !errors.Is(err, ErrMessageFDs) {
+		t.Fatalf("expected ErrMessageFDs from Build, got %v", err)
+	}
+	w := NewMessageWriter(client)
+	if err := w.Write(build(maxFDs + 1)); !errors.Is(err, ErrMessageFDs) {
+		t.Fatalf("expected ErrMessageFDs from Write, got %v", err)
+	}
+	if w.Buffered() != 0 {
+		t.Fatalf("rejected message was buffered")
+	}
+
+	if err := build(maxFDs).Build(client); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(build(maxFDs)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,157 @@
+package wlshm
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	wl "deedles.dev/wl/client"
+)
+
+// Image is a draw.Image whose pixels are stored in the byte order of
+// WL_SHM_FORMAT_ARGB8888 and WL_SHM_FORMAT_XRGB8888, which is blue,
+// green, red, and then alpha or padding, the reverse of image.RGBA's.
+// As with image.RGBA, colors are alpha-premultiplied, which is what
+// the compositor expects.
+type Image struct {
+	// Pix holds the pixels, starting with the top-left one.
+	Pix []byte
+
+	// Stride is the number of bytes between vertically adjacent
+	// pixels.
+	Stride int
+
+	// Rect is the image's bounds.
+	Rect image.Rectangle
+
+	// NoAlpha indicates that the fourth byte of each pixel is padding,
+	// as in WL_SHM_FORMAT_XRGB8888. Every pixel reads as fully opaque
+	// and any alpha value that is written is discarded.
+	NoAlpha bool
+}
+
+func (img *Image) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (img *Image) Bounds() image.Rectangle {
+	return img.Rect
+}
+
+// PixOffset returns the index of the first byte of the pixel at (x,
+// y) in Pix.
+func (img *Image) PixOffset(x, y int) int {
+	return (y-img.Rect.Min.Y)*img.Stride + (x-img.Rect.Min.X)*4
+}
+
+func (img *Image) At(x, y int) color.Color {
+	return img.RGBAAt(x, y)
+}
+
+// RGBAAt returns the color of the pixel at (x, y) without allocating.
+func (img *Image) RGBAAt(x, y int) color.RGBA {
+	if !(image.Point{x, y}.In(img.Rect)) {
+		return color.RGBA{}
+	}
+
+	i := img.PixOffset(x, y)
+	s := img.Pix[i : i+4 : i+4]
+	c := color.RGBA{R: s[2], G: s[1], B: s[0], A: s[3]}
+	if img.NoAlpha {
+		c.A = 0xFF
+	}
+	return c
+}
+
+func (img *Image) Set(x, y int, c color.Color) {
+	img.SetRGBA(x, y, color.RGBAModel.Convert(c).(color.RGBA))
+}
+
+// SetRGBA sets the color of the pixel at (x, y) without allocating.
+func (img *Image) SetRGBA(x, y int, c color.RGBA) {
+	if !(image.Point{x, y}.In(img.Rect)) {
+		return
+	}
+
+	if img.NoAlpha {
+		c.A = 0xFF
+	}
+
+	i := img.PixOffset(x, y)
+	s := img.Pix[i : i+4 : i+4]
+	s[0], s[1], s[2], s[3] = c.B, c.G, c.R, c.A
+}
+
+// SubImage returns an image representing the part of img visible
+// through r. The returned image shares pixels with img.
+func (img *Image) SubImage(r image.Rectangle) image.Image {
+	r = r.Intersect(img.Rect)
+	if r.Empty() {
+		return &Image{NoAlpha: img.NoAlpha}
+	}
+
+	i := img.PixOffset(r.Min.X, r.Min.Y)
+	return &Image{
+		Pix:     img.Pix[i:],
+		Stride:  img.Stride,
+		Rect:    r,
+		NoAlpha: img.NoAlpha,
+	}
+}
+
+// Opaque reports whether every pixel of img is fully opaque.
+func (img *Image) Opaque() bool {
+	if img.NoAlpha || img.Rect.Empty() {
+		return true
+	}
+
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		i := img.PixOffset(img.Rect.Min.X, y)
+		for x := img.Rect.Min.X; x < img.Rect.Max.X; x, i = x+1, i+4 {
+			if img.Pix[i+3] != 0xFF {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Image returns a draw.Image that reads and writes the buffer's pixels
+// directly, without copying them, so that the standard library's
+// image packages, and anything built on them, can render straight
+// into the buffer. The image respects the buffer's stride.
+//
+// Buffers in WL_SHM_FORMAT_ARGB8888 or WL_SHM_FORMAT_XRGB8888 are
+// returned as an *Image. Buffers in WL_SHM_FORMAT_ABGR8888 or
+// WL_SHM_FORMAT_XBGR8888 have the same memory layout as image.RGBA
+// and are returned as an *image.RGBA, which allows image/draw to use
+// its faster code paths. For the formats without alpha, the padding
+// byte of an *image.RGBA is written as is, which the compositor
+// ignores. Other formats are not supported.
+//
+// As with Data, the image is only valid until the buffer's pool is
+// resized or destroyed.
+func (b *Buffer) Image() (draw.Image, error) {
+	rect := image.Rect(0, 0, b.width, b.height)
+
+	switch b.format {
+	case wl.ShmFormatArgb8888, wl.ShmFormatXrgb8888:
+		return &Image{
+			Pix:     b.Data(),
+			Stride:  b.stride,
+			Rect:    rect,
+			NoAlpha: b.format == wl.ShmFormatXrgb8888,
+		}, nil
+
+	case wl.ShmFormatAbgr8888, wl.ShmFormatXbgr8888:
+		return &image.RGBA{
+			Pix:    b.Data(),
+			Stride: b.stride,
+			Rect:   rect,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("no image support for format %v", b.format)
+	}
+}
@@ -0,0 +1,276 @@
+// Package wlshm provides shared memory buffers for clients that
+// render in software. It takes care of creating and mapping the
+// memory and of sharing it with the compositor using wl_shm, so that
+// a client only needs to fill in the pixels.
+package wlshm
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	wl "deedles.dev/wl/client"
+	"deedles.dev/wl/shm"
+	"golang.org/x/sys/unix"
+)
+
+// BytesPerPixel returns the number of bytes used by each pixel in the
+// given format. It only supports formats with a single plane in which
+// every pixel takes up a whole number of bytes. It returns 0 for any
+// other format.
+func BytesPerPixel(format wl.ShmFormat) int {
+	switch format {
+	case wl.ShmFormatC8, wl.ShmFormatRgb332, wl.ShmFormatBgr233, wl.ShmFormatR8:
+		return 1
+
+	case wl.ShmFormatXrgb4444, wl.ShmFormatXbgr4444, wl.ShmFormatRgbx4444, wl.ShmFormatBgrx4444,
+		wl.ShmFormatArgb4444, wl.ShmFormatAbgr4444, wl.ShmFormatRgba4444, wl.ShmFormatBgra4444,
+		wl.ShmFormatXrgb1555, wl.ShmFormatXbgr1555, wl.ShmFormatRgbx5551, wl.ShmFormatBgrx5551,
+		wl.ShmFormatArgb1555, wl.ShmFormatAbgr1555, wl.ShmFormatRgba5551, wl.ShmFormatBgra5551,
+		wl.ShmFormatRgb565, wl.ShmFormatBgr565, wl.ShmFormatR16, wl.ShmFormatRg88, wl.ShmFormatGr88:
+		return 2
+
+	case wl.ShmFormatRgb888, wl.ShmFormatBgr888:
+		return 3
+
+	case wl.ShmFormatArgb8888, wl.ShmFormatXrgb8888, wl.ShmFormatXbgr8888, wl.ShmFormatRgbx8888,
+		wl.ShmFormatBgrx8888, wl.ShmFormatAbgr8888, wl.ShmFormatRgba8888, wl.ShmFormatBgra8888,
+		wl.ShmFormatXrgb2101010, wl.ShmFormatXbgr2101010, wl.ShmFormatRgbx1010102, wl.ShmFormatBgrx1010102,
+		wl.ShmFormatArgb2101010, wl.ShmFormatAbgr2101010, wl.ShmFormatRgba1010102, wl.ShmFormatBgra1010102,
+		wl.ShmFormatRg1616, wl.ShmFormatGr1616:
+		return 4
+
+	case wl.ShmFormatXrgb16161616f, wl.ShmFormatXbgr16161616f, wl.ShmFormatArgb16161616f, wl.ShmFormatAbgr16161616f:
+		return 8
+
+	default:
+		return 0
+	}
+}
+
+// Pool is a region of shared memory that has been shared with the
+// compositor as a wl_shm_pool. Buffers can be created from any part of
+// it.
+type Pool struct {
+	pool *wl.ShmPool
+	file *os.File
+	mmap shm.Mmap
+}
+
+// NewPool creates a new pool of the given size, in bytes, using s.
+// The memory is created with memfd_create, where possible, and sealed
+// so that it can not be shrunk, which protects the compositor from
+// being sent SIGBUS by the client truncating it while it is in use.
+func NewPool(s *wl.Shm, size int) (*Pool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid pool size: %v", size)
+	}
+
+	file, err := shm.CreateAnonymousFile(size)
+	if err != nil {
+		return nil, err
+	}
+
+	err = seal(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	mmap, err := shm.MapShared(file, size, unix.PROT_READ|unix.PROT_WRITE)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("mmap pool: %w", err)
+	}
+
+	return &Pool{
+		pool: s.CreatePool(file, int32(size)),
+		file: file,
+		mmap: mmap,
+	}, nil
+}
+
+// seal prevents file from being shrunk. Files that don't support
+// sealing, such as the ones that shm.CreateAnonymousFile falls back
+// to when memfd_create is unavailable, are left as they are.
+func seal(file *os.File) error {
+	_, err := unix.FcntlInt(file.Fd(), unix.F_ADD_SEALS, unix.F_SEAL_SHRINK)
+	if errors.Is(err, unix.EINVAL) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("seal pool: %w", err)
+	}
+	return nil
+}
+
+// ShmPool returns the underlying wl_shm_pool.
+func (p *Pool) ShmPool() *wl.ShmPool {
+	return p.pool
+}
+
+// Size returns the size of the pool in bytes.
+func (p *Pool) Size() int {
+	return len(p.mmap)
+}
+
+// Bytes returns the entire memory of the pool. The returned slice is
+// only valid until the pool is resized or destroyed.
+func (p *Pool) Bytes() []byte {
+	return p.mmap
+}
+
+// Resize grows the pool to the given size, in bytes. As with
+// wl_shm_pool.resize, a pool can not be shrunk. The memory is mapped
+// again at its new size, so slices previously returned by Bytes or
+// Buffer.Data must not be used afterwards, though the Buffers
+// themselves remain valid.
+func (p *Pool) Resize(size int) error {
+	if size < p.Size() {
+		return fmt.Errorf("can not shrink pool from %v to %v bytes", p.Size(), size)
+	}
+	if size == p.Size() {
+		return nil
+	}
+
+	err := p.file.Truncate(int64(size))
+	if err != nil {
+		return fmt.Errorf("grow pool: %w", err)
+	}
+
+	mmap, err := shm.MapShared(p.file, size, unix.PROT_READ|unix.PROT_WRITE)
+	if err != nil {
+		return fmt.Errorf("mmap pool: %w", err)
+	}
+	p.mmap.Unmap()
+	p.mmap = mmap
+
+	p.pool.Resize(int32(size))
+	return nil
+}
+
+// CreateBuffer creates a buffer that uses the part of the pool
+// starting at offset. The stride is the number of bytes between the
+// start of each row. If it is 0, it is calculated from width and the
+// format using BytesPerPixel. An error is returned if the buffer would
+// not fit into the pool.
+func (p *Pool) CreateBuffer(offset, width, height, stride int, format wl.ShmFormat) (*Buffer, error) {
+	if (width <= 0) || (height <= 0) {
+		return nil, fmt.Errorf("invalid buffer size: %vx%v", width, height)
+	}
+	if stride == 0 {
+		bpp := BytesPerPixel(format)
+		if bpp == 0 {
+			return nil, fmt.Errorf("stride must be specified for format %v", format)
+		}
+		stride = width * bpp
+	}
+
+	size := stride * height
+	if (offset < 0) || (stride < 0) || (offset+size > p.Size()) {
+		return nil, fmt.Errorf("%v byte buffer at offset %v does not fit into %v byte pool", size, offset, p.Size())
+	}
+
+	return &Buffer{
+		pool:   p,
+		buf:    p.pool.CreateBuffer(int32(offset), int32(width), int32(height), int32(stride), format),
+		offset: offset,
+		width:  width,
+		height: height,
+		stride: stride,
+		format: format,
+	}, nil
+}
+
+// Destroy destroys the wl_shm_pool and releases the memory. Buffers
+// created from the pool can still be used by the compositor until
+// they are destroyed, but their data must not be accessed by the
+// client anymore.
+func (p *Pool) Destroy() error {
+	p.pool.Destroy()
+	return errors.Join(p.mmap.Unmap(), p.file.Close())
+}
+
+// Buffer is a wl_buffer that uses part of a Pool for its pixels.
+type Buffer struct {
+	pool   *Pool
+	buf    *wl.Buffer
+	offset int
+	width  int
+	height int
+	stride int
+	format wl.ShmFormat
+	owned  bool
+}
+
+// NewBuffer creates a buffer of the given size and format in its own
+// pool, which is destroyed along with it. It is a shortcut for the
+// common case of not needing to share a pool between several buffers.
+func NewBuffer(s *wl.Shm, width, height int, format wl.ShmFormat) (*Buffer, error) {
+	bpp := BytesPerPixel(format)
+	if bpp == 0 {
+		return nil, fmt.Errorf("unsupported format: %v", format)
+	}
+
+	pool, err := NewPool(s, width*height*bpp)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := pool.CreateBuffer(0, width, height, 0, format)
+	if err != nil {
+		pool.Destroy()
+		return nil, err
+	}
+	buf.owned = true
+
+	return buf, nil
+}
+
+// Buffer returns the underlying wl_buffer, such as for attaching it
+// to a surface.
+func (b *Buffer) Buffer() *wl.Buffer {
+	return b.buf
+}
+
+// Pool returns the pool that the buffer belongs to.
+func (b *Buffer) Pool() *Pool {
+	return b.pool
+}
+
+// Width returns the width of the buffer in pixels.
+func (b *Buffer) Width() int {
+	return b.width
+}
+
+// Height returns the height of the buffer in pixels.
+func (b *Buffer) Height() int {
+	return b.height
+}
+
+// Stride returns the number of bytes between the start of each row.
+func (b *Buffer) Stride() int {
+	return b.stride
+}
+
+// Format returns the pixel format of the buffer.
+func (b *Buffer) Format() wl.ShmFormat {
+	return b.format
+}
+
+// Data returns the memory that holds the buffer's pixels. The
+// returned slice is only valid until the pool is resized or
+// destroyed.
+func (b *Buffer) Data() []byte {
+	return b.pool.mmap[b.offset : b.offset+b.stride*b.height]
+}
+
+// Destroy destroys the wl_buffer. If the buffer was created with
+// NewBuffer, its pool is destroyed as well.
+func (b *Buffer) Destroy() error {
+	b.buf.Destroy()
+	if b.owned {
+		return b.pool.Destroy()
+	}
+	return nil
+}
@@ -1,4 +1,30 @@
-// Code generated by wlgen. DO NOT EDIT.
+// Code generated by wlgen from the xdg_shell protocol. DO NOT EDIT.
+
+// Copyright © 2008-2013 Kristian Høgsberg
+// Copyright © 2013      Rafael Antognolli
+// Copyright © 2013      Jasper St. Pierre
+// Copyright © 2010-2013 Intel Corporation
+// Copyright © 2015-2017 Samsung Electronics Co., Ltd
+// Copyright © 2015-2017 Red Hat Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
 
 package xdg
 
@@ -8,6 +34,20 @@ import (
 	"fmt"
 )
 
+// ProtocolName is the name of the protocol that this file was
+// generated from.
+const ProtocolName = "xdg_shell"
+
+// Interfaces lists the interfaces defined by the xdg_shell
+// protocol along with the highest version of each that is supported.
+var Interfaces = []wire.InterfaceInfo{
+	{Name: WmBaseInterface, Version: WmBaseVersion},
+	{Name: PositionerInterface, Version: PositionerVersion},
+	{Name: SurfaceInterface, Version: SurfaceVersion},
+	{Name: ToplevelInterface, Version: ToplevelVersion},
+	{Name: PopupInterface, Version: PopupVersion},
+}
+
 const (
 	WmBaseInterface = "xdg_wm_base"
 	WmBaseVersion   = 5
@@ -32,40 +72,59 @@ type WmBaseListener interface {
 	Ping(serial uint32)
 }
 
+// WmBasePingEvent holds the arguments of a xdg_wm_base.ping
+// event.
+type WmBasePingEvent struct {
+	Serial uint32
+}
+
 // The xdg_wm_base interface is exposed as a global object enabling clients
 // to turn their wl_surfaces into windows in a desktop environment. It
 // defines the basic functionality needed for clients and the compositor to
 // create windows that can be dragged, resized, maximized, etc, as well as
 // creating transient windows such as popup menus.
 type WmBase struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener WmBaseListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnPing, if not nil, is called with the arguments of
+	// each incoming ping event before Listener is.
+	OnPing func(WmBasePingEvent)
 }
 
+var (
+	_ wire.Object      = (*WmBase)(nil)
+	_ wire.DebugObject = (*WmBase)(nil)
+)
+
 // NewWmBase returns a newly instantiated WmBase. It is
 // primarily intended for use by generated code.
 func NewWmBase(state wire.State) *WmBase {
-	return &WmBase{state: state}
-}
+	return &WmBase{Proxy: wire.NewProxy(state)}
+}
+
+// BindWmBase binds the global identified by name to a new
+// WmBase. The version should be the one advertised for the
+// global. The version actually bound is the highest one that is
+// supported by both that and WmBaseVersion. If there is no
+// such version, nothing is bound and a wire.VersionError is
+// returned.
+func BindWmBase(state wire.State, registry wire.Binder, name, version uint32) (*WmBase, error) {
+	v := wire.NegotiateVersion(WmBaseVersion, version)
+	if v == 0 {
+		return nil, wire.VersionError{Interface: WmBaseInterface, Local: WmBaseVersion, Remote: version}
+	}
 
-func BindWmBase(state wire.State, registry wire.Binder, name, version uint32) *WmBase {
 	obj := NewWmBase(state)
+	obj.SetVersion(v)
 	state.Add(obj)
-	registry.Bind(name, wire.NewID{Interface: WmBaseInterface, Version: version, ID: obj.ID()})
-	return obj
-}
-
-func (obj *WmBase) State() wire.State {
-	return obj.state
+	registry.Bind(name, wire.NewID{Interface: WmBaseInterface, Version: v, ID: obj.ID()})
+	return obj, nil
 }
 
 func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
@@ -78,6 +137,11 @@ func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnPing != nil {
+			obj.OnPing(WmBasePingEvent{
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -94,22 +158,8 @@ func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *WmBase) ID() uint32 {
-	return obj.id
-}
-
-func (obj *WmBase) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *WmBase) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *WmBase) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_wm_base", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_wm_base", obj.ID())
 }
 
 func (obj *WmBase) MethodName(op uint16) string {
@@ -125,7 +175,19 @@ func (obj *WmBase) Interface() string {
 	return WmBaseInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// WmBaseVersion, the same as MaxVersion.
 func (obj *WmBase) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return WmBaseVersion
+}
+
+// MaxVersion returns WmBaseVersion, the highest version of
+// xdg_wm_base that is supported.
+func (obj *WmBase) MaxVersion() uint32 {
 	return WmBaseVersion
 }
 
@@ -139,7 +201,7 @@ func (obj *WmBase) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -149,13 +211,14 @@ func (obj *WmBase) Destroy() {
 func (obj *WmBase) CreatePositioner() (id *Positioner) {
 	builder := wire.NewMessage(obj, 1)
 
-	id = NewPositioner(obj.state)
-	obj.state.Add(id)
+	id = NewPositioner(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "create_positioner"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -175,14 +238,15 @@ func (obj *WmBase) CreatePositioner() (id *Positioner) {
 func (obj *WmBase) GetXdgSurface(surface *wl.Surface) (id *Surface) {
 	builder := wire.NewMessage(obj, 2)
 
-	id = NewSurface(obj.state)
-	obj.state.Add(id)
+	id = NewSurface(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 	builder.WriteObject(surface)
 
 	builder.Method = "get_xdg_surface"
 	builder.Args = []any{id, surface}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -196,7 +260,7 @@ func (obj *WmBase) Pong(serial uint32) {
 
 	builder.Method = "pong"
 	builder.Args = []any{serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -225,31 +289,28 @@ const (
 	WmBaseErrorUnresponsive WmBaseError = 6
 )
 
-func (enum WmBaseError) String() string {
-	switch enum {
-	case 0:
-		return "WmBaseErrorRole"
-
-	case 1:
-		return "WmBaseErrorDefunctSurfaces"
-
-	case 2:
-		return "WmBaseErrorNotTheTopmostPopup"
-
-	case 3:
-		return "WmBaseErrorInvalidPopupParent"
-
-	case 4:
-		return "WmBaseErrorInvalidSurfaceState"
-
-	case 5:
-		return "WmBaseErrorInvalidPositioner"
+// WmBaseErrorNames maps the values of WmBaseError to their names.
+var WmBaseErrorNames = map[WmBaseError]string{
+	WmBaseErrorRole:                "WmBaseErrorRole",
+	WmBaseErrorDefunctSurfaces:     "WmBaseErrorDefunctSurfaces",
+	WmBaseErrorNotTheTopmostPopup:  "WmBaseErrorNotTheTopmostPopup",
+	WmBaseErrorInvalidPopupParent:  "WmBaseErrorInvalidPopupParent",
+	WmBaseErrorInvalidSurfaceState: "WmBaseErrorInvalidSurfaceState",
+	WmBaseErrorInvalidPositioner:   "WmBaseErrorInvalidPositioner",
+	WmBaseErrorUnresponsive:        "WmBaseErrorUnresponsive",
+}
 
-	case 6:
-		return "WmBaseErrorUnresponsive"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum WmBaseError) String() string {
+	return wire.EnumString(enum, WmBaseErrorNames)
+}
 
-	return "<invalid WmBaseError>"
+// Since returns the version of xdg_wm_base that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum WmBaseError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -277,23 +338,18 @@ const (
 // set_anchor_rect. Passing an incomplete xdg_positioner object when
 // positioning a surface raises an invalid_positioner error.
 type Positioner struct {
-
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	wire.Proxy
 }
 
+var (
+	_ wire.Object      = (*Positioner)(nil)
+	_ wire.DebugObject = (*Positioner)(nil)
+)
+
 // NewPositioner returns a newly instantiated Positioner. It is
 // primarily intended for use by generated code.
 func NewPositioner(state wire.State) *Positioner {
-	return &Positioner{state: state}
-}
-
-func (obj *Positioner) State() wire.State {
-	return obj.state
+	return &Positioner{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
@@ -305,22 +361,8 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Positioner) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Positioner) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Positioner) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Positioner) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_positioner", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_positioner", obj.ID())
 }
 
 func (obj *Positioner) MethodName(op uint16) string {
@@ -334,7 +376,19 @@ func (obj *Positioner) Interface() string {
 	return PositionerInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// PositionerVersion, the same as MaxVersion.
 func (obj *Positioner) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return PositionerVersion
+}
+
+// MaxVersion returns PositionerVersion, the highest version of
+// xdg_positioner that is supported.
+func (obj *Positioner) MaxVersion() uint32 {
 	return PositionerVersion
 }
 
@@ -344,7 +398,7 @@ func (obj *Positioner) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -361,7 +415,7 @@ func (obj *Positioner) SetSize(width int32, height int32) {
 
 	builder.Method = "set_size"
 	builder.Args = []any{width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -385,7 +439,7 @@ func (obj *Positioner) SetAnchorRect(x int32, y int32, width int32, height int32
 
 	builder.Method = "set_anchor_rect"
 	builder.Args = []any{x, y, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -402,7 +456,7 @@ func (obj *Positioner) SetAnchor(anchor PositionerAnchor) {
 
 	builder.Method = "set_anchor"
 	builder.Args = []any{anchor}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -420,7 +474,7 @@ func (obj *Positioner) SetGravity(gravity PositionerGravity) {
 
 	builder.Method = "set_gravity"
 	builder.Args = []any{gravity}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -444,7 +498,7 @@ func (obj *Positioner) SetConstraintAdjustment(constraintAdjustment uint32) {
 
 	builder.Method = "set_constraint_adjustment"
 	builder.Args = []any{constraintAdjustment}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -467,7 +521,7 @@ func (obj *Positioner) SetOffset(x int32, y int32) {
 
 	builder.Method = "set_offset"
 	builder.Args = []any{x, y}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -482,7 +536,7 @@ func (obj *Positioner) SetReactive() {
 
 	builder.Method = "set_reactive"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -501,7 +555,7 @@ func (obj *Positioner) SetParentSize(parentWidth int32, parentHeight int32) {
 
 	builder.Method = "set_parent_size"
 	builder.Args = []any{parentWidth, parentHeight}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -516,7 +570,7 @@ func (obj *Positioner) SetParentConfigure(serial uint32) {
 
 	builder.Method = "set_parent_configure"
 	builder.Args = []any{serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -527,13 +581,22 @@ const (
 	PositionerErrorInvalidInput PositionerError = 0
 )
 
+// PositionerErrorNames maps the values of PositionerError to their names.
+var PositionerErrorNames = map[PositionerError]string{
+	PositionerErrorInvalidInput: "PositionerErrorInvalidInput",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PositionerError) String() string {
-	switch enum {
-	case 0:
-		return "PositionerErrorInvalidInput"
-	}
+	return wire.EnumString(enum, PositionerErrorNames)
+}
 
-	return "<invalid PositionerError>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerError) Since() uint32 {
+	return 1
 }
 
 type PositionerAnchor int64
@@ -558,37 +621,30 @@ const (
 	PositionerAnchorBottomRight PositionerAnchor = 8
 )
 
+// PositionerAnchorNames maps the values of PositionerAnchor to their names.
+var PositionerAnchorNames = map[PositionerAnchor]string{
+	PositionerAnchorNone:        "PositionerAnchorNone",
+	PositionerAnchorTop:         "PositionerAnchorTop",
+	PositionerAnchorBottom:      "PositionerAnchorBottom",
+	PositionerAnchorLeft:        "PositionerAnchorLeft",
+	PositionerAnchorRight:       "PositionerAnchorRight",
+	PositionerAnchorTopLeft:     "PositionerAnchorTopLeft",
+	PositionerAnchorBottomLeft:  "PositionerAnchorBottomLeft",
+	PositionerAnchorTopRight:    "PositionerAnchorTopRight",
+	PositionerAnchorBottomRight: "PositionerAnchorBottomRight",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PositionerAnchor) String() string {
-	switch enum {
-	case 0:
-		return "PositionerAnchorNone"
-
-	case 1:
-		return "PositionerAnchorTop"
-
-	case 2:
-		return "PositionerAnchorBottom"
-
-	case 3:
-		return "PositionerAnchorLeft"
-
-	case 4:
-		return "PositionerAnchorRight"
-
-	case 5:
-		return "PositionerAnchorTopLeft"
-
-	case 6:
-		return "PositionerAnchorBottomLeft"
-
-	case 7:
-		return "PositionerAnchorTopRight"
-
-	case 8:
-		return "PositionerAnchorBottomRight"
-	}
+	return wire.EnumString(enum, PositionerAnchorNames)
+}
 
-	return "<invalid PositionerAnchor>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerAnchor) Since() uint32 {
+	return 1
 }
 
 type PositionerGravity int64
@@ -613,37 +669,30 @@ const (
 	PositionerGravityBottomRight PositionerGravity = 8
 )
 
+// PositionerGravityNames maps the values of PositionerGravity to their names.
+var PositionerGravityNames = map[PositionerGravity]string{
+	PositionerGravityNone:        "PositionerGravityNone",
+	PositionerGravityTop:         "PositionerGravityTop",
+	PositionerGravityBottom:      "PositionerGravityBottom",
+	PositionerGravityLeft:        "PositionerGravityLeft",
+	PositionerGravityRight:       "PositionerGravityRight",
+	PositionerGravityTopLeft:     "PositionerGravityTopLeft",
+	PositionerGravityBottomLeft:  "PositionerGravityBottomLeft",
+	PositionerGravityTopRight:    "PositionerGravityTopRight",
+	PositionerGravityBottomRight: "PositionerGravityBottomRight",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PositionerGravity) String() string {
-	switch enum {
-	case 0:
-		return "PositionerGravityNone"
-
-	case 1:
-		return "PositionerGravityTop"
-
-	case 2:
-		return "PositionerGravityBottom"
-
-	case 3:
-		return "PositionerGravityLeft"
-
-	case 4:
-		return "PositionerGravityRight"
-
-	case 5:
-		return "PositionerGravityTopLeft"
-
-	case 6:
-		return "PositionerGravityBottomLeft"
-
-	case 7:
-		return "PositionerGravityTopRight"
-
-	case 8:
-		return "PositionerGravityBottomRight"
-	}
+	return wire.EnumString(enum, PositionerGravityNames)
+}
 
-	return "<invalid PositionerGravity>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerGravity) Since() uint32 {
+	return 1
 }
 
 // The constraint adjustment value define ways the compositor will adjust
@@ -675,31 +724,28 @@ const (
 	PositionerConstraintAdjustmentResizeY PositionerConstraintAdjustment = 32
 )
 
-func (enum PositionerConstraintAdjustment) String() string {
-	switch enum {
-	case 0:
-		return "PositionerConstraintAdjustmentNone"
-
-	case 1:
-		return "PositionerConstraintAdjustmentSlideX"
-
-	case 2:
-		return "PositionerConstraintAdjustmentSlideY"
-
-	case 4:
-		return "PositionerConstraintAdjustmentFlipX"
-
-	case 8:
-		return "PositionerConstraintAdjustmentFlipY"
-
-	case 16:
-		return "PositionerConstraintAdjustmentResizeX"
+// PositionerConstraintAdjustmentNames maps the values of PositionerConstraintAdjustment to their names.
+var PositionerConstraintAdjustmentNames = map[PositionerConstraintAdjustment]string{
+	PositionerConstraintAdjustmentNone:    "PositionerConstraintAdjustmentNone",
+	PositionerConstraintAdjustmentSlideX:  "PositionerConstraintAdjustmentSlideX",
+	PositionerConstraintAdjustmentSlideY:  "PositionerConstraintAdjustmentSlideY",
+	PositionerConstraintAdjustmentFlipX:   "PositionerConstraintAdjustmentFlipX",
+	PositionerConstraintAdjustmentFlipY:   "PositionerConstraintAdjustmentFlipY",
+	PositionerConstraintAdjustmentResizeX: "PositionerConstraintAdjustmentResizeX",
+	PositionerConstraintAdjustmentResizeY: "PositionerConstraintAdjustmentResizeY",
+}
 
-	case 32:
-		return "PositionerConstraintAdjustmentResizeY"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum PositionerConstraintAdjustment) String() string {
+	return wire.FlagString(enum, PositionerConstraintAdjustmentNames)
+}
 
-	return "<invalid PositionerConstraintAdjustment>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerConstraintAdjustment) Since() uint32 {
+	return 1
 }
 
 const (
@@ -729,6 +775,12 @@ type SurfaceListener interface {
 	Configure(serial uint32)
 }
 
+// SurfaceConfigureEvent holds the arguments of a xdg_surface.configure
+// event.
+type SurfaceConfigureEvent struct {
+	Serial uint32
+}
+
 // An interface that may be implemented by a wl_surface, for
 // implementations that provide a desktop-style user interface.
 //
@@ -775,27 +827,28 @@ type SurfaceListener interface {
 // has not been destroyed, i.e. the client must perform the initial commit
 // again before attaching a buffer.
 type Surface struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SurfaceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
-
-	state wire.State
-	id    uint32
+	// OnConfigure, if not nil, is called with the arguments of
+	// each incoming configure event before Listener is.
+	OnConfigure func(SurfaceConfigureEvent)
 }
 
+var (
+	_ wire.Object      = (*Surface)(nil)
+	_ wire.DebugObject = (*Surface)(nil)
+)
+
 // NewSurface returns a newly instantiated Surface. It is
 // primarily intended for use by generated code.
 func NewSurface(state wire.State) *Surface {
-	return &Surface{state: state}
-}
-
-func (obj *Surface) State() wire.State {
-	return obj.state
+	return &Surface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
@@ -808,6 +861,11 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnConfigure != nil {
+			obj.OnConfigure(SurfaceConfigureEvent{
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -824,22 +882,8 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Surface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Surface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Surface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Surface) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_surface", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_surface", obj.ID())
 }
 
 func (obj *Surface) MethodName(op uint16) string {
@@ -855,7 +899,19 @@ func (obj *Surface) Interface() string {
 	return SurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SurfaceVersion, the same as MaxVersion.
 func (obj *Surface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SurfaceVersion
+}
+
+// MaxVersion returns SurfaceVersion, the highest version of
+// xdg_surface that is supported.
+func (obj *Surface) MaxVersion() uint32 {
 	return SurfaceVersion
 }
 
@@ -867,7 +923,7 @@ func (obj *Surface) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -879,13 +935,14 @@ func (obj *Surface) Destroy() {
 func (obj *Surface) GetToplevel() (id *Toplevel) {
 	builder := wire.NewMessage(obj, 1)
 
-	id = NewToplevel(obj.state)
-	obj.state.Add(id)
+	id = NewToplevel(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 
 	builder.Method = "get_toplevel"
 	builder.Args = []any{id}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -900,15 +957,16 @@ func (obj *Surface) GetToplevel() (id *Toplevel) {
 func (obj *Surface) GetPopup(parent *Surface, positioner *Positioner) (id *Popup) {
 	builder := wire.NewMessage(obj, 2)
 
-	id = NewPopup(obj.state)
-	obj.state.Add(id)
+	id = NewPopup(obj.State())
+	id.SetVersion(obj.Version())
+	obj.State().Add(id)
 	builder.WriteObject(id)
 	builder.WriteObject(parent)
 	builder.WriteObject(positioner)
 
 	builder.Method = "get_popup"
 	builder.Args = []any{id, parent, positioner}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return id
 }
 
@@ -951,7 +1009,7 @@ func (obj *Surface) SetWindowGeometry(x int32, y int32, width int32, height int3
 
 	builder.Method = "set_window_geometry"
 	builder.Args = []any{x, y, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -994,7 +1052,7 @@ func (obj *Surface) AckConfigure(serial uint32) {
 
 	builder.Method = "ack_configure"
 	builder.Args = []any{serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1020,28 +1078,27 @@ const (
 	SurfaceErrorDefunctRoleObject SurfaceError = 6
 )
 
-func (enum SurfaceError) String() string {
-	switch enum {
-	case 1:
-		return "SurfaceErrorNotConstructed"
-
-	case 2:
-		return "SurfaceErrorAlreadyConstructed"
-
-	case 3:
-		return "SurfaceErrorUnconfiguredBuffer"
-
-	case 4:
-		return "SurfaceErrorInvalidSerial"
-
-	case 5:
-		return "SurfaceErrorInvalidSize"
+// SurfaceErrorNames maps the values of SurfaceError to their names.
+var SurfaceErrorNames = map[SurfaceError]string{
+	SurfaceErrorNotConstructed:     "SurfaceErrorNotConstructed",
+	SurfaceErrorAlreadyConstructed: "SurfaceErrorAlreadyConstructed",
+	SurfaceErrorUnconfiguredBuffer: "SurfaceErrorUnconfiguredBuffer",
+	SurfaceErrorInvalidSerial:      "SurfaceErrorInvalidSerial",
+	SurfaceErrorInvalidSize:        "SurfaceErrorInvalidSize",
+	SurfaceErrorDefunctRoleObject:  "SurfaceErrorDefunctRoleObject",
+}
 
-	case 6:
-		return "SurfaceErrorDefunctRoleObject"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum SurfaceError) String() string {
+	return wire.EnumString(enum, SurfaceErrorNames)
+}
 
-	return "<invalid SurfaceError>"
+// Since returns the version of xdg_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SurfaceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -1123,6 +1180,32 @@ type ToplevelListener interface {
 	WmCapabilities(capabilities []byte)
 }
 
+// ToplevelConfigureEvent holds the arguments of a xdg_toplevel.configure
+// event.
+type ToplevelConfigureEvent struct {
+	Width  int32
+	Height int32
+	States []byte
+}
+
+// ToplevelCloseEvent holds the arguments of a xdg_toplevel.close
+// event.
+type ToplevelCloseEvent struct {
+}
+
+// ToplevelConfigureBoundsEvent holds the arguments of a xdg_toplevel.configure_bounds
+// event.
+type ToplevelConfigureBoundsEvent struct {
+	Width  int32
+	Height int32
+}
+
+// ToplevelWmCapabilitiesEvent holds the arguments of a xdg_toplevel.wm_capabilities
+// event.
+type ToplevelWmCapabilitiesEvent struct {
+	Capabilities []byte
+}
+
 // This interface defines an xdg_surface role which allows a surface to,
 // among other things, set window-like properties such as maximize,
 // fullscreen, and minimize, set application-specific metadata like title and
@@ -1141,27 +1224,40 @@ type ToplevelListener interface {
 //
 // Attaching a null buffer to a toplevel unmaps the surface.
 type Toplevel struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ToplevelListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnConfigure, if not nil, is called with the arguments of
+	// each incoming configure event before Listener is.
+	OnConfigure func(ToplevelConfigureEvent)
+
+	// OnClose, if not nil, is called with the arguments of
+	// each incoming close event before Listener is.
+	OnClose func(ToplevelCloseEvent)
 
-	state wire.State
-	id    uint32
+	// OnConfigureBounds, if not nil, is called with the arguments of
+	// each incoming configure_bounds event before Listener is.
+	OnConfigureBounds func(ToplevelConfigureBoundsEvent)
+
+	// OnWmCapabilities, if not nil, is called with the arguments of
+	// each incoming wm_capabilities event before Listener is.
+	OnWmCapabilities func(ToplevelWmCapabilitiesEvent)
 }
 
+var (
+	_ wire.Object      = (*Toplevel)(nil)
+	_ wire.DebugObject = (*Toplevel)(nil)
+)
+
 // NewToplevel returns a newly instantiated Toplevel. It is
 // primarily intended for use by generated code.
 func NewToplevel(state wire.State) *Toplevel {
-	return &Toplevel{state: state}
-}
-
-func (obj *Toplevel) State() wire.State {
-	return obj.state
+	return &Toplevel{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
@@ -1178,6 +1274,13 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnConfigure != nil {
+			obj.OnConfigure(ToplevelConfigureEvent{
+				Width:  width,
+				Height: height,
+				States: states,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1193,6 +1296,9 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnClose != nil {
+			obj.OnClose(ToplevelCloseEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1209,6 +1315,12 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnConfigureBounds != nil {
+			obj.OnConfigureBounds(ToplevelConfigureBoundsEvent{
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1226,6 +1338,11 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnWmCapabilities != nil {
+			obj.OnWmCapabilities(ToplevelWmCapabilitiesEvent{
+				Capabilities: capabilities,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1242,22 +1359,8 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Toplevel) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Toplevel) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Toplevel) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Toplevel) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_toplevel", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_toplevel", obj.ID())
 }
 
 func (obj *Toplevel) MethodName(op uint16) string {
@@ -1282,7 +1385,19 @@ func (obj *Toplevel) Interface() string {
 	return ToplevelInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ToplevelVersion, the same as MaxVersion.
 func (obj *Toplevel) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ToplevelVersion
+}
+
+// MaxVersion returns ToplevelVersion, the highest version of
+// xdg_toplevel that is supported.
+func (obj *Toplevel) MaxVersion() uint32 {
 	return ToplevelVersion
 }
 
@@ -1293,7 +1408,7 @@ func (obj *Toplevel) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1324,7 +1439,7 @@ func (obj *Toplevel) SetParent(parent *Toplevel) {
 
 	builder.Method = "set_parent"
 	builder.Args = []any{parent}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1340,9 +1455,11 @@ func (obj *Toplevel) SetTitle(title string) {
 
 	builder.WriteString(title)
 
+	builder.Fail(wire.CheckMessageSize(ToplevelInterface, "set_title", builder))
+
 	builder.Method = "set_title"
 	builder.Args = []any{title}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1374,9 +1491,11 @@ func (obj *Toplevel) SetAppId(appId string) {
 
 	builder.WriteString(appId)
 
+	builder.Fail(wire.CheckMessageSize(ToplevelInterface, "set_app_id", builder))
+
 	builder.Method = "set_app_id"
 	builder.Args = []any{appId}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1402,7 +1521,7 @@ func (obj *Toplevel) ShowWindowMenu(seat *wl.Seat, serial uint32, x int32, y int
 
 	builder.Method = "show_window_menu"
 	builder.Args = []any{seat, serial, x, y}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1430,7 +1549,7 @@ func (obj *Toplevel) Move(seat *wl.Seat, serial uint32) {
 
 	builder.Method = "move"
 	builder.Args = []any{seat, serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1474,7 +1593,7 @@ func (obj *Toplevel) Resize(seat *wl.Seat, serial uint32, edges ToplevelResizeEd
 
 	builder.Method = "resize"
 	builder.Args = []any{seat, serial, edges}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1520,7 +1639,7 @@ func (obj *Toplevel) SetMaxSize(width int32, height int32) {
 
 	builder.Method = "set_max_size"
 	builder.Args = []any{width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1566,7 +1685,7 @@ func (obj *Toplevel) SetMinSize(width int32, height int32) {
 
 	builder.Method = "set_min_size"
 	builder.Args = []any{width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1594,7 +1713,7 @@ func (obj *Toplevel) SetMaximized() {
 
 	builder.Method = "set_maximized"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1624,7 +1743,7 @@ func (obj *Toplevel) UnsetMaximized() {
 
 	builder.Method = "unset_maximized"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1658,7 +1777,7 @@ func (obj *Toplevel) SetFullscreen(output *wl.Output) {
 
 	builder.Method = "set_fullscreen"
 	builder.Args = []any{output}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1684,7 +1803,7 @@ func (obj *Toplevel) UnsetFullscreen() {
 
 	builder.Method = "unset_fullscreen"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1701,7 +1820,7 @@ func (obj *Toplevel) SetMinimized() {
 
 	builder.Method = "set_minimized"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1719,19 +1838,24 @@ const (
 	ToplevelErrorInvalidSize ToplevelError = 2
 )
 
-func (enum ToplevelError) String() string {
-	switch enum {
-	case 0:
-		return "ToplevelErrorInvalidResizeEdge"
-
-	case 1:
-		return "ToplevelErrorInvalidParent"
+// ToplevelErrorNames maps the values of ToplevelError to their names.
+var ToplevelErrorNames = map[ToplevelError]string{
+	ToplevelErrorInvalidResizeEdge: "ToplevelErrorInvalidResizeEdge",
+	ToplevelErrorInvalidParent:     "ToplevelErrorInvalidParent",
+	ToplevelErrorInvalidSize:       "ToplevelErrorInvalidSize",
+}
 
-	case 2:
-		return "ToplevelErrorInvalidSize"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ToplevelError) String() string {
+	return wire.EnumString(enum, ToplevelErrorNames)
+}
 
-	return "<invalid ToplevelError>"
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelError) Since() uint32 {
+	return 1
 }
 
 // These values are used to indicate which edge of a surface
@@ -1758,37 +1882,30 @@ const (
 	ToplevelResizeEdgeBottomRight ToplevelResizeEdge = 10
 )
 
+// ToplevelResizeEdgeNames maps the values of ToplevelResizeEdge to their names.
+var ToplevelResizeEdgeNames = map[ToplevelResizeEdge]string{
+	ToplevelResizeEdgeNone:        "ToplevelResizeEdgeNone",
+	ToplevelResizeEdgeTop:         "ToplevelResizeEdgeTop",
+	ToplevelResizeEdgeBottom:      "ToplevelResizeEdgeBottom",
+	ToplevelResizeEdgeLeft:        "ToplevelResizeEdgeLeft",
+	ToplevelResizeEdgeTopLeft:     "ToplevelResizeEdgeTopLeft",
+	ToplevelResizeEdgeBottomLeft:  "ToplevelResizeEdgeBottomLeft",
+	ToplevelResizeEdgeRight:       "ToplevelResizeEdgeRight",
+	ToplevelResizeEdgeTopRight:    "ToplevelResizeEdgeTopRight",
+	ToplevelResizeEdgeBottomRight: "ToplevelResizeEdgeBottomRight",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum ToplevelResizeEdge) String() string {
-	switch enum {
-	case 0:
-		return "ToplevelResizeEdgeNone"
-
-	case 1:
-		return "ToplevelResizeEdgeTop"
-
-	case 2:
-		return "ToplevelResizeEdgeBottom"
-
-	case 4:
-		return "ToplevelResizeEdgeLeft"
-
-	case 5:
-		return "ToplevelResizeEdgeTopLeft"
-
-	case 6:
-		return "ToplevelResizeEdgeBottomLeft"
-
-	case 8:
-		return "ToplevelResizeEdgeRight"
-
-	case 9:
-		return "ToplevelResizeEdgeTopRight"
-
-	case 10:
-		return "ToplevelResizeEdgeBottomRight"
-	}
+	return wire.EnumString(enum, ToplevelResizeEdgeNames)
+}
 
-	return "<invalid ToplevelResizeEdge>"
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelResizeEdge) Since() uint32 {
+	return 1
 }
 
 // The different state values used on the surface. This is designed for
@@ -1813,43 +1930,52 @@ const (
 	// the surface is now activated
 	ToplevelStateActivated ToplevelState = 4
 
+	// Since version 2.
 	ToplevelStateTiledLeft ToplevelState = 5
 
+	// Since version 2.
 	ToplevelStateTiledRight ToplevelState = 6
 
+	// Since version 2.
 	ToplevelStateTiledTop ToplevelState = 7
 
+	// Since version 2.
 	ToplevelStateTiledBottom ToplevelState = 8
 )
 
-func (enum ToplevelState) String() string {
-	switch enum {
-	case 1:
-		return "ToplevelStateMaximized"
-
-	case 2:
-		return "ToplevelStateFullscreen"
-
-	case 3:
-		return "ToplevelStateResizing"
+// ToplevelStateNames maps the values of ToplevelState to their names.
+var ToplevelStateNames = map[ToplevelState]string{
+	ToplevelStateMaximized:   "ToplevelStateMaximized",
+	ToplevelStateFullscreen:  "ToplevelStateFullscreen",
+	ToplevelStateResizing:    "ToplevelStateResizing",
+	ToplevelStateActivated:   "ToplevelStateActivated",
+	ToplevelStateTiledLeft:   "ToplevelStateTiledLeft",
+	ToplevelStateTiledRight:  "ToplevelStateTiledRight",
+	ToplevelStateTiledTop:    "ToplevelStateTiledTop",
+	ToplevelStateTiledBottom: "ToplevelStateTiledBottom",
+}
 
-	case 4:
-		return "ToplevelStateActivated"
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ToplevelState) String() string {
+	return wire.EnumString(enum, ToplevelStateNames)
+}
 
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelState) Since() uint32 {
+	switch enum {
 	case 5:
-		return "ToplevelStateTiledLeft"
-
+		return 2
 	case 6:
-		return "ToplevelStateTiledRight"
-
+		return 2
 	case 7:
-		return "ToplevelStateTiledTop"
-
+		return 2
 	case 8:
-		return "ToplevelStateTiledBottom"
+		return 2
 	}
-
-	return "<invalid ToplevelState>"
+	return 1
 }
 
 type ToplevelWmCapabilities int64
@@ -1868,22 +1994,25 @@ const (
 	ToplevelWmCapabilitiesMinimize ToplevelWmCapabilities = 4
 )
 
-func (enum ToplevelWmCapabilities) String() string {
-	switch enum {
-	case 1:
-		return "ToplevelWmCapabilitiesWindowMenu"
-
-	case 2:
-		return "ToplevelWmCapabilitiesMaximize"
-
-	case 3:
-		return "ToplevelWmCapabilitiesFullscreen"
+// ToplevelWmCapabilitiesNames maps the values of ToplevelWmCapabilities to their names.
+var ToplevelWmCapabilitiesNames = map[ToplevelWmCapabilities]string{
+	ToplevelWmCapabilitiesWindowMenu: "ToplevelWmCapabilitiesWindowMenu",
+	ToplevelWmCapabilitiesMaximize:   "ToplevelWmCapabilitiesMaximize",
+	ToplevelWmCapabilitiesFullscreen: "ToplevelWmCapabilitiesFullscreen",
+	ToplevelWmCapabilitiesMinimize:   "ToplevelWmCapabilitiesMinimize",
+}
 
-	case 4:
-		return "ToplevelWmCapabilitiesMinimize"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ToplevelWmCapabilities) String() string {
+	return wire.EnumString(enum, ToplevelWmCapabilitiesNames)
+}
 
-	return "<invalid ToplevelWmCapabilities>"
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelWmCapabilities) Since() uint32 {
+	return 1
 }
 
 const (
@@ -1931,6 +2060,26 @@ type PopupListener interface {
 	Repositioned(token uint32)
 }
 
+// PopupConfigureEvent holds the arguments of a xdg_popup.configure
+// event.
+type PopupConfigureEvent struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
+// PopupPopupDoneEvent holds the arguments of a xdg_popup.popup_done
+// event.
+type PopupPopupDoneEvent struct {
+}
+
+// PopupRepositionedEvent holds the arguments of a xdg_popup.repositioned
+// event.
+type PopupRepositionedEvent struct {
+	Token uint32
+}
+
 // A popup surface is a short-lived, temporary surface. It can be used to
 // implement for example menus, popovers, tooltips and other similar user
 // interface concepts.
@@ -1956,27 +2105,36 @@ type PopupListener interface {
 // The client must call wl_surface.commit on the corresponding wl_surface
 // for the xdg_popup state to take effect.
 type Popup struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener PopupListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnConfigure, if not nil, is called with the arguments of
+	// each incoming configure event before Listener is.
+	OnConfigure func(PopupConfigureEvent)
+
+	// OnPopupDone, if not nil, is called with the arguments of
+	// each incoming popup_done event before Listener is.
+	OnPopupDone func(PopupPopupDoneEvent)
 
-	state wire.State
-	id    uint32
+	// OnRepositioned, if not nil, is called with the arguments of
+	// each incoming repositioned event before Listener is.
+	OnRepositioned func(PopupRepositionedEvent)
 }
 
+var (
+	_ wire.Object      = (*Popup)(nil)
+	_ wire.DebugObject = (*Popup)(nil)
+)
+
 // NewPopup returns a newly instantiated Popup. It is
 // primarily intended for use by generated code.
 func NewPopup(state wire.State) *Popup {
-	return &Popup{state: state}
-}
-
-func (obj *Popup) State() wire.State {
-	return obj.state
+	return &Popup{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
@@ -1995,6 +2153,14 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnConfigure != nil {
+			obj.OnConfigure(PopupConfigureEvent{
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2011,6 +2177,9 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnPopupDone != nil {
+			obj.OnPopupDone(PopupPopupDoneEvent{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2025,6 +2194,11 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnRepositioned != nil {
+			obj.OnRepositioned(PopupRepositionedEvent{
+				Token: token,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2041,22 +2215,8 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Popup) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Popup) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Popup) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Popup) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_popup", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_popup", obj.ID())
 }
 
 func (obj *Popup) MethodName(op uint16) string {
@@ -2078,7 +2238,19 @@ func (obj *Popup) Interface() string {
 	return PopupInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// PopupVersion, the same as MaxVersion.
 func (obj *Popup) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return PopupVersion
+}
+
+// MaxVersion returns PopupVersion, the highest version of
+// xdg_popup that is supported.
+func (obj *Popup) MaxVersion() uint32 {
 	return PopupVersion
 }
 
@@ -2092,7 +2264,7 @@ func (obj *Popup) Destroy() {
 
 	builder.Method = "destroy"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2141,7 +2313,7 @@ func (obj *Popup) Grab(seat *wl.Seat, serial uint32) {
 
 	builder.Method = "grab"
 	builder.Args = []any{seat, serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2176,7 +2348,7 @@ func (obj *Popup) Reposition(positioner *Positioner, token uint32) {
 
 	builder.Method = "reposition"
 	builder.Args = []any{positioner, token}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2187,11 +2359,20 @@ const (
 	PopupErrorInvalidGrab PopupError = 0
 )
 
+// PopupErrorNames maps the values of PopupError to their names.
+var PopupErrorNames = map[PopupError]string{
+	PopupErrorInvalidGrab: "PopupErrorInvalidGrab",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PopupError) String() string {
-	switch enum {
-	case 0:
-		return "PopupErrorInvalidGrab"
-	}
+	return wire.EnumString(enum, PopupErrorNames)
+}
 
-	return "<invalid PopupError>"
+// Since returns the version of xdg_popup that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PopupError) Since() uint32 {
+	return 1
 }
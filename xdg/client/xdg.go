@@ -0,0 +1,129 @@
+// Package xdg contains client-side bindings for the xdg-shell
+// protocol, along with helpers for the configure handshake that every
+// window has to go through before it can be shown.
+package xdg
+
+import (
+	"slices"
+
+	wl "deedles.dev/wl/client"
+	"deedles.dev/wl/wire"
+)
+
+// AutoPong sets wm's OnPing handler to reply to every ping from the
+// compositor immediately, which is all that most clients need to do
+// to avoid being considered unresponsive.
+func (wm *WmBase) AutoPong() {
+	wm.OnPing = func(ev WmBasePingEvent) {
+		wm.Pong(ev.Serial)
+	}
+}
+
+// ToplevelConfig is the state of a toplevel window sent by the
+// compositor in a single configure sequence.
+type ToplevelConfig struct {
+	// Serial is the serial of the xdg_surface.configure event that
+	// ended the sequence. It has already been acknowledged by the time
+	// that the ToplevelConfig is handed to the client.
+	Serial uint32
+
+	// Width and Height are the size that the compositor suggests for
+	// the window. If either is 0, the client should choose that
+	// dimension itself.
+	Width, Height int32
+
+	// States holds the states that the window is in, such as being
+	// maximized or focused.
+	States []ToplevelState
+
+	// BoundsWidth and BoundsHeight are the largest size that the
+	// window can usefully have, such as the size of the output minus
+	// any panels, or 0 if the compositor hasn't sent any bounds.
+	BoundsWidth, BoundsHeight int32
+
+	// Capabilities holds the window management features that the
+	// compositor supports for the window, or is nil if it never said.
+	Capabilities []ToplevelWmCapabilities
+}
+
+// Has reports whether state is in c.States.
+func (c ToplevelConfig) Has(state ToplevelState) bool {
+	return slices.Contains(c.States, state)
+}
+
+// Window manages the objects that make up a toplevel window and
+// handles the configure handshake for them. The xdg_toplevel's
+// configure events are collected until the xdg_surface.configure
+// event that ends the sequence arrives, at which point the sequence is
+// acknowledged with xdg_surface.ack_configure and OnConfigure is
+// called with the result. OnConfigure should then attach a buffer of
+// the appropriate size and commit the surface.
+//
+// Window uses the On* handlers of the XdgSurface and Toplevel, so
+// those should not be changed, but their Listeners can still be set.
+type Window struct {
+	Surface    *wl.Surface
+	XdgSurface *Surface
+	Toplevel   *Toplevel
+
+	// OnConfigure is called at the end of every configure sequence,
+	// after it has been acknowledged.
+	OnConfigure func(ToplevelConfig)
+
+	// OnClose is called when the compositor asks for the window to be
+	// closed, such as because the user clicked its close button.
+	OnClose func()
+
+	pending ToplevelConfig
+}
+
+// NewWindow gives surface the xdg_toplevel role using wm. The
+// compositor doesn't configure the window until the surface has been
+// committed, so set the window's title and other properties and then
+// commit the surface without a buffer attached to get the first
+// OnConfigure call.
+func NewWindow(wm *WmBase, surface *wl.Surface) *Window {
+	w := Window{Surface: surface}
+	w.XdgSurface = wm.GetXdgSurface(surface)
+	w.Toplevel = w.XdgSurface.GetToplevel()
+
+	w.XdgSurface.OnConfigure = w.configure
+	w.Toplevel.OnConfigure = func(ev ToplevelConfigureEvent) {
+		w.pending.Width = ev.Width
+		w.pending.Height = ev.Height
+		w.pending.States, _ = wire.DecodeArrayOf[ToplevelState](ev.States)
+	}
+	w.Toplevel.OnConfigureBounds = func(ev ToplevelConfigureBoundsEvent) {
+		w.pending.BoundsWidth = ev.Width
+		w.pending.BoundsHeight = ev.Height
+	}
+	w.Toplevel.OnWmCapabilities = func(ev ToplevelWmCapabilitiesEvent) {
+		w.pending.Capabilities, _ = wire.DecodeArrayOf[ToplevelWmCapabilities](ev.Capabilities)
+	}
+	w.Toplevel.OnClose = func(ToplevelCloseEvent) {
+		if w.OnClose != nil {
+			w.OnClose()
+		}
+	}
+
+	return &w
+}
+
+func (w *Window) configure(ev SurfaceConfigureEvent) {
+	w.XdgSurface.AckConfigure(ev.Serial)
+
+	config := w.pending
+	config.Serial = ev.Serial
+	w.pending.States = nil
+
+	if w.OnConfigure != nil {
+		w.OnConfigure(config)
+	}
+}
+
+// Destroy destroys the xdg_toplevel and the xdg_surface, in that
+// order, as the protocol requires. The wl_surface is left alone.
+func (w *Window) Destroy() {
+	w.Toplevel.Destroy()
+	w.XdgSurface.Destroy()
+}
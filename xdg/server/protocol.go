@@ -1,4 +1,30 @@
-// Code generated by wlgen. DO NOT EDIT.
+// Code generated by wlgen from the xdg_shell protocol. DO NOT EDIT.
+
+// Copyright © 2008-2013 Kristian Høgsberg
+// Copyright © 2013      Rafael Antognolli
+// Copyright © 2013      Jasper St. Pierre
+// Copyright © 2010-2013 Intel Corporation
+// Copyright © 2015-2017 Samsung Electronics Co., Ltd
+// Copyright © 2015-2017 Red Hat Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
 
 package xdg
 
@@ -8,6 +34,20 @@ import (
 	"fmt"
 )
 
+// ProtocolName is the name of the protocol that this file was
+// generated from.
+const ProtocolName = "xdg_shell"
+
+// Interfaces lists the interfaces defined by the xdg_shell
+// protocol along with the highest version of each that is supported.
+var Interfaces = []wire.InterfaceInfo{
+	{Name: WmBaseInterface, Version: WmBaseVersion},
+	{Name: PositionerInterface, Version: PositionerVersion},
+	{Name: SurfaceInterface, Version: SurfaceVersion},
+	{Name: ToplevelInterface, Version: ToplevelVersion},
+	{Name: PopupInterface, Version: PopupVersion},
+}
+
 const (
 	WmBaseInterface = "xdg_wm_base"
 	WmBaseVersion   = 5
@@ -49,40 +89,86 @@ type WmBaseListener interface {
 	Pong(serial uint32)
 }
 
+// WmBaseDestroyRequest holds the arguments of a xdg_wm_base.destroy
+// request.
+type WmBaseDestroyRequest struct {
+}
+
+// WmBaseCreatePositionerRequest holds the arguments of a xdg_wm_base.create_positioner
+// request.
+type WmBaseCreatePositionerRequest struct {
+	Id *Positioner
+}
+
+// WmBaseGetXdgSurfaceRequest holds the arguments of a xdg_wm_base.get_xdg_surface
+// request.
+type WmBaseGetXdgSurfaceRequest struct {
+	Id      *Surface
+	Surface *wl.Surface
+}
+
+// WmBasePongRequest holds the arguments of a xdg_wm_base.pong
+// request.
+type WmBasePongRequest struct {
+	Serial uint32
+}
+
 // The xdg_wm_base interface is exposed as a global object enabling clients
 // to turn their wl_surfaces into windows in a desktop environment. It
 // defines the basic functionality needed for clients and the compositor to
 // create windows that can be dragged, resized, maximized, etc, as well as
 // creating transient windows such as popup menus.
 type WmBase struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener WmBaseListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(WmBaseDestroyRequest)
+
+	// OnCreatePositioner, if not nil, is called with the arguments of
+	// each incoming create_positioner request before Listener is.
+	OnCreatePositioner func(WmBaseCreatePositionerRequest)
+
+	// OnGetXdgSurface, if not nil, is called with the arguments of
+	// each incoming get_xdg_surface request before Listener is.
+	OnGetXdgSurface func(WmBaseGetXdgSurfaceRequest)
 
-	state wire.State
-	id    uint32
+	// OnPong, if not nil, is called with the arguments of
+	// each incoming pong request before Listener is.
+	OnPong func(WmBasePongRequest)
 }
 
+var (
+	_ wire.Object      = (*WmBase)(nil)
+	_ wire.DebugObject = (*WmBase)(nil)
+)
+
 // NewWmBase returns a newly instantiated WmBase. It is
 // primarily intended for use by generated code.
 func NewWmBase(state wire.State) *WmBase {
-	return &WmBase{state: state}
+	return &WmBase{Proxy: wire.NewProxy(state)}
 }
 
-func BindWmBase(state wire.State, id wire.NewID) *WmBase {
+// BindWmBase creates a new WmBase for the new_id sent by
+// a client in a request to bind a global. If the new_id is for a
+// different interface or for a version that is not supported,
+// nothing is created and a wire.BindError is returned.
+func BindWmBase(state wire.State, id wire.NewID) (*WmBase, error) {
+	if err := id.Check(WmBaseInterface, WmBaseVersion); err != nil {
+		return nil, err
+	}
+
 	obj := NewWmBase(state)
 	obj.SetID(id.ID)
+	obj.SetVersion(id.Version)
 	state.Add(obj)
-	return obj
-}
-
-func (obj *WmBase) State() wire.State {
-	return obj.state
+	return obj, nil
 }
 
 func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
@@ -92,6 +178,9 @@ func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(WmBaseDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -100,15 +189,20 @@ func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		id := NewPositioner(obj.state)
+		id := NewPositioner(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnCreatePositioner != nil {
+			obj.OnCreatePositioner(WmBaseCreatePositionerRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -119,19 +213,23 @@ func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 2:
 
-		id := NewSurface(obj.state)
+		id := NewSurface(obj.State())
 		id.SetID(msg.ReadUint())
+		id.SetVersion(obj.Version())
 
-		obj.state.Add(id)
-
-		surface, _ := obj.state.Get(msg.ReadUint()).(*wl.Surface)
-
-		obj.state.Add(surface)
+		surface, _ := obj.State().Get(msg.ReadUint()).(*wl.Surface)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetXdgSurface != nil {
+			obj.OnGetXdgSurface(WmBaseGetXdgSurfaceRequest{
+				Id:      id,
+				Surface: surface,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -149,6 +247,11 @@ func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnPong != nil {
+			obj.OnPong(WmBasePongRequest{
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -165,22 +268,8 @@ func (obj *WmBase) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *WmBase) ID() uint32 {
-	return obj.id
-}
-
-func (obj *WmBase) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *WmBase) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *WmBase) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_wm_base", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_wm_base", obj.ID())
 }
 
 func (obj *WmBase) MethodName(op uint16) string {
@@ -205,7 +294,19 @@ func (obj *WmBase) Interface() string {
 	return WmBaseInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// WmBaseVersion, the same as MaxVersion.
 func (obj *WmBase) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return WmBaseVersion
+}
+
+// MaxVersion returns WmBaseVersion, the highest version of
+// xdg_wm_base that is supported.
+func (obj *WmBase) MaxVersion() uint32 {
 	return WmBaseVersion
 }
 
@@ -229,7 +330,7 @@ func (obj *WmBase) Ping(serial uint32) {
 
 	builder.Method = "ping"
 	builder.Args = []any{serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -258,31 +359,28 @@ const (
 	WmBaseErrorUnresponsive WmBaseError = 6
 )
 
-func (enum WmBaseError) String() string {
-	switch enum {
-	case 0:
-		return "WmBaseErrorRole"
-
-	case 1:
-		return "WmBaseErrorDefunctSurfaces"
-
-	case 2:
-		return "WmBaseErrorNotTheTopmostPopup"
-
-	case 3:
-		return "WmBaseErrorInvalidPopupParent"
-
-	case 4:
-		return "WmBaseErrorInvalidSurfaceState"
-
-	case 5:
-		return "WmBaseErrorInvalidPositioner"
+// WmBaseErrorNames maps the values of WmBaseError to their names.
+var WmBaseErrorNames = map[WmBaseError]string{
+	WmBaseErrorRole:                "WmBaseErrorRole",
+	WmBaseErrorDefunctSurfaces:     "WmBaseErrorDefunctSurfaces",
+	WmBaseErrorNotTheTopmostPopup:  "WmBaseErrorNotTheTopmostPopup",
+	WmBaseErrorInvalidPopupParent:  "WmBaseErrorInvalidPopupParent",
+	WmBaseErrorInvalidSurfaceState: "WmBaseErrorInvalidSurfaceState",
+	WmBaseErrorInvalidPositioner:   "WmBaseErrorInvalidPositioner",
+	WmBaseErrorUnresponsive:        "WmBaseErrorUnresponsive",
+}
 
-	case 6:
-		return "WmBaseErrorUnresponsive"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum WmBaseError) String() string {
+	return wire.EnumString(enum, WmBaseErrorNames)
+}
 
-	return "<invalid WmBaseError>"
+// Since returns the version of xdg_wm_base that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum WmBaseError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -384,6 +482,70 @@ type PositionerListener interface {
 	SetParentConfigure(serial uint32)
 }
 
+// PositionerDestroyRequest holds the arguments of a xdg_positioner.destroy
+// request.
+type PositionerDestroyRequest struct {
+}
+
+// PositionerSetSizeRequest holds the arguments of a xdg_positioner.set_size
+// request.
+type PositionerSetSizeRequest struct {
+	Width  int32
+	Height int32
+}
+
+// PositionerSetAnchorRectRequest holds the arguments of a xdg_positioner.set_anchor_rect
+// request.
+type PositionerSetAnchorRectRequest struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
+// PositionerSetAnchorRequest holds the arguments of a xdg_positioner.set_anchor
+// request.
+type PositionerSetAnchorRequest struct {
+	Anchor PositionerAnchor
+}
+
+// PositionerSetGravityRequest holds the arguments of a xdg_positioner.set_gravity
+// request.
+type PositionerSetGravityRequest struct {
+	Gravity PositionerGravity
+}
+
+// PositionerSetConstraintAdjustmentRequest holds the arguments of a xdg_positioner.set_constraint_adjustment
+// request.
+type PositionerSetConstraintAdjustmentRequest struct {
+	ConstraintAdjustment uint32
+}
+
+// PositionerSetOffsetRequest holds the arguments of a xdg_positioner.set_offset
+// request.
+type PositionerSetOffsetRequest struct {
+	X int32
+	Y int32
+}
+
+// PositionerSetReactiveRequest holds the arguments of a xdg_positioner.set_reactive
+// request.
+type PositionerSetReactiveRequest struct {
+}
+
+// PositionerSetParentSizeRequest holds the arguments of a xdg_positioner.set_parent_size
+// request.
+type PositionerSetParentSizeRequest struct {
+	ParentWidth  int32
+	ParentHeight int32
+}
+
+// PositionerSetParentConfigureRequest holds the arguments of a xdg_positioner.set_parent_configure
+// request.
+type PositionerSetParentConfigureRequest struct {
+	Serial uint32
+}
+
 // The xdg_positioner provides a collection of rules for the placement of a
 // child surface relative to a parent surface. Rules can be defined to ensure
 // the child surface remains within the visible area's borders, and to
@@ -404,27 +566,64 @@ type PositionerListener interface {
 // set_anchor_rect. Passing an incomplete xdg_positioner object when
 // positioning a surface raises an invalid_positioner error.
 type Positioner struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener PositionerListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(PositionerDestroyRequest)
+
+	// OnSetSize, if not nil, is called with the arguments of
+	// each incoming set_size request before Listener is.
+	OnSetSize func(PositionerSetSizeRequest)
+
+	// OnSetAnchorRect, if not nil, is called with the arguments of
+	// each incoming set_anchor_rect request before Listener is.
+	OnSetAnchorRect func(PositionerSetAnchorRectRequest)
 
-	state wire.State
-	id    uint32
+	// OnSetAnchor, if not nil, is called with the arguments of
+	// each incoming set_anchor request before Listener is.
+	OnSetAnchor func(PositionerSetAnchorRequest)
+
+	// OnSetGravity, if not nil, is called with the arguments of
+	// each incoming set_gravity request before Listener is.
+	OnSetGravity func(PositionerSetGravityRequest)
+
+	// OnSetConstraintAdjustment, if not nil, is called with the arguments of
+	// each incoming set_constraint_adjustment request before Listener is.
+	OnSetConstraintAdjustment func(PositionerSetConstraintAdjustmentRequest)
+
+	// OnSetOffset, if not nil, is called with the arguments of
+	// each incoming set_offset request before Listener is.
+	OnSetOffset func(PositionerSetOffsetRequest)
+
+	// OnSetReactive, if not nil, is called with the arguments of
+	// each incoming set_reactive request before Listener is.
+	OnSetReactive func(PositionerSetReactiveRequest)
+
+	// OnSetParentSize, if not nil, is called with the arguments of
+	// each incoming set_parent_size request before Listener is.
+	OnSetParentSize func(PositionerSetParentSizeRequest)
+
+	// OnSetParentConfigure, if not nil, is called with the arguments of
+	// each incoming set_parent_configure request before Listener is.
+	OnSetParentConfigure func(PositionerSetParentConfigureRequest)
 }
 
+var (
+	_ wire.Object      = (*Positioner)(nil)
+	_ wire.DebugObject = (*Positioner)(nil)
+)
+
 // NewPositioner returns a newly instantiated Positioner. It is
 // primarily intended for use by generated code.
 func NewPositioner(state wire.State) *Positioner {
-	return &Positioner{state: state}
-}
-
-func (obj *Positioner) State() wire.State {
-	return obj.state
+	return &Positioner{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
@@ -434,6 +633,9 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(PositionerDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -450,6 +652,12 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetSize != nil {
+			obj.OnSetSize(PositionerSetSizeRequest{
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -473,6 +681,14 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetAnchorRect != nil {
+			obj.OnSetAnchorRect(PositionerSetAnchorRectRequest{
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -492,6 +708,11 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetAnchor != nil {
+			obj.OnSetAnchor(PositionerSetAnchorRequest{
+				Anchor: anchor,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -508,6 +729,11 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetGravity != nil {
+			obj.OnSetGravity(PositionerSetGravityRequest{
+				Gravity: gravity,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -524,6 +750,11 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetConstraintAdjustment != nil {
+			obj.OnSetConstraintAdjustment(PositionerSetConstraintAdjustmentRequest{
+				ConstraintAdjustment: constraintAdjustment,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -542,6 +773,12 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetOffset != nil {
+			obj.OnSetOffset(PositionerSetOffsetRequest{
+				X: x,
+				Y: y,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -556,6 +793,9 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetReactive != nil {
+			obj.OnSetReactive(PositionerSetReactiveRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -572,6 +812,12 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetParentSize != nil {
+			obj.OnSetParentSize(PositionerSetParentSizeRequest{
+				ParentWidth:  parentWidth,
+				ParentHeight: parentHeight,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -589,6 +835,11 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetParentConfigure != nil {
+			obj.OnSetParentConfigure(PositionerSetParentConfigureRequest{
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -605,22 +856,8 @@ func (obj *Positioner) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Positioner) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Positioner) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Positioner) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Positioner) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_positioner", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_positioner", obj.ID())
 }
 
 func (obj *Positioner) MethodName(op uint16) string {
@@ -663,7 +900,19 @@ func (obj *Positioner) Interface() string {
 	return PositionerInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// PositionerVersion, the same as MaxVersion.
 func (obj *Positioner) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return PositionerVersion
+}
+
+// MaxVersion returns PositionerVersion, the highest version of
+// xdg_positioner that is supported.
+func (obj *Positioner) MaxVersion() uint32 {
 	return PositionerVersion
 }
 
@@ -674,13 +923,22 @@ const (
 	PositionerErrorInvalidInput PositionerError = 0
 )
 
+// PositionerErrorNames maps the values of PositionerError to their names.
+var PositionerErrorNames = map[PositionerError]string{
+	PositionerErrorInvalidInput: "PositionerErrorInvalidInput",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PositionerError) String() string {
-	switch enum {
-	case 0:
-		return "PositionerErrorInvalidInput"
-	}
+	return wire.EnumString(enum, PositionerErrorNames)
+}
 
-	return "<invalid PositionerError>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerError) Since() uint32 {
+	return 1
 }
 
 type PositionerAnchor int64
@@ -705,37 +963,30 @@ const (
 	PositionerAnchorBottomRight PositionerAnchor = 8
 )
 
+// PositionerAnchorNames maps the values of PositionerAnchor to their names.
+var PositionerAnchorNames = map[PositionerAnchor]string{
+	PositionerAnchorNone:        "PositionerAnchorNone",
+	PositionerAnchorTop:         "PositionerAnchorTop",
+	PositionerAnchorBottom:      "PositionerAnchorBottom",
+	PositionerAnchorLeft:        "PositionerAnchorLeft",
+	PositionerAnchorRight:       "PositionerAnchorRight",
+	PositionerAnchorTopLeft:     "PositionerAnchorTopLeft",
+	PositionerAnchorBottomLeft:  "PositionerAnchorBottomLeft",
+	PositionerAnchorTopRight:    "PositionerAnchorTopRight",
+	PositionerAnchorBottomRight: "PositionerAnchorBottomRight",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PositionerAnchor) String() string {
-	switch enum {
-	case 0:
-		return "PositionerAnchorNone"
-
-	case 1:
-		return "PositionerAnchorTop"
-
-	case 2:
-		return "PositionerAnchorBottom"
-
-	case 3:
-		return "PositionerAnchorLeft"
-
-	case 4:
-		return "PositionerAnchorRight"
-
-	case 5:
-		return "PositionerAnchorTopLeft"
-
-	case 6:
-		return "PositionerAnchorBottomLeft"
-
-	case 7:
-		return "PositionerAnchorTopRight"
-
-	case 8:
-		return "PositionerAnchorBottomRight"
-	}
+	return wire.EnumString(enum, PositionerAnchorNames)
+}
 
-	return "<invalid PositionerAnchor>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerAnchor) Since() uint32 {
+	return 1
 }
 
 type PositionerGravity int64
@@ -760,37 +1011,30 @@ const (
 	PositionerGravityBottomRight PositionerGravity = 8
 )
 
+// PositionerGravityNames maps the values of PositionerGravity to their names.
+var PositionerGravityNames = map[PositionerGravity]string{
+	PositionerGravityNone:        "PositionerGravityNone",
+	PositionerGravityTop:         "PositionerGravityTop",
+	PositionerGravityBottom:      "PositionerGravityBottom",
+	PositionerGravityLeft:        "PositionerGravityLeft",
+	PositionerGravityRight:       "PositionerGravityRight",
+	PositionerGravityTopLeft:     "PositionerGravityTopLeft",
+	PositionerGravityBottomLeft:  "PositionerGravityBottomLeft",
+	PositionerGravityTopRight:    "PositionerGravityTopRight",
+	PositionerGravityBottomRight: "PositionerGravityBottomRight",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PositionerGravity) String() string {
-	switch enum {
-	case 0:
-		return "PositionerGravityNone"
-
-	case 1:
-		return "PositionerGravityTop"
-
-	case 2:
-		return "PositionerGravityBottom"
-
-	case 3:
-		return "PositionerGravityLeft"
-
-	case 4:
-		return "PositionerGravityRight"
-
-	case 5:
-		return "PositionerGravityTopLeft"
-
-	case 6:
-		return "PositionerGravityBottomLeft"
-
-	case 7:
-		return "PositionerGravityTopRight"
-
-	case 8:
-		return "PositionerGravityBottomRight"
-	}
+	return wire.EnumString(enum, PositionerGravityNames)
+}
 
-	return "<invalid PositionerGravity>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerGravity) Since() uint32 {
+	return 1
 }
 
 // The constraint adjustment value define ways the compositor will adjust
@@ -822,31 +1066,28 @@ const (
 	PositionerConstraintAdjustmentResizeY PositionerConstraintAdjustment = 32
 )
 
-func (enum PositionerConstraintAdjustment) String() string {
-	switch enum {
-	case 0:
-		return "PositionerConstraintAdjustmentNone"
-
-	case 1:
-		return "PositionerConstraintAdjustmentSlideX"
-
-	case 2:
-		return "PositionerConstraintAdjustmentSlideY"
-
-	case 4:
-		return "PositionerConstraintAdjustmentFlipX"
-
-	case 8:
-		return "PositionerConstraintAdjustmentFlipY"
-
-	case 16:
-		return "PositionerConstraintAdjustmentResizeX"
+// PositionerConstraintAdjustmentNames maps the values of PositionerConstraintAdjustment to their names.
+var PositionerConstraintAdjustmentNames = map[PositionerConstraintAdjustment]string{
+	PositionerConstraintAdjustmentNone:    "PositionerConstraintAdjustmentNone",
+	PositionerConstraintAdjustmentSlideX:  "PositionerConstraintAdjustmentSlideX",
+	PositionerConstraintAdjustmentSlideY:  "PositionerConstraintAdjustmentSlideY",
+	PositionerConstraintAdjustmentFlipX:   "PositionerConstraintAdjustmentFlipX",
+	PositionerConstraintAdjustmentFlipY:   "PositionerConstraintAdjustmentFlipY",
+	PositionerConstraintAdjustmentResizeX: "PositionerConstraintAdjustmentResizeX",
+	PositionerConstraintAdjustmentResizeY: "PositionerConstraintAdjustmentResizeY",
+}
 
-	case 32:
-		return "PositionerConstraintAdjustmentResizeY"
-	}
+// String returns the names of the flags set in enum, joined
+// with "|".
+func (enum PositionerConstraintAdjustment) String() string {
+	return wire.FlagString(enum, PositionerConstraintAdjustmentNames)
+}
 
-	return "<invalid PositionerConstraintAdjustment>"
+// Since returns the version of xdg_positioner that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PositionerConstraintAdjustment) Since() uint32 {
+	return 1
 }
 
 const (
@@ -945,6 +1186,40 @@ type SurfaceListener interface {
 	AckConfigure(serial uint32)
 }
 
+// SurfaceDestroyRequest holds the arguments of a xdg_surface.destroy
+// request.
+type SurfaceDestroyRequest struct {
+}
+
+// SurfaceGetToplevelRequest holds the arguments of a xdg_surface.get_toplevel
+// request.
+type SurfaceGetToplevelRequest struct {
+	Id *Toplevel
+}
+
+// SurfaceGetPopupRequest holds the arguments of a xdg_surface.get_popup
+// request.
+type SurfaceGetPopupRequest struct {
+	Id         *Popup
+	Parent     *Surface
+	Positioner *Positioner
+}
+
+// SurfaceSetWindowGeometryRequest holds the arguments of a xdg_surface.set_window_geometry
+// request.
+type SurfaceSetWindowGeometryRequest struct {
+	X      int32
+	Y      int32
+	Width  int32
+	Height int32
+}
+
+// SurfaceAckConfigureRequest holds the arguments of a xdg_surface.ack_configure
+// request.
+type SurfaceAckConfigureRequest struct {
+	Serial uint32
+}
+
 // An interface that may be implemented by a wl_surface, for
 // implementations that provide a desktop-style user interface.
 //
@@ -991,27 +1266,44 @@ type SurfaceListener interface {
 // has not been destroyed, i.e. the client must perform the initial commit
 // again before attaching a buffer.
 type Surface struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener SurfaceListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(SurfaceDestroyRequest)
+
+	// OnGetToplevel, if not nil, is called with the arguments of
+	// each incoming get_toplevel request before Listener is.
+	OnGetToplevel func(SurfaceGetToplevelRequest)
+
+	// OnGetPopup, if not nil, is called with the arguments of
+	// each incoming get_popup request before Listener is.
+	OnGetPopup func(SurfaceGetPopupRequest)
+
+	// OnSetWindowGeometry, if not nil, is called with the arguments of
+	// each incoming set_window_geometry request before Listener is.
+	OnSetWindowGeometry func(SurfaceSetWindowGeometryRequest)
 
-	state wire.State
-	id    uint32
+	// OnAckConfigure, if not nil, is called with the arguments of
+	// each incoming ack_configure request before Listener is.
+	OnAckConfigure func(SurfaceAckConfigureRequest)
 }
 
+var (
+	_ wire.Object      = (*Surface)(nil)
+	_ wire.DebugObject = (*Surface)(nil)
+)
+
 // NewSurface returns a newly instantiated Surface. It is
 // primarily intended for use by generated code.
 func NewSurface(state wire.State) *Surface {
-	return &Surface{state: state}
-}
-
-func (obj *Surface) State() wire.State {
-	return obj.state
+	return &Surface{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
@@ -1021,6 +1313,9 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(SurfaceDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1029,15 +1324,20 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		id := NewToplevel(obj.state)
+		id := NewToplevel(obj.State())
 		id.SetID(msg.ReadUint())
-
-		obj.state.Add(id)
+		id.SetVersion(obj.Version())
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetToplevel != nil {
+			obj.OnGetToplevel(SurfaceGetToplevelRequest{
+				Id: id,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1048,23 +1348,26 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 2:
 
-		id := NewPopup(obj.state)
+		id := NewPopup(obj.State())
 		id.SetID(msg.ReadUint())
+		id.SetVersion(obj.Version())
 
-		obj.state.Add(id)
-
-		parent, _ := obj.state.Get(msg.ReadUint()).(*Surface)
-
-		obj.state.Add(parent)
+		parent, _ := obj.State().Get(msg.ReadUint()).(*Surface)
 
-		positioner, _ := obj.state.Get(msg.ReadUint()).(*Positioner)
-
-		obj.state.Add(positioner)
+		positioner, _ := obj.State().Get(msg.ReadUint()).(*Positioner)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
+		obj.State().Add(id)
 
+		if obj.OnGetPopup != nil {
+			obj.OnGetPopup(SurfaceGetPopupRequest{
+				Id:         id,
+				Parent:     parent,
+				Positioner: positioner,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1089,6 +1392,14 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetWindowGeometry != nil {
+			obj.OnSetWindowGeometry(SurfaceSetWindowGeometryRequest{
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1108,6 +1419,11 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnAckConfigure != nil {
+			obj.OnAckConfigure(SurfaceAckConfigureRequest{
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1124,22 +1440,8 @@ func (obj *Surface) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Surface) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Surface) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Surface) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Surface) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_surface", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_surface", obj.ID())
 }
 
 func (obj *Surface) MethodName(op uint16) string {
@@ -1167,7 +1469,19 @@ func (obj *Surface) Interface() string {
 	return SurfaceInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// SurfaceVersion, the same as MaxVersion.
 func (obj *Surface) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return SurfaceVersion
+}
+
+// MaxVersion returns SurfaceVersion, the highest version of
+// xdg_surface that is supported.
+func (obj *Surface) MaxVersion() uint32 {
 	return SurfaceVersion
 }
 
@@ -1194,7 +1508,7 @@ func (obj *Surface) Configure(serial uint32) {
 
 	builder.Method = "configure"
 	builder.Args = []any{serial}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1220,28 +1534,27 @@ const (
 	SurfaceErrorDefunctRoleObject SurfaceError = 6
 )
 
-func (enum SurfaceError) String() string {
-	switch enum {
-	case 1:
-		return "SurfaceErrorNotConstructed"
-
-	case 2:
-		return "SurfaceErrorAlreadyConstructed"
-
-	case 3:
-		return "SurfaceErrorUnconfiguredBuffer"
-
-	case 4:
-		return "SurfaceErrorInvalidSerial"
-
-	case 5:
-		return "SurfaceErrorInvalidSize"
+// SurfaceErrorNames maps the values of SurfaceError to their names.
+var SurfaceErrorNames = map[SurfaceError]string{
+	SurfaceErrorNotConstructed:     "SurfaceErrorNotConstructed",
+	SurfaceErrorAlreadyConstructed: "SurfaceErrorAlreadyConstructed",
+	SurfaceErrorUnconfiguredBuffer: "SurfaceErrorUnconfiguredBuffer",
+	SurfaceErrorInvalidSerial:      "SurfaceErrorInvalidSerial",
+	SurfaceErrorInvalidSize:        "SurfaceErrorInvalidSize",
+	SurfaceErrorDefunctRoleObject:  "SurfaceErrorDefunctRoleObject",
+}
 
-	case 6:
-		return "SurfaceErrorDefunctRoleObject"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum SurfaceError) String() string {
+	return wire.EnumString(enum, SurfaceErrorNames)
+}
 
-	return "<invalid SurfaceError>"
+// Since returns the version of xdg_surface that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum SurfaceError) Since() uint32 {
+	return 1
 }
 
 const (
@@ -1548,6 +1861,93 @@ type ToplevelListener interface {
 	SetMinimized()
 }
 
+// ToplevelDestroyRequest holds the arguments of a xdg_toplevel.destroy
+// request.
+type ToplevelDestroyRequest struct {
+}
+
+// ToplevelSetParentRequest holds the arguments of a xdg_toplevel.set_parent
+// request.
+type ToplevelSetParentRequest struct {
+	Parent *Toplevel
+}
+
+// ToplevelSetTitleRequest holds the arguments of a xdg_toplevel.set_title
+// request.
+type ToplevelSetTitleRequest struct {
+	Title string
+}
+
+// ToplevelSetAppIdRequest holds the arguments of a xdg_toplevel.set_app_id
+// request.
+type ToplevelSetAppIdRequest struct {
+	AppId string
+}
+
+// ToplevelShowWindowMenuRequest holds the arguments of a xdg_toplevel.show_window_menu
+// request.
+type ToplevelShowWindowMenuRequest struct {
+	Seat   *wl.Seat
+	Serial uint32
+	X      int32
+	Y      int32
+}
+
+// ToplevelMoveRequest holds the arguments of a xdg_toplevel.move
+// request.
+type ToplevelMoveRequest struct {
+	Seat   *wl.Seat
+	Serial uint32
+}
+
+// ToplevelResizeRequest holds the arguments of a xdg_toplevel.resize
+// request.
+type ToplevelResizeRequest struct {
+	Seat   *wl.Seat
+	Serial uint32
+	Edges  ToplevelResizeEdge
+}
+
+// ToplevelSetMaxSizeRequest holds the arguments of a xdg_toplevel.set_max_size
+// request.
+type ToplevelSetMaxSizeRequest struct {
+	Width  int32
+	Height int32
+}
+
+// ToplevelSetMinSizeRequest holds the arguments of a xdg_toplevel.set_min_size
+// request.
+type ToplevelSetMinSizeRequest struct {
+	Width  int32
+	Height int32
+}
+
+// ToplevelSetMaximizedRequest holds the arguments of a xdg_toplevel.set_maximized
+// request.
+type ToplevelSetMaximizedRequest struct {
+}
+
+// ToplevelUnsetMaximizedRequest holds the arguments of a xdg_toplevel.unset_maximized
+// request.
+type ToplevelUnsetMaximizedRequest struct {
+}
+
+// ToplevelSetFullscreenRequest holds the arguments of a xdg_toplevel.set_fullscreen
+// request.
+type ToplevelSetFullscreenRequest struct {
+	Output *wl.Output
+}
+
+// ToplevelUnsetFullscreenRequest holds the arguments of a xdg_toplevel.unset_fullscreen
+// request.
+type ToplevelUnsetFullscreenRequest struct {
+}
+
+// ToplevelSetMinimizedRequest holds the arguments of a xdg_toplevel.set_minimized
+// request.
+type ToplevelSetMinimizedRequest struct {
+}
+
 // This interface defines an xdg_surface role which allows a surface to,
 // among other things, set window-like properties such as maximize,
 // fullscreen, and minimize, set application-specific metadata like title and
@@ -1566,27 +1966,80 @@ type ToplevelListener interface {
 //
 // Attaching a null buffer to a toplevel unmaps the surface.
 type Toplevel struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener ToplevelListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(ToplevelDestroyRequest)
+
+	// OnSetParent, if not nil, is called with the arguments of
+	// each incoming set_parent request before Listener is.
+	OnSetParent func(ToplevelSetParentRequest)
+
+	// OnSetTitle, if not nil, is called with the arguments of
+	// each incoming set_title request before Listener is.
+	OnSetTitle func(ToplevelSetTitleRequest)
+
+	// OnSetAppId, if not nil, is called with the arguments of
+	// each incoming set_app_id request before Listener is.
+	OnSetAppId func(ToplevelSetAppIdRequest)
+
+	// OnShowWindowMenu, if not nil, is called with the arguments of
+	// each incoming show_window_menu request before Listener is.
+	OnShowWindowMenu func(ToplevelShowWindowMenuRequest)
+
+	// OnMove, if not nil, is called with the arguments of
+	// each incoming move request before Listener is.
+	OnMove func(ToplevelMoveRequest)
+
+	// OnResize, if not nil, is called with the arguments of
+	// each incoming resize request before Listener is.
+	OnResize func(ToplevelResizeRequest)
+
+	// OnSetMaxSize, if not nil, is called with the arguments of
+	// each incoming set_max_size request before Listener is.
+	OnSetMaxSize func(ToplevelSetMaxSizeRequest)
+
+	// OnSetMinSize, if not nil, is called with the arguments of
+	// each incoming set_min_size request before Listener is.
+	OnSetMinSize func(ToplevelSetMinSizeRequest)
 
-	state wire.State
-	id    uint32
+	// OnSetMaximized, if not nil, is called with the arguments of
+	// each incoming set_maximized request before Listener is.
+	OnSetMaximized func(ToplevelSetMaximizedRequest)
+
+	// OnUnsetMaximized, if not nil, is called with the arguments of
+	// each incoming unset_maximized request before Listener is.
+	OnUnsetMaximized func(ToplevelUnsetMaximizedRequest)
+
+	// OnSetFullscreen, if not nil, is called with the arguments of
+	// each incoming set_fullscreen request before Listener is.
+	OnSetFullscreen func(ToplevelSetFullscreenRequest)
+
+	// OnUnsetFullscreen, if not nil, is called with the arguments of
+	// each incoming unset_fullscreen request before Listener is.
+	OnUnsetFullscreen func(ToplevelUnsetFullscreenRequest)
+
+	// OnSetMinimized, if not nil, is called with the arguments of
+	// each incoming set_minimized request before Listener is.
+	OnSetMinimized func(ToplevelSetMinimizedRequest)
 }
 
+var (
+	_ wire.Object      = (*Toplevel)(nil)
+	_ wire.DebugObject = (*Toplevel)(nil)
+)
+
 // NewToplevel returns a newly instantiated Toplevel. It is
 // primarily intended for use by generated code.
 func NewToplevel(state wire.State) *Toplevel {
-	return &Toplevel{state: state}
-}
-
-func (obj *Toplevel) State() wire.State {
-	return obj.state
+	return &Toplevel{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
@@ -1596,6 +2049,9 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(ToplevelDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1604,14 +2060,17 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		parent, _ := obj.state.Get(msg.ReadUint()).(*Toplevel)
-
-		obj.state.Add(parent)
+		parent, _ := obj.State().Get(msg.ReadUint()).(*Toplevel)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnSetParent != nil {
+			obj.OnSetParent(ToplevelSetParentRequest{
+				Parent: parent,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1628,6 +2087,11 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetTitle != nil {
+			obj.OnSetTitle(ToplevelSetTitleRequest{
+				Title: title,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1644,6 +2108,11 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetAppId != nil {
+			obj.OnSetAppId(ToplevelSetAppIdRequest{
+				AppId: appId,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1654,9 +2123,7 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 4:
 
-		seat, _ := obj.state.Get(msg.ReadUint()).(*wl.Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*wl.Seat)
 
 		serial := msg.ReadUint()
 
@@ -1668,6 +2135,14 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnShowWindowMenu != nil {
+			obj.OnShowWindowMenu(ToplevelShowWindowMenuRequest{
+				Seat:   seat,
+				Serial: serial,
+				X:      x,
+				Y:      y,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1681,9 +2156,7 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 5:
 
-		seat, _ := obj.state.Get(msg.ReadUint()).(*wl.Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*wl.Seat)
 
 		serial := msg.ReadUint()
 
@@ -1691,6 +2164,12 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnMove != nil {
+			obj.OnMove(ToplevelMoveRequest{
+				Seat:   seat,
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1702,9 +2181,7 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 6:
 
-		seat, _ := obj.state.Get(msg.ReadUint()).(*wl.Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*wl.Seat)
 
 		serial := msg.ReadUint()
 
@@ -1714,6 +2191,13 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnResize != nil {
+			obj.OnResize(ToplevelResizeRequest{
+				Seat:   seat,
+				Serial: serial,
+				Edges:  edges,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1734,6 +2218,12 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetMaxSize != nil {
+			obj.OnSetMaxSize(ToplevelSetMaxSizeRequest{
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1753,6 +2243,12 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetMinSize != nil {
+			obj.OnSetMinSize(ToplevelSetMinSizeRequest{
+				Width:  width,
+				Height: height,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1767,6 +2263,9 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetMaximized != nil {
+			obj.OnSetMaximized(ToplevelSetMaximizedRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1778,6 +2277,9 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnUnsetMaximized != nil {
+			obj.OnUnsetMaximized(ToplevelUnsetMaximizedRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1786,14 +2288,17 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 11:
 
-		output, _ := obj.state.Get(msg.ReadUint()).(*wl.Output)
-
-		obj.state.Add(output)
+		output, _ := obj.State().Get(msg.ReadUint()).(*wl.Output)
 
 		if err := msg.Err(); err != nil {
 			return err
 		}
 
+		if obj.OnSetFullscreen != nil {
+			obj.OnSetFullscreen(ToplevelSetFullscreenRequest{
+				Output: output,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1807,6 +2312,9 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnUnsetFullscreen != nil {
+			obj.OnUnsetFullscreen(ToplevelUnsetFullscreenRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1818,6 +2326,9 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnSetMinimized != nil {
+			obj.OnSetMinimized(ToplevelSetMinimizedRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -1832,22 +2343,8 @@ func (obj *Toplevel) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Toplevel) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Toplevel) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Toplevel) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Toplevel) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_toplevel", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_toplevel", obj.ID())
 }
 
 func (obj *Toplevel) MethodName(op uint16) string {
@@ -1902,7 +2399,19 @@ func (obj *Toplevel) Interface() string {
 	return ToplevelInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// ToplevelVersion, the same as MaxVersion.
 func (obj *Toplevel) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return ToplevelVersion
+}
+
+// MaxVersion returns ToplevelVersion, the highest version of
+// xdg_toplevel that is supported.
+func (obj *Toplevel) MaxVersion() uint32 {
 	return ToplevelVersion
 }
 
@@ -1932,9 +2441,11 @@ func (obj *Toplevel) Configure(width int32, height int32, states []byte) {
 	builder.WriteInt(height)
 	builder.WriteArray(states)
 
+	builder.Fail(wire.CheckMessageSize(ToplevelInterface, "configure", builder))
+
 	builder.Method = "configure"
 	builder.Args = []any{width, height, states}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1951,7 +2462,7 @@ func (obj *Toplevel) Close() {
 
 	builder.Method = "close"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -1978,7 +2489,7 @@ func (obj *Toplevel) ConfigureBounds(width int32, height int32) {
 
 	builder.Method = "configure_bounds"
 	builder.Args = []any{width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2007,9 +2518,11 @@ func (obj *Toplevel) WmCapabilities(capabilities []byte) {
 
 	builder.WriteArray(capabilities)
 
+	builder.Fail(wire.CheckMessageSize(ToplevelInterface, "wm_capabilities", builder))
+
 	builder.Method = "wm_capabilities"
 	builder.Args = []any{capabilities}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2027,19 +2540,24 @@ const (
 	ToplevelErrorInvalidSize ToplevelError = 2
 )
 
-func (enum ToplevelError) String() string {
-	switch enum {
-	case 0:
-		return "ToplevelErrorInvalidResizeEdge"
-
-	case 1:
-		return "ToplevelErrorInvalidParent"
+// ToplevelErrorNames maps the values of ToplevelError to their names.
+var ToplevelErrorNames = map[ToplevelError]string{
+	ToplevelErrorInvalidResizeEdge: "ToplevelErrorInvalidResizeEdge",
+	ToplevelErrorInvalidParent:     "ToplevelErrorInvalidParent",
+	ToplevelErrorInvalidSize:       "ToplevelErrorInvalidSize",
+}
 
-	case 2:
-		return "ToplevelErrorInvalidSize"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ToplevelError) String() string {
+	return wire.EnumString(enum, ToplevelErrorNames)
+}
 
-	return "<invalid ToplevelError>"
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelError) Since() uint32 {
+	return 1
 }
 
 // These values are used to indicate which edge of a surface
@@ -2066,37 +2584,30 @@ const (
 	ToplevelResizeEdgeBottomRight ToplevelResizeEdge = 10
 )
 
+// ToplevelResizeEdgeNames maps the values of ToplevelResizeEdge to their names.
+var ToplevelResizeEdgeNames = map[ToplevelResizeEdge]string{
+	ToplevelResizeEdgeNone:        "ToplevelResizeEdgeNone",
+	ToplevelResizeEdgeTop:         "ToplevelResizeEdgeTop",
+	ToplevelResizeEdgeBottom:      "ToplevelResizeEdgeBottom",
+	ToplevelResizeEdgeLeft:        "ToplevelResizeEdgeLeft",
+	ToplevelResizeEdgeTopLeft:     "ToplevelResizeEdgeTopLeft",
+	ToplevelResizeEdgeBottomLeft:  "ToplevelResizeEdgeBottomLeft",
+	ToplevelResizeEdgeRight:       "ToplevelResizeEdgeRight",
+	ToplevelResizeEdgeTopRight:    "ToplevelResizeEdgeTopRight",
+	ToplevelResizeEdgeBottomRight: "ToplevelResizeEdgeBottomRight",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum ToplevelResizeEdge) String() string {
-	switch enum {
-	case 0:
-		return "ToplevelResizeEdgeNone"
-
-	case 1:
-		return "ToplevelResizeEdgeTop"
-
-	case 2:
-		return "ToplevelResizeEdgeBottom"
-
-	case 4:
-		return "ToplevelResizeEdgeLeft"
-
-	case 5:
-		return "ToplevelResizeEdgeTopLeft"
-
-	case 6:
-		return "ToplevelResizeEdgeBottomLeft"
-
-	case 8:
-		return "ToplevelResizeEdgeRight"
-
-	case 9:
-		return "ToplevelResizeEdgeTopRight"
-
-	case 10:
-		return "ToplevelResizeEdgeBottomRight"
-	}
+	return wire.EnumString(enum, ToplevelResizeEdgeNames)
+}
 
-	return "<invalid ToplevelResizeEdge>"
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelResizeEdge) Since() uint32 {
+	return 1
 }
 
 // The different state values used on the surface. This is designed for
@@ -2121,43 +2632,52 @@ const (
 	// the surface is now activated
 	ToplevelStateActivated ToplevelState = 4
 
+	// Since version 2.
 	ToplevelStateTiledLeft ToplevelState = 5
 
+	// Since version 2.
 	ToplevelStateTiledRight ToplevelState = 6
 
+	// Since version 2.
 	ToplevelStateTiledTop ToplevelState = 7
 
+	// Since version 2.
 	ToplevelStateTiledBottom ToplevelState = 8
 )
 
-func (enum ToplevelState) String() string {
-	switch enum {
-	case 1:
-		return "ToplevelStateMaximized"
-
-	case 2:
-		return "ToplevelStateFullscreen"
-
-	case 3:
-		return "ToplevelStateResizing"
+// ToplevelStateNames maps the values of ToplevelState to their names.
+var ToplevelStateNames = map[ToplevelState]string{
+	ToplevelStateMaximized:   "ToplevelStateMaximized",
+	ToplevelStateFullscreen:  "ToplevelStateFullscreen",
+	ToplevelStateResizing:    "ToplevelStateResizing",
+	ToplevelStateActivated:   "ToplevelStateActivated",
+	ToplevelStateTiledLeft:   "ToplevelStateTiledLeft",
+	ToplevelStateTiledRight:  "ToplevelStateTiledRight",
+	ToplevelStateTiledTop:    "ToplevelStateTiledTop",
+	ToplevelStateTiledBottom: "ToplevelStateTiledBottom",
+}
 
-	case 4:
-		return "ToplevelStateActivated"
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ToplevelState) String() string {
+	return wire.EnumString(enum, ToplevelStateNames)
+}
 
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelState) Since() uint32 {
+	switch enum {
 	case 5:
-		return "ToplevelStateTiledLeft"
-
+		return 2
 	case 6:
-		return "ToplevelStateTiledRight"
-
+		return 2
 	case 7:
-		return "ToplevelStateTiledTop"
-
+		return 2
 	case 8:
-		return "ToplevelStateTiledBottom"
+		return 2
 	}
-
-	return "<invalid ToplevelState>"
+	return 1
 }
 
 type ToplevelWmCapabilities int64
@@ -2176,22 +2696,25 @@ const (
 	ToplevelWmCapabilitiesMinimize ToplevelWmCapabilities = 4
 )
 
-func (enum ToplevelWmCapabilities) String() string {
-	switch enum {
-	case 1:
-		return "ToplevelWmCapabilitiesWindowMenu"
-
-	case 2:
-		return "ToplevelWmCapabilitiesMaximize"
-
-	case 3:
-		return "ToplevelWmCapabilitiesFullscreen"
+// ToplevelWmCapabilitiesNames maps the values of ToplevelWmCapabilities to their names.
+var ToplevelWmCapabilitiesNames = map[ToplevelWmCapabilities]string{
+	ToplevelWmCapabilitiesWindowMenu: "ToplevelWmCapabilitiesWindowMenu",
+	ToplevelWmCapabilitiesMaximize:   "ToplevelWmCapabilitiesMaximize",
+	ToplevelWmCapabilitiesFullscreen: "ToplevelWmCapabilitiesFullscreen",
+	ToplevelWmCapabilitiesMinimize:   "ToplevelWmCapabilitiesMinimize",
+}
 
-	case 4:
-		return "ToplevelWmCapabilitiesMinimize"
-	}
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
+func (enum ToplevelWmCapabilities) String() string {
+	return wire.EnumString(enum, ToplevelWmCapabilitiesNames)
+}
 
-	return "<invalid ToplevelWmCapabilities>"
+// Since returns the version of xdg_toplevel that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum ToplevelWmCapabilities) Since() uint32 {
+	return 1
 }
 
 const (
@@ -2274,6 +2797,25 @@ type PopupListener interface {
 	Reposition(positioner *Positioner, token uint32)
 }
 
+// PopupDestroyRequest holds the arguments of a xdg_popup.destroy
+// request.
+type PopupDestroyRequest struct {
+}
+
+// PopupGrabRequest holds the arguments of a xdg_popup.grab
+// request.
+type PopupGrabRequest struct {
+	Seat   *wl.Seat
+	Serial uint32
+}
+
+// PopupRepositionRequest holds the arguments of a xdg_popup.reposition
+// request.
+type PopupRepositionRequest struct {
+	Positioner *Positioner
+	Token      uint32
+}
+
 // A popup surface is a short-lived, temporary surface. It can be used to
 // implement for example menus, popovers, tooltips and other similar user
 // interface concepts.
@@ -2299,27 +2841,36 @@ type PopupListener interface {
 // The client must call wl_surface.commit on the corresponding wl_surface
 // for the xdg_popup state to take effect.
 type Popup struct {
+	wire.Proxy
+
 	// Listener's methods are called by incoming messages from the
 	// remote end via Dispatch. If it is nil, messages are silently
-	// ignored.
+	// ignored. File descriptors in messages that have no handler
+	// are held by the object and closed when it is deleted.
 	Listener PopupListener
 
-	// OnDelete is called when the object is removed from the tracking
-	// system.
-	OnDelete func()
+	// OnDestroy, if not nil, is called with the arguments of
+	// each incoming destroy request before Listener is.
+	OnDestroy func(PopupDestroyRequest)
 
-	state wire.State
-	id    uint32
+	// OnGrab, if not nil, is called with the arguments of
+	// each incoming grab request before Listener is.
+	OnGrab func(PopupGrabRequest)
+
+	// OnReposition, if not nil, is called with the arguments of
+	// each incoming reposition request before Listener is.
+	OnReposition func(PopupRepositionRequest)
 }
 
+var (
+	_ wire.Object      = (*Popup)(nil)
+	_ wire.DebugObject = (*Popup)(nil)
+)
+
 // NewPopup returns a newly instantiated Popup. It is
 // primarily intended for use by generated code.
 func NewPopup(state wire.State) *Popup {
-	return &Popup{state: state}
-}
-
-func (obj *Popup) State() wire.State {
-	return obj.state
+	return &Popup{Proxy: wire.NewProxy(state)}
 }
 
 func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
@@ -2329,6 +2880,9 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnDestroy != nil {
+			obj.OnDestroy(PopupDestroyRequest{})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2337,9 +2891,7 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 1:
 
-		seat, _ := obj.state.Get(msg.ReadUint()).(*wl.Seat)
-
-		obj.state.Add(seat)
+		seat, _ := obj.State().Get(msg.ReadUint()).(*wl.Seat)
 
 		serial := msg.ReadUint()
 
@@ -2347,6 +2899,12 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnGrab != nil {
+			obj.OnGrab(PopupGrabRequest{
+				Seat:   seat,
+				Serial: serial,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2358,9 +2916,7 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 
 	case 2:
 
-		positioner, _ := obj.state.Get(msg.ReadUint()).(*Positioner)
-
-		obj.state.Add(positioner)
+		positioner, _ := obj.State().Get(msg.ReadUint()).(*Positioner)
 
 		token := msg.ReadUint()
 
@@ -2368,6 +2924,12 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 			return err
 		}
 
+		if obj.OnReposition != nil {
+			obj.OnReposition(PopupRepositionRequest{
+				Positioner: positioner,
+				Token:      token,
+			})
+		}
 		if obj.Listener == nil {
 			return nil
 		}
@@ -2385,22 +2947,8 @@ func (obj *Popup) Dispatch(msg *wire.MessageBuffer) error {
 	}
 }
 
-func (obj *Popup) ID() uint32 {
-	return obj.id
-}
-
-func (obj *Popup) SetID(id uint32) {
-	obj.id = id
-}
-
-func (obj *Popup) Delete() {
-	if obj.OnDelete != nil {
-		obj.OnDelete()
-	}
-}
-
 func (obj *Popup) String() string {
-	return fmt.Sprintf("%v(%v)", "xdg_popup", obj.id)
+	return fmt.Sprintf("%v@%v", "xdg_popup", obj.ID())
 }
 
 func (obj *Popup) MethodName(op uint16) string {
@@ -2422,7 +2970,19 @@ func (obj *Popup) Interface() string {
 	return PopupInterface
 }
 
+// Version returns the version that obj was bound or created with.
+// If obj has not been given a version yet, it returns
+// PopupVersion, the same as MaxVersion.
 func (obj *Popup) Version() uint32 {
+	if v := obj.Proxy.Version(); v != 0 {
+		return v
+	}
+	return PopupVersion
+}
+
+// MaxVersion returns PopupVersion, the highest version of
+// xdg_popup that is supported.
+func (obj *Popup) MaxVersion() uint32 {
 	return PopupVersion
 }
 
@@ -2448,7 +3008,7 @@ func (obj *Popup) Configure(x int32, y int32, width int32, height int32) {
 
 	builder.Method = "configure"
 	builder.Args = []any{x, y, width, height}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2460,7 +3020,7 @@ func (obj *Popup) PopupDone() {
 
 	builder.Method = "popup_done"
 	builder.Args = []any{}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2486,7 +3046,7 @@ func (obj *Popup) Repositioned(token uint32) {
 
 	builder.Method = "repositioned"
 	builder.Args = []any{token}
-	obj.state.Enqueue(builder)
+	obj.State().Enqueue(builder)
 	return
 }
 
@@ -2497,11 +3057,20 @@ const (
 	PopupErrorInvalidGrab PopupError = 0
 )
 
+// PopupErrorNames maps the values of PopupError to their names.
+var PopupErrorNames = map[PopupError]string{
+	PopupErrorInvalidGrab: "PopupErrorInvalidGrab",
+}
+
+// String returns the name of enum, or its numeric value if it
+// doesn't have one.
 func (enum PopupError) String() string {
-	switch enum {
-	case 0:
-		return "PopupErrorInvalidGrab"
-	}
+	return wire.EnumString(enum, PopupErrorNames)
+}
 
-	return "<invalid PopupError>"
+// Since returns the version of xdg_popup that introduced
+// the value of enum. Sending a value to an object bound at an
+// earlier version is a protocol error.
+func (enum PopupError) Since() uint32 {
+	return 1
 }
@@ -0,0 +1,7 @@
+// Package xdg provides bindings for the xdg-shell protocol, which is
+// used to turn surfaces into desktop-style windows and popups. The
+// client and server subpackages contain the bindings for each end of
+// the protocol.
+package xdg
+
+//go:generate go run deedles.dev/wl/cmd/wlgen -role both -xml xdg-shell.xml -out protocol.go